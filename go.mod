@@ -11,14 +11,22 @@ require (
 	k8s.io/klog/v2 v2.110.1
 )
 
-require github.com/jarcoal/httpmock v1.3.0
+require (
+	github.com/hajimehoshi/go-mp3 v0.3.4
+	github.com/jarcoal/httpmock v1.3.0
+	github.com/mewkiz/flac v1.0.8
+	github.com/pion/opus v0.0.0-20230123082803-1052c3e89e58
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/fatih/color v1.15.0 // indirect
 	github.com/go-logr/logr v1.3.0 // indirect
 	github.com/google/go-cmp v0.5.9 // indirect
+	github.com/icza/bitio v1.1.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.17 // indirect
+	github.com/mewkiz/pkg v0.0.0-20230226050401-4010bf0fec14 // indirect
 	github.com/youpy/go-riff v0.1.0 // indirect
 	github.com/zaf/g711 v0.0.0-20190814101024-76a4a538f52b // indirect
 	golang.org/x/sys v0.6.0 // indirect
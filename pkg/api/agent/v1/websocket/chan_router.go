@@ -5,6 +5,7 @@
 package websocketv1
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"os"
@@ -14,6 +15,8 @@ import (
 	klog "k8s.io/klog/v2"
 
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/agent/v1/websocket/interfaces"
+	delivery "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1/delivery"
+	clientinterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
 )
 
 // NewWithDefault creates a ChanRouter with the default callback handler
@@ -32,6 +35,12 @@ func NewChanWithDefault() *ChanRouter {
 // New creates a ChanRouter with a user-defined channels
 // gocritic:ignore
 func NewChanRouter(chans interfaces.AgentMessageChan) *ChanRouter {
+	return NewChanRouterWithOptions(context.Background(), chans, nil)
+}
+
+// NewChanRouterWithOptions creates a ChanRouter which applies the delivery options to every channel.
+// Blocked sends are abandoned once ctx is done.
+func NewChanRouterWithOptions(ctx context.Context, chans interfaces.AgentMessageChan, opts *clientinterfaces.ChanDeliveryOptions) *ChanRouter {
 	var debugStr string
 	if v := os.Getenv("DEEPGRAM_DEBUG"); v != "" {
 		klog.V(4).Infof("DEEPGRAM_DEBUG found")
@@ -40,6 +49,7 @@ func NewChanRouter(chans interfaces.AgentMessageChan) *ChanRouter {
 
 	router := &ChanRouter{
 		debugWebsocket:               strings.EqualFold(strings.ToLower(debugStr), "true"),
+		dispatcher:                   delivery.New(ctx, opts),
 		binaryChan:                   make([]*chan *[]byte, 0),
 		openChan:                     make([]*chan *interfaces.OpenResponse, 0),
 		welcomeResponse:              make([]*chan *interfaces.WelcomeResponse, 0),
@@ -75,6 +85,8 @@ func NewChanRouter(chans interfaces.AgentMessageChan) *ChanRouter {
 		router.settingsAppliedResponse = append(router.settingsAppliedResponse, chans.GetSettingsApplied()...)
 	}
 
+	router.events = delivery.NewHub[interfaces.Event](router.dispatcher)
+
 	return router
 }
 
@@ -94,8 +106,11 @@ func (r *ChanRouter) Open(or *interfaces.OpenResponse) error {
 		}
 
 		for _, ch := range r.openChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeOpenResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeOpenResponse), Open: &msg})
 		return nil
 	}
 
@@ -118,8 +133,11 @@ func (r *ChanRouter) Close(cr *interfaces.CloseResponse) error {
 		}
 
 		for _, ch := range r.closeChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeCloseResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeCloseResponse), Close: &msg})
 		return nil
 	}
 
@@ -142,8 +160,11 @@ func (r *ChanRouter) Error(er *interfaces.ErrorResponse) error {
 		}
 
 		for _, ch := range r.errorChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeErrorResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeErrorResponse), Error: &msg})
 		return nil
 	}
 
@@ -176,8 +197,11 @@ func (r *ChanRouter) processWelcome(byMsg []byte) error {
 		}
 
 		for _, ch := range r.welcomeResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeWelcomeResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeWelcomeResponse), Welcome: &msg})
 		return nil
 	}
 
@@ -193,8 +217,11 @@ func (r *ChanRouter) processConversationText(byMsg []byte) error {
 		}
 
 		for _, ch := range r.conversationTextResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeConversationTextResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeConversationTextResponse), ConversationText: &msg})
 		return nil
 	}
 
@@ -210,8 +237,11 @@ func (r *ChanRouter) processUserStartedSpeaking(byMsg []byte) error {
 		}
 
 		for _, ch := range r.userStartedSpeakingResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeUserStartedSpeakingResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeUserStartedSpeakingResponse), UserStartedSpeaking: &msg})
 		return nil
 	}
 
@@ -227,8 +257,11 @@ func (r *ChanRouter) processAgentThinking(byMsg []byte) error {
 		}
 
 		for _, ch := range r.agentThinkingResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeAgentThinkingResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeAgentThinkingResponse), AgentThinking: &msg})
 		return nil
 	}
 
@@ -244,8 +277,11 @@ func (r *ChanRouter) processFunctionCallRequest(byMsg []byte) error {
 		}
 
 		for _, ch := range r.functionCallRequestResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeFunctionCallRequestResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeFunctionCallRequestResponse), FunctionCallRequest: &msg})
 		return nil
 	}
 
@@ -261,8 +297,11 @@ func (r *ChanRouter) processAgentStartedSpeaking(byMsg []byte) error {
 		}
 
 		for _, ch := range r.agentStartedSpeakingResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeAgentStartedSpeakingResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeAgentStartedSpeakingResponse), AgentStartedSpeaking: &msg})
 		return nil
 	}
 
@@ -278,8 +317,11 @@ func (r *ChanRouter) processAgentAudioDone(byMsg []byte) error {
 		}
 
 		for _, ch := range r.agentAudioDoneResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeAgentAudioDoneResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeAgentAudioDoneResponse), AgentAudioDone: &msg})
 		return nil
 	}
 
@@ -295,8 +337,11 @@ func (r *ChanRouter) processErrorResponse(byMsg []byte) error {
 		}
 
 		for _, ch := range r.errorChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeErrorResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeErrorResponse), Error: &msg})
 		return nil
 	}
 
@@ -312,8 +357,11 @@ func (r *ChanRouter) processInjectionRefused(byMsg []byte) error {
 		}
 
 		for _, ch := range r.injectionRefusedResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeInjectionRefusedResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeInjectionRefusedResponse), InjectionRefused: &msg})
 		return nil
 	}
 
@@ -329,8 +377,11 @@ func (r *ChanRouter) processKeepAlive(byMsg []byte) error {
 		}
 
 		for _, ch := range r.keepAliveResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeKeepAlive), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeKeepAlive), KeepAlive: &msg})
 		return nil
 	}
 
@@ -346,8 +397,11 @@ func (r *ChanRouter) processSettingsApplied(byMsg []byte) error {
 		}
 
 		for _, ch := range r.settingsAppliedResponse {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeSettingsAppliedResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeSettingsAppliedResponse), SettingsApplied: &msg})
 		return nil
 	}
 
@@ -412,8 +466,13 @@ func (r *ChanRouter) Binary(byMsg []byte) error {
 
 	klog.V(5).Infof("Binary Message:\n%s...\n", hex.EncodeToString(byMsg[:20]))
 	for _, ch := range r.binaryChan {
-		*ch <- &byMsg
+		if err := delivery.Send(r.dispatcher, delivery.MessageTypeBinary, ch, &byMsg); err != nil {
+			klog.V(1).Infof("router.Binary delivery failed. Err: %v\n", err)
+			klog.V(6).Infof("router.Binary LEAVE\n")
+			return err
+		}
 	}
+	r.events.Publish(interfaces.Event{Type: delivery.MessageTypeBinary, Binary: byMsg})
 
 	klog.V(6).Infof("router.Binary LEAVE\n")
 	return nil
@@ -425,14 +484,28 @@ func (r *ChanRouter) UnhandledMessage(byMsg []byte) error {
 	r.printDebugMessages(3, "UnhandledMessage", byMsg)
 
 	for _, ch := range r.unhandledChan {
-		*ch <- &byMsg
+		if err := delivery.Send(r.dispatcher, delivery.MessageTypeUnhandled, ch, &byMsg); err != nil {
+			break
+		}
 	}
+	r.events.Publish(interfaces.Event{Type: delivery.MessageTypeUnhandled, Unhandled: byMsg})
 
 	klog.V(1).Infof("Unknown Event was received\n")
 	klog.V(6).Infof("router.UnhandledMessage LEAVE\n")
 	return ErrInvalidMessageType
 }
 
+// Subscribe adds a subscriber which receives the events matching filter, see interfaces.EventFilter.
+// Call cancel to unsubscribe, which closes the channel.
+func (r *ChanRouter) Subscribe(filter *interfaces.EventFilter) (<-chan interfaces.Event, func()) {
+	return r.events.Subscribe(filter.Match, defaultSubscriberBuffer)
+}
+
+// DeliveryStats returns the delivery counters for each channel, keyed by message type and index
+func (r *ChanRouter) DeliveryStats() map[string]delivery.Stats {
+	return r.dispatcher.Stats()
+}
+
 // printDebugMessages formats and logs debugging messages
 func (r *ChanRouter) printDebugMessages(level klog.Level, function string, byMsg []byte) {
 	prettyJSON, err := prettyjson.Format(byMsg)
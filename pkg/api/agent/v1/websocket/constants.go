@@ -10,6 +10,11 @@ const (
 	PackageVersion string = "v1.0"
 )
 
+const (
+	// channel buffer for each Subscribe subscriber
+	defaultSubscriberBuffer int = 100
+)
+
 var (
 	// ErrInvalidMessageType invalid message type
 	ErrInvalidMessageType = errors.New("invalid message type")
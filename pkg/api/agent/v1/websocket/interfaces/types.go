@@ -143,3 +143,59 @@ type InjectionRefusedResponse struct {
 type SettingsAppliedResponse struct {
 	Type string `json:"type,omitempty"`
 }
+
+/***********************************/
+// Subscriptions
+/***********************************/
+// Event is a single message from the websocket. Type is the message type and only the matching field is set.
+type Event struct {
+	Type string
+
+	Open                 *OpenResponse
+	Welcome              *WelcomeResponse
+	ConversationText     *ConversationTextResponse
+	UserStartedSpeaking  *UserStartedSpeakingResponse
+	AgentThinking        *AgentThinkingResponse
+	FunctionCallRequest  *FunctionCallRequestResponse
+	AgentStartedSpeaking *AgentStartedSpeakingResponse
+	AgentAudioDone       *AgentAudioDoneResponse
+	InjectionRefused     *InjectionRefusedResponse
+	KeepAlive            *KeepAlive
+	SettingsApplied      *SettingsAppliedResponse
+	Close                *CloseResponse
+	Error                *ErrorResponse
+	Binary               []byte // agent audio, Type is "Binary"
+	Unhandled            []byte
+}
+
+// EventFilter selects the events a subscriber receives. A nil or empty filter receives everything.
+type EventFilter struct {
+	Types []string // message types, e.g. TypeConversationTextResponse or "Binary"
+	Role  string   // only ConversationText from this role, e.g. "user" or "assistant"
+}
+
+// Match reports whether the event passes the filter
+func (f *EventFilter) Match(e Event) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if t == e.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.Role != "" && e.ConversationText != nil && e.ConversationText.Role != f.Role {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,397 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package provides an http.Handler which issues short-lived access tokens.
+
+Browser and mobile apps should never hold an API key. Mount a Broker on your backend, authenticate
+the app's own session in an AuthorizeFunc, and the app can fetch a token just before opening a
+streaming connection:
+
+	b, err := broker.New(authClient, broker.Options{
+		Authorize: func(r *http.Request) (*broker.Caller, error) {
+			user, err := sessions.User(r)
+			if err != nil {
+				return nil, err
+			}
+			return &broker.Caller{ID: user.ID}, nil
+		},
+		RateLimit:      0.2,
+		Burst:          3,
+		AllowedOrigins: []string{"https://app.example.com"},
+	})
+	http.Handle("/deepgram/token", b)
+*/
+package broker
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	auth "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/auth/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/auth/v1/interfaces"
+)
+
+// New creates a Broker which issues tokens using the given client. The client must be authenticated with an API key.
+func New(c *auth.Client, opts Options) (*Broker, error) {
+	if c == nil {
+		klog.V(1).Infof("broker.New: client is required\n")
+		return nil, ErrInvalidInput
+	}
+	return NewWithGrant(c.GrantToken, opts)
+}
+
+// NewWithGrant creates a Broker using a custom grant function
+func NewWithGrant(grant GrantFunc, opts Options) (*Broker, error) {
+	klog.V(6).Infof("broker.New ENTER\n")
+
+	if grant == nil || opts.Authorize == nil {
+		klog.V(1).Infof("broker.New: grant and Authorize are required\n")
+		klog.V(6).Infof("broker.New LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+
+	// a wildcard with credentials would let any site mint tokens with a logged in user's cookies
+	if opts.AllowCredentials && hasWildcard(opts.AllowedOrigins) {
+		klog.V(1).Infof("broker.New: %v\n", ErrWildcardCredentials)
+		klog.V(6).Infof("broker.New LEAVE\n")
+		return nil, ErrWildcardCredentials
+	}
+
+	if opts.TTLSeconds <= 0 {
+		opts.TTLSeconds = defaultTTLSeconds
+	}
+	if opts.TTLSeconds > maxTTLSeconds {
+		opts.TTLSeconds = maxTTLSeconds
+	}
+	if opts.MaxTTLSeconds <= 0 {
+		opts.MaxTTLSeconds = opts.TTLSeconds
+	}
+	if opts.MaxTTLSeconds > maxTTLSeconds {
+		opts.MaxTTLSeconds = maxTTLSeconds
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = 1
+	}
+	if len(opts.AllowedHeaders) == 0 {
+		opts.AllowedHeaders = defaultAllowedHeaders
+	}
+	if opts.Audit == nil {
+		opts.Audit = logAudit
+	}
+
+	b := &Broker{
+		grant:     grant,
+		opts:      opts,
+		limiters:  make(map[string]*bucket),
+		cache:     make(map[string]cachedToken),
+		lastSweep: time.Now(),
+	}
+
+	klog.V(3).Infof("broker.New Succeeded\n")
+	klog.V(6).Infof("broker.New LEAVE\n")
+
+	return b, nil
+}
+
+// ServeHTTP implements http.Handler
+func (b *Broker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	klog.V(6).Infof("broker.ServeHTTP ENTER\n")
+	defer klog.V(6).Infof("broker.ServeHTTP LEAVE\n")
+
+	event := AuditEvent{
+		Time:       time.Now(),
+		RemoteAddr: req.RemoteAddr,
+		Origin:     req.Header.Get("Origin"),
+	}
+
+	// a disallowed origin is refused before Authorize, so it can't mint a token the browser then hides
+	if answered, allowed := b.cors(w, req); answered {
+		if !allowed {
+			event.Outcome = OutcomeForbidden
+			event.Error = ErrOriginNotAllowed.Error()
+			b.opts.Audit(event)
+		}
+		return
+	}
+
+	if req.Method != http.MethodPost && (req.Method != http.MethodGet || !b.opts.AllowGet) {
+		w.Header().Set("Allow", b.allowedMethods())
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	caller, err := b.opts.Authorize(req)
+	if err == nil && caller == nil {
+		err = ErrUnauthorized
+	}
+	if err != nil {
+		event.Error = err.Error()
+		if errors.Is(err, ErrForbidden) {
+			event.Outcome = OutcomeForbidden
+			b.opts.Audit(event)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		event.Outcome = OutcomeUnauthorized
+		b.opts.Audit(event)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	callerID := caller.ID
+	if callerID == "" {
+		callerID = remoteHost(req.RemoteAddr)
+	}
+	event.CallerID = callerID
+
+	if wait, ok := b.allow(callerID); !ok {
+		event.Outcome = OutcomeRateLimited
+		b.opts.Audit(event)
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+		return
+	}
+
+	ttl := b.opts.TTLSeconds
+	if caller.TTLSeconds > 0 {
+		ttl = caller.TTLSeconds
+		if ttl > b.opts.MaxTTLSeconds {
+			ttl = b.opts.MaxTTLSeconds
+		}
+	}
+	event.TTLSeconds = ttl
+
+	key := callerID + "/" + strconv.Itoa(ttl)
+	if token, ok := b.cached(key, ttl); ok {
+		event.Outcome = OutcomeCached
+		event.ExpiresAt = token.expiry
+		b.opts.Audit(event)
+		writeToken(w, token.token, time.Until(token.expiry))
+		return
+	}
+
+	resp, err := b.grant(req.Context(), &api.GrantTokenRequest{TTLSeconds: &ttl})
+	if err == nil && (resp == nil || resp.AccessToken == "") {
+		err = auth.ErrNoAccessToken
+	}
+	if err != nil {
+		klog.V(1).Infof("GrantToken failed. Err: %v\n", err)
+		event.Outcome = OutcomeFailed
+		event.Error = err.Error()
+		b.opts.Audit(event)
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
+	lifetime := time.Duration(resp.ExpiresIn * float64(time.Second))
+	if lifetime <= 0 {
+		lifetime = time.Duration(ttl) * time.Second
+	}
+	expiry := time.Now().Add(lifetime)
+	if b.opts.Cache {
+		b.mu.Lock()
+		b.cache[key] = cachedToken{token: resp.AccessToken, expiry: expiry}
+		b.mu.Unlock()
+	}
+
+	event.Outcome = OutcomeGranted
+	event.ExpiresAt = expiry
+	b.opts.Audit(event)
+
+	writeToken(w, resp.AccessToken, lifetime)
+}
+
+/*
+cors sets the CORS headers, answers preflight requests and refuses any request from an origin which is not allowed.
+It returns whether the request was answered, and whether the origin was allowed.
+*/
+func (b *Broker) cors(w http.ResponseWriter, req *http.Request) (bool, bool) {
+	origin := req.Header.Get("Origin")
+	preflight := req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != ""
+
+	if origin == "" || len(b.opts.AllowedOrigins) == 0 {
+		if preflight {
+			w.WriteHeader(http.StatusNoContent)
+		}
+		return preflight, true
+	}
+
+	w.Header().Add("Vary", "Origin")
+	allowed, wildcard := b.originAllowed(origin)
+	if !allowed {
+		klog.V(4).Infof("Origin %s is not allowed\n", origin)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return true, false
+	}
+
+	// only a listed origin is echoed, and only it can be sent credentials
+	if wildcard {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	if b.opts.AllowCredentials && !wildcard {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if !preflight {
+		return false, true
+	}
+
+	w.Header().Set("Access-Control-Allow-Methods", b.allowedMethods())
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(b.opts.AllowedHeaders, ", "))
+	if b.opts.CORSMaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(b.opts.CORSMaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	return true, true
+}
+
+// allowedMethods lists the methods for the Allow and Access-Control-Allow-Methods headers
+func (b *Broker) allowedMethods() string {
+	if b.opts.AllowGet {
+		return "GET, POST, OPTIONS"
+	}
+	return "POST, OPTIONS"
+}
+
+// originAllowed reports whether the origin may call the broker, and whether it only matched "*"
+func (b *Broker) originAllowed(origin string) (bool, bool) {
+	for _, allowed := range b.opts.AllowedOrigins {
+		if strings.EqualFold(allowed, origin) {
+			return true, false
+		}
+	}
+	if hasWildcard(b.opts.AllowedOrigins) {
+		return true, true
+	}
+	return false, false
+}
+
+// allow takes a token from the caller's bucket, or returns how long until one is available
+func (b *Broker) allow(callerID string) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.sweep(now)
+
+	if b.opts.RateLimit <= 0 {
+		return 0, true
+	}
+
+	burst := float64(b.opts.Burst)
+	lim, ok := b.limiters[callerID]
+	if !ok {
+		lim = &bucket{tokens: burst, last: now}
+		b.limiters[callerID] = lim
+	}
+
+	lim.tokens += now.Sub(lim.last).Seconds() * b.opts.RateLimit
+	if lim.tokens > burst {
+		lim.tokens = burst
+	}
+	lim.last = now
+
+	if lim.tokens < 1 {
+		return time.Duration((1 - lim.tokens) / b.opts.RateLimit * float64(time.Second)), false
+	}
+	lim.tokens--
+
+	return 0, true
+}
+
+// cached returns a cached token with enough lifetime left
+func (b *Broker) cached(key string, ttl int) (cachedToken, bool) {
+	if !b.opts.Cache {
+		return cachedToken{}, false
+	}
+
+	minRemaining := b.opts.CacheMinRemaining
+	if minRemaining <= 0 {
+		minRemaining = time.Duration(ttl) * time.Second / 2
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	token, ok := b.cache[key]
+	if !ok || time.Until(token.expiry) < minRemaining {
+		return cachedToken{}, false
+	}
+	return token, true
+}
+
+// sweep drops full rate limit buckets and expired tokens. The caller holds the lock.
+func (b *Broker) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < sweepInterval {
+		return
+	}
+	b.lastSweep = now
+
+	for id, lim := range b.limiters {
+		if lim.tokens+now.Sub(lim.last).Seconds()*b.opts.RateLimit >= float64(b.opts.Burst) {
+			delete(b.limiters, id)
+		}
+	}
+	for key, token := range b.cache {
+		if now.After(token.expiry) {
+			delete(b.cache, key)
+		}
+	}
+}
+
+/*
+helpers
+*/
+func writeToken(w http.ResponseWriter, token string, remaining time.Duration) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	err := json.NewEncoder(w).Encode(Response{
+		AccessToken: token,
+		ExpiresIn:   math.Floor(remaining.Seconds()),
+	})
+	if err != nil {
+		klog.V(1).Infof("json.NewEncoder().Encode() failed. Err: %v\n", err)
+	}
+}
+
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+func logAudit(event AuditEvent) {
+	if event.Error != "" {
+		klog.V(3).Infof("broker: %s caller=%q remote=%s origin=%q err=%q\n",
+			event.Outcome, event.CallerID, event.RemoteAddr, event.Origin, event.Error)
+		return
+	}
+	klog.V(3).Infof("broker: %s caller=%q remote=%s origin=%q ttl=%d expires=%s\n",
+		event.Outcome, event.CallerID, event.RemoteAddr, event.Origin, event.TTLSeconds, event.ExpiresAt.Format(time.RFC3339))
+}
+
+func hasWildcard(origins []string) bool {
+	for _, origin := range origins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
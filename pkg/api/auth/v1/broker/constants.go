@@ -0,0 +1,54 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package broker
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// Outcome is the result of a token request recorded in the audit log
+type Outcome string
+
+const (
+	OutcomeGranted      Outcome = "granted"
+	OutcomeCached       Outcome = "cached"
+	OutcomeUnauthorized Outcome = "unauthorized"
+	OutcomeForbidden    Outcome = "forbidden"
+	OutcomeRateLimited  Outcome = "rate_limited"
+	OutcomeFailed       Outcome = "failed"
+)
+
+const (
+	defaultTTLSeconds int = 30
+	maxTTLSeconds     int = 3600
+
+	// idle rate limit buckets and expired cache entries are dropped this often
+	sweepInterval = time.Minute
+)
+
+var defaultAllowedHeaders = []string{"Authorization", "Content-Type"}
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrUnauthorized the caller could not be authenticated
+	ErrUnauthorized = errors.New("caller is not authenticated")
+
+	// ErrForbidden the caller is authenticated but may not have a token. Return it from an AuthorizeFunc for a 403.
+	ErrForbidden = errors.New("caller may not request a token")
+
+	// ErrOriginNotAllowed the request's Origin is not in AllowedOrigins
+	ErrOriginNotAllowed = errors.New("origin is not allowed")
+
+	// ErrWildcardCredentials AllowedOrigins "*" can't be combined with AllowCredentials
+	ErrWildcardCredentials = errors.New(`AllowedOrigins "*" can't be used with AllowCredentials`)
+)
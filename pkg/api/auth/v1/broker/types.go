@@ -0,0 +1,111 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package broker
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/auth/v1/interfaces"
+)
+
+// Caller is an authenticated client of the broker
+type Caller struct {
+	// ID identifies the caller for rate limiting, caching and auditing
+	ID string
+
+	// TTLSeconds optionally overrides Options.TTLSeconds for this caller, capped at Options.MaxTTLSeconds
+	TTLSeconds int
+}
+
+// AuthorizeFunc authenticates a token request. Return ErrForbidden (or an error wrapping it)
+// to reject with a 403, any other error rejects with a 401.
+type AuthorizeFunc func(r *http.Request) (*Caller, error)
+
+// GrantFunc obtains a new access token, usually auth.Client.GrantToken
+type GrantFunc func(ctx context.Context, req *api.GrantTokenRequest) (*api.GrantToken, error)
+
+// AuditEvent records a single token request
+type AuditEvent struct {
+	Time       time.Time `json:"time"`
+	CallerID   string    `json:"caller_id,omitempty"`
+	RemoteAddr string    `json:"remote_addr,omitempty"`
+	Origin     string    `json:"origin,omitempty"`
+	Outcome    Outcome   `json:"outcome"`
+	TTLSeconds int       `json:"ttl_seconds,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AuditFunc receives every AuditEvent. It is called synchronously, so it should not block.
+type AuditFunc func(event AuditEvent)
+
+// Options configures a Broker
+type Options struct {
+	// Authorize is required and authenticates each request
+	Authorize AuthorizeFunc
+
+	// TTLSeconds is the lifetime of issued tokens. Defaults to 30.
+	TTLSeconds int
+	// MaxTTLSeconds caps Caller.TTLSeconds. Defaults to TTLSeconds.
+	MaxTTLSeconds int
+
+	// RateLimit is the number of tokens per second each caller may request, 0 for unlimited
+	RateLimit float64
+	// Burst is the number of requests a caller may make at once. Defaults to 1.
+	Burst int
+
+	// AllowGet also issues tokens for GET requests. Tokens are only issued for POST by default,
+	// since any page can make a GET with the user's cookies.
+	AllowGet bool
+
+	// AllowedOrigins for CORS. "*" allows any origin, without credentials. No CORS headers are sent when empty.
+	// When set, a request from any other origin is refused with a 403 before Authorize is called.
+	AllowedOrigins []string
+	// AllowCredentials allows cookies and HTTP auth on cross origin requests from the listed origins
+	AllowCredentials bool
+	// AllowedHeaders for CORS preflight requests. Defaults to Authorization and Content-Type.
+	AllowedHeaders []string
+	// CORSMaxAge is how long browsers may cache a preflight response
+	CORSMaxAge time.Duration
+
+	// Cache reuses a caller's token while it has at least CacheMinRemaining left
+	Cache bool
+	// CacheMinRemaining defaults to half of the token lifetime
+	CacheMinRemaining time.Duration
+
+	// Audit receives a record of every request. Defaults to logging.
+	Audit AuditFunc
+}
+
+// Response is the JSON body returned to the caller
+type Response struct {
+	AccessToken string  `json:"access_token"`
+	ExpiresIn   float64 `json:"expires_in"`
+}
+
+// Broker is an http.Handler which issues short-lived access tokens
+type Broker struct {
+	grant GrantFunc
+	opts  Options
+
+	mu        sync.Mutex
+	limiters  map[string]*bucket
+	cache     map[string]cachedToken
+	lastSweep time.Time
+}
+
+type cachedToken struct {
+	token  string
+	expiry time.Time
+}
+
+// bucket is a token bucket rate limiter for a single caller
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
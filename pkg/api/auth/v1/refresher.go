@@ -0,0 +1,163 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/auth/v1/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+)
+
+const (
+	// defaultTokenTTL is the lifetime of a token when the platform does not say
+	defaultTokenTTL = 30 * time.Second
+)
+
+// ErrNoAccessToken the grant returned no access token
+var ErrNoAccessToken = errors.New("grant returned no access token")
+
+// GrantFunc obtains a new access token, usually Client.GrantToken
+type GrantFunc func(ctx context.Context) (*api.GrantToken, error)
+
+// TokenRefresherOptions controls a TokenRefresher
+type TokenRefresherOptions struct {
+	TTLSeconds    *int                                 // requested lifetime of each token, nil for the platform default
+	RefreshBefore time.Duration                        // fetch a new token this long before expiry, defaults to a fifth of the lifetime
+	OnRefresh     func(token string, expiry time.Time) // called after each new token is obtained
+}
+
+/*
+TokenRefresher is an interfaces.TokenSource which obtains access tokens using GrantToken.
+
+A new token is fetched lazily once the current one is within RefreshBefore of expiring.
+Concurrent callers share a single in-flight grant and the token is swapped atomically.
+
+	options := &interfaces.ClientOptions{
+		TokenSource: auth.NewTokenRefresher(authClient, nil),
+	}
+*/
+type TokenRefresher struct {
+	grant GrantFunc
+	opts  TokenRefresherOptions
+
+	current atomic.Pointer[grantedToken]
+	mu      sync.Mutex
+}
+
+type grantedToken struct {
+	token     string
+	refreshAt time.Time
+	expiry    time.Time
+}
+
+// NewTokenRefresher creates a TokenRefresher which calls GrantToken on the given client.
+// The grant is authenticated with the client's API key, so the client may share ClientOptions with the refresher.
+func NewTokenRefresher(c *Client, opts *TokenRefresherOptions) *TokenRefresher {
+	if opts == nil {
+		opts = &TokenRefresherOptions{}
+	}
+	ttl := opts.TTLSeconds
+
+	return NewTokenRefresherWithGrant(func(ctx context.Context) (*api.GrantToken, error) {
+		var req *api.GrantTokenRequest
+		if ttl != nil {
+			req = &api.GrantTokenRequest{TTLSeconds: ttl}
+		}
+		return c.GrantToken(interfaces.WithAPIKey(ctx), req)
+	}, opts)
+}
+
+// NewTokenRefresherWithGrant creates a TokenRefresher using a custom grant function
+func NewTokenRefresherWithGrant(grant GrantFunc, opts *TokenRefresherOptions) *TokenRefresher {
+	if opts == nil {
+		opts = &TokenRefresherOptions{}
+	}
+
+	return &TokenRefresher{
+		grant: grant,
+		opts:  *opts,
+	}
+}
+
+// Token returns the current access token, fetching a new one if it is about to expire
+func (r *TokenRefresher) Token(ctx context.Context) (string, error) {
+	if t := r.current.Load(); t != nil && time.Now().Before(t.refreshAt) {
+		return t.token, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// another caller may have refreshed while we waited
+	now := time.Now()
+	t := r.current.Load()
+	if t != nil && now.Before(t.refreshAt) {
+		return t.token, nil
+	}
+
+	klog.V(6).Infof("auth.TokenRefresher.Token() ENTER\n")
+
+	resp, err := r.grant(ctx)
+	if err == nil && (resp == nil || resp.AccessToken == "") {
+		err = ErrNoAccessToken
+	}
+	if err != nil {
+		// keep using a token which has not actually expired yet
+		if t != nil && now.Before(t.expiry) {
+			klog.V(1).Infof("GrantToken failed. Using current token until it expires. Err: %v\n", err)
+			klog.V(6).Infof("auth.TokenRefresher.Token() LEAVE\n")
+			return t.token, nil
+		}
+		klog.V(1).Infof("GrantToken failed. Err: %v\n", err)
+		klog.V(6).Infof("auth.TokenRefresher.Token() LEAVE\n")
+		return "", err
+	}
+
+	lifetime := time.Duration(resp.ExpiresIn * float64(time.Second))
+	if lifetime <= 0 {
+		lifetime = defaultTokenTTL
+	}
+	before := r.opts.RefreshBefore
+	if before <= 0 || before >= lifetime {
+		before = lifetime / 5
+	}
+
+	t = &grantedToken{
+		token:     resp.AccessToken,
+		refreshAt: now.Add(lifetime - before),
+		expiry:    now.Add(lifetime),
+	}
+	r.current.Store(t)
+
+	if r.opts.OnRefresh != nil {
+		r.opts.OnRefresh(t.token, t.expiry)
+	}
+
+	klog.V(3).Infof("auth.TokenRefresher.Token() Succeeded. Expires in %v\n", lifetime)
+	klog.V(6).Infof("auth.TokenRefresher.Token() LEAVE\n")
+
+	return t.token, nil
+}
+
+// Invalidate discards the current token so the next call to Token fetches a new one
+func (r *TokenRefresher) Invalidate() {
+	klog.V(4).Infof("auth.TokenRefresher: token invalidated\n")
+	r.current.Store(nil)
+}
+
+// Expiry returns when the current token expires, or the zero time if there is none
+func (r *TokenRefresher) Expiry() time.Time {
+	if t := r.current.Load(); t != nil {
+		return t.expiry
+	}
+	return time.Time{}
+}
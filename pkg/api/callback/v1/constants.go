@@ -0,0 +1,61 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package callback
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// Kind is the type of result delivered to a callback
+type Kind string
+
+const (
+	KindTranscription Kind = "transcription"
+	KindAnalyze       Kind = "analyze"
+	KindSpeak         Kind = "speak"
+	KindError         Kind = "error"
+)
+
+// headers which may carry the request ID for audio (speak) deliveries
+var requestIDHeaders = []string{
+	"dg-request-id",
+	"request-id",
+	"x-request-id",
+}
+
+const (
+	defaultMaxBodySize  int64 = 100 * 1024 * 1024
+	defaultDedupeWindow       = 30 * time.Minute
+	defaultAwaitTimeout       = 15 * time.Minute
+)
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrUnauthorized the delivery failed verification
+	ErrUnauthorized = errors.New("callback delivery is not authorized")
+
+	// ErrUnknownPayload the body could not be recognized as a Deepgram result
+	ErrUnknownPayload = errors.New("unrecognized callback payload")
+
+	// ErrNoRequestID the submission did not return a request ID to correlate with
+	ErrNoRequestID = errors.New("no request ID to correlate the callback with")
+
+	// ErrNoCallback the options passed to a submit helper do not set a callback URL
+	ErrNoCallback = errors.New("options do not specify a callback URL")
+
+	// ErrTimeout the callback did not arrive in time
+	ErrTimeout = errors.New("timed out waiting for callback")
+
+	// ErrClosed the receiver has been closed
+	ErrClosed = errors.New("callback receiver is closed")
+)
@@ -0,0 +1,413 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package receives the results of requests made with a callback URL.
+
+PreRecordedTranscriptionOptions.Callback, AnalyzeOptions.Callback and SpeakOptions.Callback make the
+platform POST the result to your server instead of returning it. Mount a Receiver on that URL and it
+will verify, decode and deduplicate each delivery, then hand it to your Handler, Results channel or a
+pending Await call matched by request ID.
+*/
+package callback
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	analyze "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/analyze/v1/interfaces"
+	listen "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+)
+
+// New creates a Receiver
+func New(opts Options) *Receiver {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = defaultMaxBodySize
+	}
+	if opts.DedupeWindow <= 0 {
+		opts.DedupeWindow = defaultDedupeWindow
+	}
+	if opts.AwaitTimeout <= 0 {
+		opts.AwaitTimeout = defaultAwaitTimeout
+	}
+
+	return &Receiver{
+		opts:    opts,
+		seen:    make(map[string]time.Time),
+		pending: make(map[string]*Result),
+		waiters: make(map[string][]chan *Result),
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	klog.V(6).Infof("callback.ServeHTTP ENTER\n")
+	defer klog.V(6).Infof("callback.ServeHTTP LEAVE\n")
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.verify(req); err != nil {
+		klog.V(1).Infof("callback verification failed. Err: %v\n", err)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, r.opts.MaxBodySize))
+	if err != nil {
+		klog.V(1).Infof("io.ReadAll failed. Err: %v\n", err)
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	result, err := Decode(req.Header, body)
+	if err != nil {
+		klog.V(1).Infof("callback.Decode failed. Err: %v\n", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key := result.RequestID
+	if key == "" {
+		sum := sha256.Sum256(body)
+		key = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	if !r.markSeen(key) {
+		klog.V(4).Infof("Duplicate delivery for %s. Ignoring.\n", key)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := r.deliver(req.Context(), result); err != nil {
+		// let the platform retry the delivery
+		klog.V(1).Infof("callback delivery for %s failed. Err: %v\n", key, err)
+		r.forget(key)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+
+	klog.V(3).Infof("Received %s callback for %s\n", result.Kind, result.RequestID)
+	w.WriteHeader(http.StatusOK)
+}
+
+/*
+Await blocks until the callback for requestID arrives, the context is done, or Options.AwaitTimeout
+elapses when the context has no deadline. A result which arrived before Await was called is returned
+immediately. If the platform delivered an error, it is returned as a *DeliveryError along with the Result.
+*/
+func (r *Receiver) Await(ctx context.Context, requestID string) (*Result, error) {
+	klog.V(6).Infof("callback.Await ENTER\n")
+	defer klog.V(6).Infof("callback.Await LEAVE\n")
+
+	if requestID == "" {
+		return nil, ErrNoRequestID
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.opts.AwaitTimeout)
+		defer cancel()
+	}
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil, ErrClosed
+	}
+	if result, ok := r.pending[requestID]; ok {
+		delete(r.pending, requestID)
+		r.mu.Unlock()
+		return result, result.err()
+	}
+	ch := make(chan *Result, 1)
+	r.waiters[requestID] = append(r.waiters[requestID], ch)
+	r.mu.Unlock()
+
+	select {
+	case result := <-ch:
+		if result == nil {
+			return nil, ErrClosed
+		}
+		return result, result.err()
+	case <-ctx.Done():
+		r.removeWaiter(requestID, ch)
+		select {
+		case result := <-ch:
+			// delivered while we were giving up
+			if result != nil {
+				return result, result.err()
+			}
+		default:
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			klog.V(1).Infof("Timed out waiting for callback %s\n", requestID)
+			return nil, ErrTimeout
+		}
+		return nil, ctx.Err()
+	}
+}
+
+/*
+SubmitAndAwait starts a request with submit and waits for its callback. The timeout applies to both.
+
+	res, err := receiver.SubmitAndAwait(ctx, callback.TranscribeURL(dg, url, options))
+*/
+func (r *Receiver) SubmitAndAwait(ctx context.Context, submit SubmitFunc) (*Result, error) {
+	klog.V(6).Infof("callback.SubmitAndAwait ENTER\n")
+	defer klog.V(6).Infof("callback.SubmitAndAwait LEAVE\n")
+
+	if submit == nil {
+		return nil, ErrInvalidInput
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.opts.AwaitTimeout)
+		defer cancel()
+	}
+
+	requestID, err := submit(ctx)
+	if err != nil {
+		klog.V(1).Infof("submit failed. Err: %v\n", err)
+		return nil, err
+	}
+	if requestID == "" {
+		return nil, ErrNoRequestID
+	}
+	klog.V(4).Infof("Submitted %s. Waiting for callback.\n", requestID)
+
+	return r.Await(ctx, requestID)
+}
+
+// Close releases any pending Await calls with ErrClosed
+func (r *Receiver) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+	r.closed = true
+	for id, chans := range r.waiters {
+		for _, ch := range chans {
+			close(ch)
+		}
+		delete(r.waiters, id)
+	}
+}
+
+/*
+Decode converts a callback delivery into a Result.
+
+JSON bodies are recognized as transcription, analyze or error payloads. Anything else is treated as
+speak audio, with the request ID taken from the headers.
+*/
+func Decode(header http.Header, body []byte) (*Result, error) {
+	result := &Result{
+		ContentType: header.Get("Content-Type"),
+		Header:      header,
+		ReceivedAt:  time.Now(),
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(result.ContentType)
+	isJSON := strings.HasSuffix(mediaType, "json") ||
+		(mediaType == "" && bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")))
+
+	if !isJSON {
+		if len(body) == 0 {
+			return nil, ErrUnknownPayload
+		}
+		result.Kind = KindSpeak
+		result.Audio = body
+		for _, name := range requestIDHeaders {
+			if id := header.Get(name); id != "" {
+				result.RequestID = id
+				break
+			}
+		}
+		return result, nil
+	}
+
+	var probe struct {
+		RequestID string `json:"request_id"`
+		Metadata  *struct {
+			RequestID string `json:"request_id"`
+		} `json:"metadata"`
+		Results map[string]json.RawMessage `json:"results"`
+		ErrCode string                     `json:"err_code"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		klog.V(1).Infof("json.Unmarshal failed. Err: %v\n", err)
+		return nil, err
+	}
+
+	result.RequestID = probe.RequestID
+	if result.RequestID == "" && probe.Metadata != nil {
+		result.RequestID = probe.Metadata.RequestID
+	}
+
+	switch {
+	case probe.ErrCode != "":
+		var dgErr interfaces.DeepgramError
+		if err := json.Unmarshal(body, &dgErr); err != nil {
+			return nil, err
+		}
+		result.Kind = KindError
+		result.Error = &DeliveryError{RequestID: result.RequestID, DeepgramError: &dgErr}
+	case probe.Results["channels"] != nil:
+		var resp listen.PreRecordedResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, err
+		}
+		result.Kind = KindTranscription
+		result.Transcription = &resp
+	case probe.Results["summary"] != nil || probe.Results["topics"] != nil ||
+		probe.Results["intents"] != nil || probe.Results["sentiments"] != nil:
+		var resp analyze.AnalyzeResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, err
+		}
+		result.Kind = KindAnalyze
+		result.Analyze = &resp
+	default:
+		return nil, ErrUnknownPayload
+	}
+
+	return result, nil
+}
+
+/*
+helpers
+*/
+func (r *Receiver) verify(req *http.Request) error {
+	if r.opts.Username != "" || r.opts.Password != "" {
+		user, pass, ok := req.BasicAuth()
+		if !ok || !secureEqual(user, r.opts.Username) || !secureEqual(pass, r.opts.Password) {
+			return ErrUnauthorized
+		}
+	}
+
+	for name, value := range r.opts.Headers {
+		if !secureEqual(req.Header.Get(name), value) {
+			return ErrUnauthorized
+		}
+	}
+
+	if r.opts.Verify != nil {
+		return r.opts.Verify(req)
+	}
+
+	return nil
+}
+
+// markSeen records the delivery and returns false if it was already seen
+func (r *Receiver) markSeen(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for k, t := range r.seen {
+		if now.Sub(t) > r.opts.DedupeWindow {
+			delete(r.seen, k)
+		}
+	}
+	for k, result := range r.pending {
+		if now.Sub(result.ReceivedAt) > r.opts.DedupeWindow {
+			delete(r.pending, k)
+		}
+	}
+
+	if _, ok := r.seen[key]; ok {
+		return false
+	}
+	r.seen[key] = now
+	return true
+}
+
+func (r *Receiver) forget(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.seen, key)
+}
+
+func (r *Receiver) deliver(ctx context.Context, result *Result) error {
+	if r.opts.Results != nil {
+		select {
+		case r.opts.Results <- result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if result.RequestID != "" {
+		r.mu.Lock()
+		if chans, ok := r.waiters[result.RequestID]; ok {
+			for _, ch := range chans {
+				ch <- result
+			}
+			delete(r.waiters, result.RequestID)
+		} else {
+			r.pending[result.RequestID] = result
+		}
+		r.mu.Unlock()
+	}
+
+	if r.opts.Handler != nil {
+		r.opts.Handler(result)
+	}
+
+	return nil
+}
+
+func (r *Receiver) removeWaiter(requestID string, ch chan *Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chans := r.waiters[requestID]
+	for i, c := range chans {
+		if c == ch {
+			chans = append(chans[:i], chans[i+1:]...)
+			break
+		}
+	}
+	if len(chans) == 0 {
+		delete(r.waiters, requestID)
+	} else {
+		r.waiters[requestID] = chans
+	}
+}
+
+func (res *Result) err() error {
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
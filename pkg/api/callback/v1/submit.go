@@ -0,0 +1,99 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package callback
+
+import (
+	"context"
+	"encoding/json"
+
+	analyze "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/analyze/v1"
+	listen "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest"
+	speak "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/speak/v1/rest"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+)
+
+// TranscribeURL returns a SubmitFunc for a prerecorded transcription of url. options.Callback must be set.
+func TranscribeURL(c *listen.Client, url string, options *interfaces.PreRecordedTranscriptionOptions) SubmitFunc {
+	return func(ctx context.Context) (string, error) {
+		if c == nil || options == nil {
+			return "", ErrInvalidInput
+		}
+		if options.Callback == "" {
+			return "", ErrNoCallback
+		}
+
+		resp, err := c.FromURL(ctx, url, options)
+		if err != nil {
+			return "", err
+		}
+		return resp.RequestID, nil
+	}
+}
+
+// TranscribeFile returns a SubmitFunc for a prerecorded transcription of a local file. options.Callback must be set.
+func TranscribeFile(c *listen.Client, file string, options *interfaces.PreRecordedTranscriptionOptions) SubmitFunc {
+	return func(ctx context.Context) (string, error) {
+		if c == nil || options == nil {
+			return "", ErrInvalidInput
+		}
+		if options.Callback == "" {
+			return "", ErrNoCallback
+		}
+
+		resp, err := c.FromFile(ctx, file, options)
+		if err != nil {
+			return "", err
+		}
+		return resp.RequestID, nil
+	}
+}
+
+// AnalyzeURL returns a SubmitFunc for text analysis of the document at url. options.Callback must be set.
+func AnalyzeURL(c *analyze.Client, url string, options *interfaces.AnalyzeOptions) SubmitFunc {
+	return func(ctx context.Context) (string, error) {
+		if c == nil || options == nil {
+			return "", ErrInvalidInput
+		}
+		if options.Callback == "" {
+			return "", ErrNoCallback
+		}
+
+		resp, err := c.FromURL(ctx, url, options)
+		if err != nil {
+			return "", err
+		}
+		return resp.RequestID, nil
+	}
+}
+
+// Speak returns a SubmitFunc for text-to-speech of text. options.Callback must be set.
+func Speak(c *speak.Client, text string, options *interfaces.SpeakOptions) SubmitFunc {
+	return func(ctx context.Context) (string, error) {
+		if c == nil || options == nil {
+			return "", ErrInvalidInput
+		}
+		if options.Callback == "" {
+			return "", ErrNoCallback
+		}
+
+		var buf interfaces.RawResponse
+		resp, err := c.ToStream(ctx, text, options, &buf)
+		if err != nil {
+			return "", err
+		}
+		if resp.RequestID != "" {
+			return resp.RequestID, nil
+		}
+
+		// with a callback the request ID is returned in the body
+		var body struct {
+			RequestID string `json:"request_id"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &body); err != nil {
+			return "", ErrNoRequestID
+		}
+		return body.RequestID, nil
+	}
+}
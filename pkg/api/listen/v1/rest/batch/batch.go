@@ -0,0 +1,369 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package provides a batch runner for the prerecorded API.
+
+Items are transcribed on a bounded worker pool with an optional token-bucket rate limit and
+retries. Completed items are recorded in a checkpoint file so an interrupted run can be resumed,
+and audio which has already been transcribed (by Metadata.Sha256) is not sent twice.
+*/
+package batch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	listen "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+)
+
+// New creates a batch Runner
+func New(c *client.Client, opts Options) (*Runner, error) {
+	klog.V(6).Infof("batch.New ENTER\n")
+
+	if c == nil || opts.Sink == nil {
+		klog.V(1).Infof("batch.New: client and sink are required\n")
+		klog.V(6).Infof("batch.New LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = opts.Concurrency
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = defaultMaxRetries
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = defaultRetryBackoff
+	}
+
+	cp, err := loadCheckpoint(opts.CheckpointFile)
+	if err != nil {
+		klog.V(1).Infof("loadCheckpoint failed. Err: %v\n", err)
+		klog.V(6).Infof("batch.New LEAVE\n")
+		return nil, err
+	}
+
+	r := &Runner{
+		client:     c,
+		opts:       opts,
+		limiter:    newTokenBucket(opts.RequestsPerSecond, opts.Burst),
+		checkpoint: cp,
+	}
+
+	klog.V(3).Infof("batch.New Succeeded\n")
+	klog.V(6).Infof("batch.New LEAVE\n")
+
+	return r, nil
+}
+
+/*
+Run transcribes the items and blocks until they are all processed or the context is canceled.
+
+Items which fail after all retries are reported in the Summary and do not stop the run.
+The returned error is only non-nil for an invalid manifest or a canceled context.
+*/
+func (r *Runner) Run(ctx context.Context, items []Item) (*Summary, error) {
+	klog.V(6).Infof("batch.Run ENTER\n")
+
+	ids := make(map[string]bool, len(items))
+	for i := range items {
+		item := &items[i]
+		if err := item.normalize(); err != nil {
+			klog.V(1).Infof("item %d is invalid. Err: %v\n", i, err)
+			klog.V(6).Infof("batch.Run LEAVE\n")
+			return nil, err
+		}
+		if ids[item.ID] {
+			klog.V(1).Infof("duplicate item id: %s\n", item.ID)
+			klog.V(6).Infof("batch.Run LEAVE\n")
+			return nil, ErrDuplicateID
+		}
+		ids[item.ID] = true
+	}
+
+	summary := &Summary{
+		Total:   len(items),
+		Results: make([]ItemResult, len(items)),
+	}
+
+	work := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < r.opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range work {
+				result := r.process(ctx, &items[idx])
+
+				mu.Lock()
+				summary.Results[idx] = result
+				summary.add(result.Status)
+				mu.Unlock()
+
+				if r.opts.OnResult != nil {
+					r.opts.OnResult(result)
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for idx := range items {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case work <- idx:
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	// anything not dispatched because of cancellation
+	for idx := range summary.Results {
+		if summary.Results[idx].Status == "" {
+			summary.Results[idx] = ItemResult{ID: items[idx].ID, Status: StatusFailed, Error: ctx.Err().Error()}
+			summary.add(StatusFailed)
+		}
+	}
+
+	klog.V(3).Infof("batch.Run: %d succeeded, %d skipped, %d duplicates, %d failed\n",
+		summary.Succeeded, summary.Skipped, summary.Duplicates, summary.Failed)
+	klog.V(6).Infof("batch.Run LEAVE\n")
+
+	return summary, ctx.Err()
+}
+
+// process transcribes a single item with retries
+func (r *Runner) process(ctx context.Context, item *Item) ItemResult {
+	result := ItemResult{ID: item.ID}
+
+	if r.checkpoint.done(item.ID) {
+		klog.V(4).Infof("Item %s already completed. Skipping.\n", item.ID)
+		result.Status = StatusSkipped
+		return result
+	}
+
+	// hash local audio up front so it is never uploaded twice
+	if item.File != "" {
+		hash, err := hashFile(item.File)
+		if err != nil {
+			klog.V(1).Infof("hashFile(%s) failed. Err: %v\n", item.File, err)
+			result.Status = StatusFailed
+			result.Error = err.Error()
+			return result
+		}
+		result.Sha256 = hash
+
+		owner, ok, err := r.checkpoint.reserve(ctx, hash)
+		if err != nil {
+			klog.V(1).Infof("Item %s waiting for the same audio failed. Err: %v\n", item.ID, err)
+			result.Status = StatusFailed
+			result.Error = err.Error()
+			return result
+		}
+		if ok {
+			klog.V(4).Infof("Item %s has the same audio as %s. Skipping.\n", item.ID, owner)
+			result.Status = StatusDuplicate
+			r.completeDuplicate(item, &result)
+			return result
+		}
+		// items with the same audio wait until this one is completed or has failed
+		defer r.checkpoint.release(hash)
+	}
+
+	var resp *api.PreRecordedResponse
+	var err error
+	backoff := r.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		result.Attempts = attempt + 1
+
+		if err = r.limiter.Wait(ctx); err != nil {
+			break
+		}
+
+		resp, err = r.transcribe(ctx, item)
+		if err == nil || attempt >= r.opts.MaxRetries || !retryable(err) {
+			break
+		}
+		if rs, ok := item.Reader.(io.Seeker); ok {
+			if _, err = rs.Seek(0, io.SeekStart); err != nil {
+				break
+			}
+		} else if item.Reader != nil {
+			err = ErrReaderNotRewindable
+			break
+		}
+
+		klog.V(3).Infof("Item %s attempt %d failed. Retrying in %v. Err: %v\n", item.ID, attempt+1, backoff, err)
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-time.After(backoff):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+	if err != nil {
+		klog.V(1).Infof("Item %s failed. Err: %v\n", item.ID, err)
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	if resp.Metadata != nil {
+		result.RequestID = resp.Metadata.RequestID
+		// local audio keeps the hash it was reserved with
+		if resp.Metadata.Sha256 != "" && result.Sha256 == "" {
+			result.Sha256 = resp.Metadata.Sha256
+		}
+	}
+
+	// URLs and readers can only be checked after the fact
+	if owner, ok := r.checkpoint.owner(result.Sha256); ok && owner != item.ID {
+		klog.V(4).Infof("Item %s has the same audio as %s\n", item.ID, owner)
+		result.Status = StatusDuplicate
+		r.completeDuplicate(item, &result)
+		return result
+	}
+
+	if err := r.opts.Sink.Write(ctx, item, resp); err != nil {
+		klog.V(1).Infof("Sink.Write(%s) failed. Err: %v\n", item.ID, err)
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	if err := r.checkpoint.complete(item.ID, checkpointEntry{RequestID: result.RequestID, Sha256: result.Sha256}); err != nil {
+		klog.V(1).Infof("checkpoint.complete(%s) failed. Err: %v\n", item.ID, err)
+	}
+
+	result.Status = StatusSucceeded
+	return result
+}
+
+func (r *Runner) transcribe(ctx context.Context, item *Item) (*api.PreRecordedResponse, error) {
+	options := item.Options
+	if options == nil {
+		options = r.opts.Defaults
+	}
+	if options == nil {
+		options = &interfaces.PreRecordedTranscriptionOptions{}
+	}
+
+	// the options may be shared between items, so each request gets its own copy
+	opts := *options
+
+	c := listen.New(r.client)
+	switch {
+	case item.File != "":
+		return c.FromFile(ctx, item.File, &opts)
+	case item.URL != "":
+		return c.FromURL(ctx, item.URL, &opts)
+	default:
+		return c.FromStream(ctx, item.Reader, &opts)
+	}
+}
+
+func (r *Runner) completeDuplicate(item *Item, result *ItemResult) {
+	if err := r.checkpoint.complete(item.ID, checkpointEntry{Sha256: result.Sha256}); err != nil {
+		klog.V(1).Infof("checkpoint.complete(%s) failed. Err: %v\n", item.ID, err)
+	}
+}
+
+/*
+helpers
+*/
+func (i *Item) normalize() error {
+	set := 0
+	if i.File != "" {
+		set++
+	}
+	if i.URL != "" {
+		set++
+	}
+	if i.Reader != nil {
+		set++
+	}
+	if set != 1 {
+		return ErrInvalidItem
+	}
+
+	if i.ID == "" {
+		i.ID = i.File + i.URL
+	}
+	if i.ID == "" {
+		// readers need an ID to be resumable
+		return ErrInvalidItem
+	}
+
+	return nil
+}
+
+func (s *Summary) add(status Status) {
+	switch status {
+	case StatusSucceeded:
+		s.Succeeded++
+	case StatusSkipped:
+		s.Skipped++
+	case StatusDuplicate:
+		s.Duplicates++
+	case StatusFailed:
+		s.Failed++
+	}
+}
+
+// retryable returns false for client errors which will fail the same way again
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var se *interfaces.StatusError
+	if errors.As(err, &se) && se.Resp != nil {
+		code := se.Resp.StatusCode
+		if code == http.StatusTooManyRequests || code == http.StatusRequestTimeout {
+			return true
+		}
+		return code < 400 || code >= 500
+	}
+
+	return true
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	klog "k8s.io/klog/v2"
+)
+
+// loadCheckpoint reads the checkpoint at path. A missing file starts a new checkpoint.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	cp := &checkpoint{
+		Completed: make(map[string]checkpointEntry),
+		Hashes:    make(map[string]string),
+		path:      path,
+		inFlight:  make(map[string]chan struct{}),
+	}
+	if path == "" {
+		return cp, nil
+	}
+
+	byData, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		klog.V(3).Infof("Checkpoint %s not found. Starting a new run.\n", path)
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(byData, cp); err != nil {
+		klog.V(1).Infof("json.Unmarshal(checkpoint) failed. Err: %v\n", err)
+		return nil, err
+	}
+	if cp.Completed == nil {
+		cp.Completed = make(map[string]checkpointEntry)
+	}
+	if cp.Hashes == nil {
+		cp.Hashes = make(map[string]string)
+	}
+	klog.V(3).Infof("Resuming from checkpoint %s with %d completed items\n", path, len(cp.Completed))
+
+	return cp, nil
+}
+
+// done returns true if the item was completed in this or a previous run
+func (cp *checkpoint) done(id string) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	_, ok := cp.Completed[id]
+	return ok
+}
+
+// owner returns the ID of the item which already transcribed the audio with this hash
+func (cp *checkpoint) owner(sha256 string) (string, bool) {
+	if sha256 == "" {
+		return "", false
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	id, ok := cp.Hashes[sha256]
+	return id, ok
+}
+
+/*
+reserve claims the audio with this hash before it is transcribed, so identical audio is only uploaded
+once. It returns the owner when the audio was already transcribed. When another item is transcribing
+it, reserve waits for that item, which may fail and leave the audio to this one.
+
+A successful reservation must be released once the item is completed or has failed.
+*/
+func (cp *checkpoint) reserve(ctx context.Context, sha256 string) (string, bool, error) {
+	for {
+		cp.mu.Lock()
+		if id, ok := cp.Hashes[sha256]; ok {
+			cp.mu.Unlock()
+			return id, true, nil
+		}
+		wait, busy := cp.inFlight[sha256]
+		if !busy {
+			cp.inFlight[sha256] = make(chan struct{})
+			cp.mu.Unlock()
+			return "", false, nil
+		}
+		cp.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return "", false, ctx.Err()
+		}
+	}
+}
+
+// release ends a reservation made by reserve
+func (cp *checkpoint) release(sha256 string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if wait, ok := cp.inFlight[sha256]; ok {
+		close(wait)
+		delete(cp.inFlight, sha256)
+	}
+}
+
+// complete records the item and persists the checkpoint
+func (cp *checkpoint) complete(id string, entry checkpointEntry) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.Completed[id] = entry
+	if entry.Sha256 != "" {
+		if _, ok := cp.Hashes[entry.Sha256]; !ok {
+			cp.Hashes[entry.Sha256] = id
+		}
+	}
+
+	return cp.save()
+}
+
+// save writes the checkpoint atomically. Must be called with the lock held.
+func (cp *checkpoint) save() error {
+	if cp.path == "" {
+		return nil
+	}
+
+	byData, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(cp.path), filepath.Base(cp.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(byData); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), cp.path)
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package batch
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// Status of an item in a batch run
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusSkipped   Status = "skipped"   // already completed in a previous run
+	StatusDuplicate Status = "duplicate" // same audio (by sha256) as another completed item
+	StatusFailed    Status = "failed"
+)
+
+// defaults
+const (
+	defaultConcurrency  int           = 4
+	defaultMaxRetries   int           = 3
+	defaultRetryBackoff time.Duration = time.Second
+	maxRetryBackoff     time.Duration = 30 * time.Second
+)
+
+// filenameHashLen is how many bytes of the ID's sha256 are appended to a sanitized file name
+const filenameHashLen int = 4
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrInvalidItem the item must have exactly one of File, URL or Reader
+	ErrInvalidItem = errors.New("item must specify exactly one of file, url or reader")
+
+	// ErrDuplicateID two items in the manifest have the same ID
+	ErrDuplicateID = errors.New("duplicate item id in manifest")
+
+	// ErrReaderNotRewindable the reader cannot be rewound to retry the request
+	ErrReaderNotRewindable = errors.New("reader is not an io.Seeker and cannot be retried")
+)
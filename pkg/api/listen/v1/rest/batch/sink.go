@@ -0,0 +1,99 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package batch
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	klog "k8s.io/klog/v2"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+)
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// DirSink writes each response to <dir>/<id>.json
+type DirSink struct {
+	Dir string
+}
+
+// NewDirSink creates a DirSink, creating dir if needed
+func NewDirSink(dir string) (*DirSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		klog.V(1).Infof("os.MkdirAll(%s) failed. Err: %v\n", dir, err)
+		return nil, err
+	}
+	return &DirSink{Dir: dir}, nil
+}
+
+// Write saves the response as indented JSON
+func (s *DirSink) Write(_ context.Context, item *Item, resp *api.PreRecordedResponse) error {
+	byData, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(s.Dir, Filename(item.ID)+".json"), byData, 0o600)
+}
+
+/*
+Filename converts an item ID (usually a path or URL) into a safe file name. When the ID had to be changed,
+a short hash of it is appended so IDs like "a/b.wav" and "a_b.wav" don't overwrite each other.
+*/
+func Filename(id string) string {
+	name := unsafeFilenameChars.ReplaceAllString(id, "_")
+	name = strings.Trim(name, "._")
+	if name == id {
+		return name
+	}
+	if name == "" {
+		name = "item"
+	}
+
+	sum := sha256.Sum256([]byte(id))
+	return name + "-" + hex.EncodeToString(sum[:filenameHashLen])
+}
+
+/*
+LoadManifest reads a JSON Lines manifest, one Item per line:
+
+	{"id": "call-1", "file": "audio/call-1.wav", "options": {"model": "nova-3", "diarize": true}}
+	{"url": "https://dpgr.am/spacewalk.wav"}
+*/
+func LoadManifest(path string) ([]Item, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		klog.V(1).Infof("os.Open(%s) failed. Err: %v\n", path, err)
+		return nil, err
+	}
+	defer file.Close()
+
+	items := make([]Item, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var item Item
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			klog.V(1).Infof("json.Unmarshal(Item) failed. Err: %v\n", err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, scanner.Err()
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package batch
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+)
+
+// Item is a single piece of audio to transcribe. Exactly one of File, URL or Reader must be set.
+type Item struct {
+	// ID identifies the item in the checkpoint and sink. Defaults to File or URL.
+	ID string `json:"id,omitempty"`
+
+	File   string    `json:"file,omitempty"`
+	URL    string    `json:"url,omitempty"`
+	Reader io.Reader `json:"-"`
+
+	// Options for this item. Falls back to Options.Defaults when nil.
+	Options *interfaces.PreRecordedTranscriptionOptions `json:"options,omitempty"`
+}
+
+// Sink receives each successful transcription
+type Sink interface {
+	Write(ctx context.Context, item *Item, resp *api.PreRecordedResponse) error
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(ctx context.Context, item *Item, resp *api.PreRecordedResponse) error
+
+// Write calls f(ctx, item, resp)
+func (f SinkFunc) Write(ctx context.Context, item *Item, resp *api.PreRecordedResponse) error {
+	return f(ctx, item, resp)
+}
+
+// Options configures a Runner
+type Options struct {
+	// Defaults are the transcription options for items which do not specify their own
+	Defaults *interfaces.PreRecordedTranscriptionOptions
+
+	// Concurrency is the number of workers. Defaults to 4.
+	Concurrency int
+
+	// RequestsPerSecond limits the rate of requests. 0 means unlimited.
+	RequestsPerSecond float64
+	// Burst is the token bucket size. Defaults to Concurrency.
+	Burst int
+
+	// MaxRetries for failed requests. Defaults to 3. Use a negative value to disable retries.
+	MaxRetries int
+	// RetryBackoff is the initial delay between retries, doubled on each attempt. Defaults to 1s.
+	RetryBackoff time.Duration
+
+	// CheckpointFile records completed items so an interrupted run can be resumed
+	CheckpointFile string
+
+	// Sink receives every response. Required.
+	Sink Sink
+
+	// OnResult is called after each item completes
+	OnResult func(result ItemResult)
+}
+
+// ItemResult is the outcome of a single item
+type ItemResult struct {
+	ID        string `json:"id"`
+	Status    Status `json:"status"`
+	RequestID string `json:"request_id,omitempty"`
+	Sha256    string `json:"sha256,omitempty"`
+	Attempts  int    `json:"attempts,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Summary of a batch run
+type Summary struct {
+	Total      int          `json:"total"`
+	Succeeded  int          `json:"succeeded"`
+	Skipped    int          `json:"skipped"`
+	Duplicates int          `json:"duplicates"`
+	Failed     int          `json:"failed"`
+	Results    []ItemResult `json:"results"`
+}
+
+// Runner transcribes a manifest of items on a bounded worker pool
+type Runner struct {
+	client     *client.Client
+	opts       Options
+	limiter    *tokenBucket
+	checkpoint *checkpoint
+}
+
+// checkpoint is the persisted progress of a run
+type checkpoint struct {
+	Completed map[string]checkpointEntry `json:"completed"`
+	Hashes    map[string]string          `json:"hashes"` // sha256 -> item ID
+
+	path     string
+	mu       sync.Mutex
+	inFlight map[string]chan struct{} // sha256 -> closed when the item transcribing it finishes
+}
+
+type checkpointEntry struct {
+	RequestID string `json:"request_id,omitempty"`
+	Sha256    string `json:"sha256,omitempty"`
+}
+
+// tokenBucket is a simple token bucket rate limiter
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	mu     sync.Mutex
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package conversation
+
+import (
+	"errors"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// Format is the rendering used for a Conversation
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+	FormatJSON     Format = "json"
+)
+
+// internal constants
+const (
+	defaultMaxGap float64 = 1.5
+)
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrNoTranscript the response does not contain any words or utterances
+	ErrNoTranscript = errors.New("response does not contain any words or utterances")
+
+	// ErrInvalidFormat the rendering format is not supported
+	ErrInvalidFormat = errors.New("invalid conversation format")
+)
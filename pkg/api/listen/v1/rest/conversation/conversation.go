@@ -0,0 +1,291 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package merges Multichannel and Diarize transcripts into a single chronological conversation
+
+	conv, err := conversation.Merge(resp, &conversation.Options{
+		ChannelRoles: map[int]string{0: "Agent", 1: "Customer"},
+	})
+	fmt.Print(conv.Text())
+*/
+package conversation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	klog "k8s.io/klog/v2"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+)
+
+// Merge interleaves every channel and speaker in the response into time ordered turns
+func Merge(resp *api.PreRecordedResponse, opts *Options) (*Conversation, error) {
+	klog.V(6).Infof("conversation.Merge ENTER\n")
+
+	if resp == nil || resp.Results == nil {
+		klog.V(1).Infof("conversation.Merge: response has no results\n")
+		klog.V(6).Infof("conversation.Merge LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	maxGap := opts.MaxGap
+	if maxGap <= 0 {
+		maxGap = defaultMaxGap
+	}
+
+	multichannel := len(resp.Results.Channels) > 1
+
+	var turns []Turn
+	if len(resp.Results.Utterances) > 0 && !opts.UseWords {
+		klog.V(4).Infof("Building turns from %d utterances\n", len(resp.Results.Utterances))
+		turns = turnsFromUtterances(resp.Results.Utterances, opts, multichannel)
+	} else {
+		klog.V(4).Infof("Building turns from words across %d channels\n", len(resp.Results.Channels))
+		turns = turnsFromChannels(resp.Results.Channels, opts, maxGap, multichannel)
+	}
+
+	if len(turns) == 0 {
+		klog.V(1).Infof("conversation.Merge: no words or utterances found\n")
+		klog.V(6).Infof("conversation.Merge LEAVE\n")
+		return nil, ErrNoTranscript
+	}
+
+	sort.SliceStable(turns, func(i, j int) bool {
+		if turns[i].Start == turns[j].Start {
+			return turns[i].Channel < turns[j].Channel
+		}
+		return turns[i].Start < turns[j].Start
+	})
+
+	turns = combineTurns(turns, maxGap)
+	markOverlaps(turns)
+
+	conv := &Conversation{
+		Turns:      turns,
+		timestamps: opts.Timestamps,
+	}
+	if resp.Metadata != nil {
+		conv.RequestID = resp.Metadata.RequestID
+	}
+
+	klog.V(4).Infof("conversation.Merge produced %d turns\n", len(turns))
+	klog.V(6).Infof("conversation.Merge LEAVE\n")
+
+	return conv, nil
+}
+
+// Render returns the conversation in the requested format
+func (c *Conversation) Render(format Format) (string, error) {
+	switch format {
+	case FormatText:
+		return c.Text(), nil
+	case FormatMarkdown:
+		return c.Markdown(), nil
+	case FormatJSON:
+		byData, err := c.JSON()
+		return string(byData), err
+	}
+	return "", ErrInvalidFormat
+}
+
+// Text renders the conversation as "Role: text" lines
+func (c *Conversation) Text() string {
+	var sb strings.Builder
+	for _, turn := range c.Turns {
+		if c.timestamps {
+			sb.WriteString("[" + api.SecondsToTimestamp(turn.Start) + "] ")
+		}
+		sb.WriteString(turn.Role + ": " + turn.Text)
+		if turn.Overlaps {
+			sb.WriteString(" [overlap]")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Markdown renders the conversation with bold roles, one paragraph per turn
+func (c *Conversation) Markdown() string {
+	var sb strings.Builder
+	for i, turn := range c.Turns {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("**" + turn.Role + "**")
+		if c.timestamps {
+			sb.WriteString(" _" + api.SecondsToTimestamp(turn.Start) + "_")
+		}
+		sb.WriteString(": " + turn.Text)
+		if turn.Overlaps {
+			sb.WriteString(" _(overlapping)_")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// JSON renders the conversation as JSON
+func (c *Conversation) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+/*
+helpers
+*/
+func turnsFromUtterances(utterances []api.Utterance, opts *Options, multichannel bool) []Turn {
+	turns := make([]Turn, 0, len(utterances))
+	for _, u := range utterances {
+		text := strings.TrimSpace(u.Transcript)
+		if text == "" {
+			text = joinWords(u.Words)
+		}
+		if text == "" {
+			continue
+		}
+		turns = append(turns, Turn{
+			Role:    roleFor(opts, u.Channel, u.Speaker, multichannel),
+			Channel: u.Channel,
+			Speaker: u.Speaker,
+			Start:   u.Start,
+			End:     u.End,
+			Text:    text,
+			Words:   u.Words,
+		})
+	}
+	return turns
+}
+
+// turnsFromChannels walks the words of every channel in time order, so a turn ends as soon as anyone else speaks
+func turnsFromChannels(channels []api.Channel, opts *Options, maxGap float64, multichannel bool) []Turn {
+	words := make([]channelWord, 0)
+	for chIdx, channel := range channels {
+		if len(channel.Alternatives) == 0 {
+			continue
+		}
+		for _, w := range channel.Alternatives[0].Words {
+			words = append(words, channelWord{channel: chIdx, word: w})
+		}
+	}
+	sort.SliceStable(words, func(i, j int) bool {
+		return words[i].word.Start < words[j].word.Start
+	})
+
+	turns := make([]Turn, 0)
+	var current *Turn
+	for _, cw := range words {
+		w := cw.word
+		if current != nil && current.Channel == cw.channel && sameSpeaker(current.Speaker, w.Speaker) && w.Start-current.End <= maxGap {
+			current.Words = append(current.Words, w)
+			if w.End > current.End {
+				current.End = w.End
+			}
+			continue
+		}
+		if current != nil {
+			current.Text = joinWords(current.Words)
+			turns = append(turns, *current)
+		}
+		current = &Turn{
+			Role:    roleFor(opts, cw.channel, w.Speaker, multichannel),
+			Channel: cw.channel,
+			Speaker: w.Speaker,
+			Start:   w.Start,
+			End:     w.End,
+			Words:   []api.Word{w},
+		}
+	}
+	if current != nil {
+		current.Text = joinWords(current.Words)
+		turns = append(turns, *current)
+	}
+	return turns
+}
+
+// combineTurns joins back to back turns from the same channel and speaker
+func combineTurns(turns []Turn, maxGap float64) []Turn {
+	combined := make([]Turn, 0, len(turns))
+	for _, turn := range turns {
+		n := len(combined)
+		if n > 0 {
+			prev := &combined[n-1]
+			if prev.Channel == turn.Channel && sameSpeaker(prev.Speaker, turn.Speaker) && turn.Start-prev.End <= maxGap {
+				prev.Text = prev.Text + " " + turn.Text
+				prev.Words = append(prev.Words, turn.Words...)
+				if turn.End > prev.End {
+					prev.End = turn.End
+				}
+				continue
+			}
+		}
+		combined = append(combined, turn)
+	}
+	return combined
+}
+
+// markOverlaps flags turns which start while another role is still speaking
+func markOverlaps(turns []Turn) {
+	for i := 1; i < len(turns); i++ {
+		for j := i - 1; j >= 0; j-- {
+			if turns[j].Role == turns[i].Role {
+				continue
+			}
+			if turns[i].Start < turns[j].End {
+				turns[i].Overlaps = true
+				break
+			}
+		}
+	}
+}
+
+// roleFor picks the role for a channel and speaker. Speaker IDs are per channel, so SpeakerRoles only applies to a single channel.
+func roleFor(opts *Options, channel int, speaker *int, multichannel bool) string {
+	if opts.Roles != nil {
+		if role := opts.Roles(channel, speaker); role != "" {
+			return role
+		}
+	}
+	if speaker != nil {
+		if role, ok := opts.ChannelSpeakerRoles[channel][*speaker]; ok {
+			return role
+		}
+		if role, ok := opts.SpeakerRoles[*speaker]; ok && !multichannel {
+			return role
+		}
+	}
+	if role, ok := opts.ChannelRoles[channel]; ok {
+		return role
+	}
+	if speaker != nil {
+		if multichannel {
+			return fmt.Sprintf("Channel %d Speaker %d", channel, *speaker)
+		}
+		return fmt.Sprintf("Speaker %d", *speaker)
+	}
+	return fmt.Sprintf("Channel %d", channel)
+}
+
+func sameSpeaker(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func joinWords(words []api.Word) string {
+	parts := make([]string, 0, len(words))
+	for _, w := range words {
+		if w.PunctuatedWord != "" {
+			parts = append(parts, w.PunctuatedWord)
+		} else {
+			parts = append(parts, w.Word)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package conversation
+
+import (
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+)
+
+// RoleFunc returns the role for a given channel and speaker. speaker is nil when diarize is off.
+type RoleFunc func(channel int, speaker *int) string
+
+// Options control how a transcript is merged into a conversation
+type Options struct {
+	// ChannelRoles maps a channel index to a role (ie 0: "Agent", 1: "Customer")
+	ChannelRoles map[int]string
+
+	// SpeakerRoles maps a diarized speaker ID to a role. Takes precedence over ChannelRoles.
+	// Speaker IDs are numbered per channel, so it is ignored for multichannel responses.
+	SpeakerRoles map[int]string
+
+	// ChannelSpeakerRoles maps a channel index, then a diarized speaker ID on it, to a role.
+	// Takes precedence over SpeakerRoles and ChannelRoles.
+	ChannelSpeakerRoles map[int]map[int]string
+
+	// Roles overrides ChannelRoles and SpeakerRoles when set
+	Roles RoleFunc
+
+	// UseWords builds turns from words even when Utterances are present in the response
+	UseWords bool
+
+	// MaxGap is the silence, in seconds, which splits a turn when building turns from words. Defaults to 1.5.
+	MaxGap float64
+
+	// Timestamps includes the start time of each turn in the text and markdown renderings
+	Timestamps bool
+}
+
+// Turn is a contiguous block of speech by a single role
+type Turn struct {
+	Role     string     `json:"role"`
+	Channel  int        `json:"channel"`
+	Speaker  *int       `json:"speaker,omitempty"`
+	Start    float64    `json:"start"`
+	End      float64    `json:"end"`
+	Text     string     `json:"text"`
+	Overlaps bool       `json:"overlaps,omitempty"` // true if this turn starts before the previous turn ended
+	Words    []api.Word `json:"words,omitempty"`
+}
+
+// channelWord is a word and the channel it was spoken on
+type channelWord struct {
+	channel int
+	word    api.Word
+}
+
+// Conversation is a chronological view of all channels and speakers in a transcript
+type Conversation struct {
+	RequestID string `json:"request_id,omitempty"`
+	Turns     []Turn `json:"turns"`
+
+	timestamps bool
+}
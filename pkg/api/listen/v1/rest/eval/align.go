@@ -0,0 +1,96 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package eval
+
+// align computes the minimum edit alignment between the reference and hypothesis words
+func align(ref, hyp []string) []AlignedWord {
+	n, m := len(ref), len(hyp)
+
+	// cost matrix
+	dist := make([][]int, n+1)
+	for i := range dist {
+		dist[i] = make([]int, m+1)
+		dist[i][0] = i
+	}
+	for j := 0; j <= m; j++ {
+		dist[0][j] = j
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if ref[i-1] == hyp[j-1] {
+				cost = 0
+			}
+			dist[i][j] = minOf(
+				dist[i-1][j-1]+cost,
+				dist[i-1][j]+1,
+				dist[i][j-1]+1,
+			)
+		}
+	}
+
+	// backtrace
+	alignment := make([]AlignedWord, 0, n+m)
+	i, j := n, m
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && ref[i-1] == hyp[j-1] && dist[i][j] == dist[i-1][j-1]:
+			alignment = append(alignment, AlignedWord{Op: OpEqual, Reference: ref[i-1], Hypothesis: hyp[j-1]})
+			i--
+			j--
+		case i > 0 && j > 0 && dist[i][j] == dist[i-1][j-1]+1:
+			alignment = append(alignment, AlignedWord{Op: OpSubstitute, Reference: ref[i-1], Hypothesis: hyp[j-1]})
+			i--
+			j--
+		case i > 0 && dist[i][j] == dist[i-1][j]+1:
+			alignment = append(alignment, AlignedWord{Op: OpDelete, Reference: ref[i-1]})
+			i--
+		default:
+			alignment = append(alignment, AlignedWord{Op: OpInsert, Hypothesis: hyp[j-1]})
+			j--
+		}
+	}
+
+	// reverse into reading order
+	for l, r := 0, len(alignment)-1; l < r; l, r = l+1, r-1 {
+		alignment[l], alignment[r] = alignment[r], alignment[l]
+	}
+
+	return alignment
+}
+
+// charDistance computes the Levenshtein distance between two strings using two rows
+func charDistance(ref, hyp []rune) int {
+	prev := make([]int, len(hyp)+1)
+	curr := make([]int, len(hyp)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ref); i++ {
+		curr[0] = i
+		for j := 1; j <= len(hyp); j++ {
+			cost := 1
+			if ref[i-1] == hyp[j-1] {
+				cost = 0
+			}
+			curr[j] = minOf(prev[j-1]+cost, prev[j]+1, curr[j-1]+1)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(hyp)]
+}
+
+func minOf(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,254 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package eval
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	klog "k8s.io/klog/v2"
+
+	listen "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+)
+
+// NewEvaluator creates an Evaluator using the given prerecorded client
+func NewEvaluator(c *client.Client) *Evaluator {
+	return &Evaluator{client: c}
+}
+
+/*
+EvaluateDir transcribes every audio file in dir and evaluates it against the reference transcript
+with the same base name (ie call1.wav is compared with call1.txt).
+
+Files without a reference are reported with ErrNoReference and excluded from the totals.
+*/
+func (e *Evaluator) EvaluateDir(ctx context.Context, dir string, opts *BatchOptions) (*Report, error) {
+	klog.V(6).Infof("eval.EvaluateDir ENTER\n")
+
+	if e.client == nil || dir == "" {
+		klog.V(1).Infof("eval.EvaluateDir: client or dir is missing\n")
+		klog.V(6).Infof("eval.EvaluateDir LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+	// defaults are applied to a copy, leaving the caller's options alone
+	var batchOpts BatchOptions
+	if opts != nil {
+		batchOpts = *opts
+	}
+	if batchOpts.Normalization == nil {
+		normalization := DefaultNormalization()
+		batchOpts.Normalization = &normalization
+	}
+	if batchOpts.Transcription == nil {
+		batchOpts.Transcription = &interfaces.PreRecordedTranscriptionOptions{}
+	}
+	opts = &batchOpts
+
+	files, err := audioFiles(dir, opts.AudioExtensions)
+	if err != nil {
+		klog.V(1).Infof("audioFiles failed. Err: %v\n", err)
+		klog.V(6).Infof("eval.EvaluateDir LEAVE\n")
+		return nil, err
+	}
+	klog.V(4).Infof("Found %d audio files in %s\n", len(files), dir)
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	report := &Report{
+		Model: opts.Transcription.Model,
+		Items: make([]ItemResult, len(files)),
+	}
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range work {
+				report.Items[idx] = e.evaluateFile(ctx, files[idx], opts)
+			}
+		}()
+	}
+
+dispatch:
+	for idx := range files {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case work <- idx:
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	for idx := range report.Items {
+		item := &report.Items[idx]
+		if item.File == "" {
+			item.File = files[idx]
+			item.Error = ctx.Err().Error()
+			continue
+		}
+		if item.Result != nil {
+			report.Total.add(item.Result)
+			if !opts.IncludeAlignment {
+				item.Result.Alignment = nil
+			}
+		}
+	}
+
+	klog.V(3).Infof("EvaluateDir: WER %.4f over %d files\n", report.Total.WER, len(files))
+	klog.V(6).Infof("eval.EvaluateDir LEAVE\n")
+
+	return report, ctx.Err()
+}
+
+func (e *Evaluator) evaluateFile(ctx context.Context, file string, opts *BatchOptions) ItemResult {
+	item := ItemResult{File: file}
+
+	reference, err := readReference(file, opts)
+	if err != nil {
+		klog.V(1).Infof("readReference(%s) failed. Err: %v\n", file, err)
+		item.Error = err.Error()
+		return item
+	}
+	item.Reference = reference
+
+	// copy the options since requests run concurrently
+	tOptions := *opts.Transcription
+
+	resp, err := listen.New(e.client).FromFile(ctx, file, &tOptions)
+	if err != nil {
+		klog.V(1).Infof("FromFile(%s) failed. Err: %v\n", file, err)
+		item.Error = err.Error()
+		return item
+	}
+	if resp.Metadata != nil {
+		item.RequestID = resp.Metadata.RequestID
+	}
+
+	item.Hypothesis, err = TranscriptOf(resp)
+	if err != nil && err != ErrNoTranscript {
+		item.Error = err.Error()
+		return item
+	}
+
+	item.Result = CompareText(item.Hypothesis, reference, *opts.Normalization)
+	return item
+}
+
+// WriteJSON writes the report as JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes one row per file followed by a TOTAL row
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"file", "request_id", "wer", "cer", "hits", "substitutions", "insertions", "deletions", "reference_words", "hypothesis_words", "error"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, item := range r.Items {
+		if err := writer.Write(csvRow(item.File, item.RequestID, item.Result, item.Error)); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write(csvRow("TOTAL", "", &r.Total, "")); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+/*
+helpers
+*/
+func csvRow(file, requestID string, result *Result, errStr string) []string {
+	if result == nil {
+		return []string{file, requestID, "", "", "", "", "", "", "", "", errStr}
+	}
+	return []string{
+		file,
+		requestID,
+		strconv.FormatFloat(result.WER, 'f', 4, 64),
+		strconv.FormatFloat(result.CER, 'f', 4, 64),
+		strconv.Itoa(result.Hits),
+		strconv.Itoa(result.Substitutions),
+		strconv.Itoa(result.Insertions),
+		strconv.Itoa(result.Deletions),
+		strconv.Itoa(result.ReferenceWords),
+		strconv.Itoa(result.HypothesisWords),
+		errStr,
+	}
+}
+
+func audioFiles(dir string, extensions []string) ([]string, error) {
+	if len(extensions) == 0 {
+		extensions = DefaultAudioExtensions
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		for _, allowed := range extensions {
+			if ext == strings.ToLower(allowed) {
+				files = append(files, filepath.Join(dir, entry.Name()))
+				break
+			}
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func readReference(audioFile string, opts *BatchOptions) (string, error) {
+	ext := opts.ReferenceExt
+	if ext == "" {
+		ext = defaultReferenceExt
+	}
+	dir := opts.ReferenceDir
+	if dir == "" {
+		dir = filepath.Dir(audioFile)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(audioFile), filepath.Ext(audioFile))
+	byData, err := os.ReadFile(filepath.Join(dir, base+ext))
+	if os.IsNotExist(err) {
+		return "", ErrNoReference
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(byData)), nil
+}
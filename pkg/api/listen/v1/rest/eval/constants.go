@@ -0,0 +1,44 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package eval
+
+import (
+	"errors"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// Op is a single edit operation in an alignment
+type Op string
+
+const (
+	OpEqual      Op = "equal"
+	OpSubstitute Op = "substitute"
+	OpInsert     Op = "insert"
+	OpDelete     Op = "delete"
+)
+
+// defaults for batch evaluation
+const (
+	defaultReferenceExt string = ".txt"
+	defaultConcurrency  int    = 4
+)
+
+// DefaultAudioExtensions are the file extensions picked up by EvaluateDir
+var DefaultAudioExtensions = []string{".wav", ".mp3", ".flac", ".ogg", ".opus", ".m4a", ".mp4", ".webm", ".aac"}
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrNoTranscript the response does not contain a transcript
+	ErrNoTranscript = errors.New("response does not contain a transcript")
+
+	// ErrNoReference a reference transcript was not found for the audio file
+	ErrNoReference = errors.New("reference transcript not found")
+)
@@ -0,0 +1,156 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package evaluates transcripts against a reference by computing WER, CER and a word alignment.
+
+It is intended for comparing models (ie nova-2 vs nova-3) and option sets, either one response
+at a time or in batch over a directory of audio files and reference transcripts.
+*/
+package eval
+
+import (
+	"strings"
+
+	klog "k8s.io/klog/v2"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+)
+
+// CompareText evaluates a hypothesis against a reference transcript
+func CompareText(hypothesis, reference string, n Normalization) *Result {
+	ref := Normalize(reference, n)
+	hyp := Normalize(hypothesis, n)
+
+	result := &Result{
+		ReferenceWords:  len(ref),
+		HypothesisWords: len(hyp),
+		Alignment:       align(ref, hyp),
+	}
+
+	for _, a := range result.Alignment {
+		switch a.Op {
+		case OpEqual:
+			result.Hits++
+		case OpSubstitute:
+			result.Substitutions++
+		case OpInsert:
+			result.Insertions++
+		case OpDelete:
+			result.Deletions++
+		}
+	}
+
+	refChars := []rune(strings.Join(ref, " "))
+	hypChars := []rune(strings.Join(hyp, " "))
+	result.ReferenceChars = len(refChars)
+	result.CharErrors = charDistance(refChars, hypChars)
+
+	result.computeRates()
+
+	klog.V(5).Infof("eval: WER %.4f CER %.4f (S:%d I:%d D:%d N:%d)\n",
+		result.WER, result.CER, result.Substitutions, result.Insertions, result.Deletions, result.ReferenceWords)
+
+	return result
+}
+
+// Compare evaluates a transcription response against a reference transcript
+func Compare(hypothesis *api.PreRecordedResponse, reference string, n Normalization) (*Result, error) {
+	text, err := TranscriptOf(hypothesis)
+	if err != nil {
+		klog.V(1).Infof("TranscriptOf failed. Err: %v\n", err)
+		return nil, err
+	}
+
+	return CompareText(text, reference, n), nil
+}
+
+// CompareResponses evaluates one transcription response against another used as the reference
+func CompareResponses(hypothesis, reference *api.PreRecordedResponse, n Normalization) (*Result, error) {
+	refText, err := TranscriptOf(reference)
+	if err != nil {
+		klog.V(1).Infof("TranscriptOf(reference) failed. Err: %v\n", err)
+		return nil, err
+	}
+
+	return Compare(hypothesis, refText, n)
+}
+
+// TranscriptOf returns the transcript of the first alternative of every channel joined together
+func TranscriptOf(resp *api.PreRecordedResponse) (string, error) {
+	if resp == nil || resp.Results == nil {
+		return "", ErrInvalidInput
+	}
+
+	parts := make([]string, 0, len(resp.Results.Channels))
+	for _, channel := range resp.Results.Channels {
+		if len(channel.Alternatives) == 0 {
+			continue
+		}
+		if t := strings.TrimSpace(channel.Alternatives[0].Transcript); t != "" {
+			parts = append(parts, t)
+		}
+	}
+	if len(parts) == 0 {
+		return "", ErrNoTranscript
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+/*
+Diff renders the alignment using word-diff notation:
+
+	the [-cat-]{+bat+} sat {+down+} on [-the-] mat
+*/
+func (r *Result) Diff() string {
+	parts := make([]string, 0, len(r.Alignment))
+	for _, a := range r.Alignment {
+		switch a.Op {
+		case OpEqual:
+			parts = append(parts, a.Reference)
+		case OpSubstitute:
+			parts = append(parts, "[-"+a.Reference+"-]{+"+a.Hypothesis+"+}")
+		case OpInsert:
+			parts = append(parts, "{+"+a.Hypothesis+"+}")
+		case OpDelete:
+			parts = append(parts, "[-"+a.Reference+"-]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Errors returns the total number of word errors
+func (r *Result) Errors() int {
+	return r.Substitutions + r.Insertions + r.Deletions
+}
+
+// add accumulates the counts from another result
+func (r *Result) add(o *Result) {
+	r.Hits += o.Hits
+	r.Substitutions += o.Substitutions
+	r.Insertions += o.Insertions
+	r.Deletions += o.Deletions
+	r.ReferenceWords += o.ReferenceWords
+	r.HypothesisWords += o.HypothesisWords
+	r.ReferenceChars += o.ReferenceChars
+	r.CharErrors += o.CharErrors
+	r.computeRates()
+}
+
+func (r *Result) computeRates() {
+	r.WER = rate(r.Errors(), r.ReferenceWords, r.HypothesisWords)
+	r.CER = rate(r.CharErrors, r.ReferenceChars, r.CharErrors)
+}
+
+// rate returns errors/total. An empty reference is a perfect score only if the hypothesis is also empty.
+func rate(errs, total, hypTotal int) float64 {
+	if total == 0 {
+		if hypTotal == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(errs) / float64(total)
+}
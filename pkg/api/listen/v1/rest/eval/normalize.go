@@ -0,0 +1,194 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package eval
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	unitWords = map[string]int{
+		"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4,
+		"five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9,
+	}
+	teenWords = map[string]int{
+		"ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14,
+		"fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+	}
+	tensWords = map[string]int{
+		"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+		"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	}
+	scaleWords = map[string]int{
+		"thousand": 1000, "million": 1000000, "billion": 1000000000,
+	}
+	fillerWords = map[string]bool{
+		"um": true, "uh": true, "uhm": true, "umm": true, "erm": true,
+		"er": true, "ah": true, "hmm": true, "mm": true, "mhm": true,
+	}
+)
+
+// DefaultNormalization enables every normalization step
+func DefaultNormalization() Normalization {
+	return Normalization{
+		Lowercase:         true,
+		RemovePunctuation: true,
+		Numerals:          true,
+		RemoveFillers:     true,
+	}
+}
+
+// Normalize cleans text and splits it into words
+func Normalize(text string, n Normalization) []string {
+	if n.Lowercase {
+		text = strings.ToLower(text)
+	}
+	if n.RemovePunctuation {
+		text = stripPunctuation(text)
+	}
+
+	words := strings.Fields(text)
+
+	if n.RemoveFillers {
+		kept := make([]string, 0, len(words))
+		for _, w := range words {
+			if !fillerWords[strings.ToLower(w)] {
+				kept = append(kept, w)
+			}
+		}
+		words = kept
+	}
+	if n.Numerals {
+		words = wordsToNumerals(words)
+	}
+
+	return words
+}
+
+// stripPunctuation removes punctuation while keeping apostrophes inside words and separators inside numbers
+func stripPunctuation(text string) string {
+	runes := []rune(text)
+	var sb strings.Builder
+
+	for i, r := range runes {
+		if !unicode.IsPunct(r) && !unicode.IsSymbol(r) {
+			sb.WriteRune(r)
+			continue
+		}
+
+		prevLetter := i > 0 && unicode.IsLetter(runes[i-1])
+		nextLetter := i+1 < len(runes) && unicode.IsLetter(runes[i+1])
+		prevDigit := i > 0 && unicode.IsDigit(runes[i-1])
+		nextDigit := i+1 < len(runes) && unicode.IsDigit(runes[i+1])
+
+		switch {
+		case (r == '\'' || r == '’') && prevLetter && nextLetter:
+			sb.WriteRune('\'')
+		case r == '.' && prevDigit && nextDigit:
+			sb.WriteRune(r)
+		case r == ',' && prevDigit && nextDigit:
+			// thousands separator, drop it
+		case r == '%' || r == '$':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(' ')
+		}
+	}
+
+	return sb.String()
+}
+
+// wordsToNumerals converts runs of spelled out numbers to digits
+func wordsToNumerals(words []string) []string {
+	out := make([]string, 0, len(words))
+
+	const (
+		kindNone = iota
+		kindUnit
+		kindTeen
+		kindTens
+		kindHundred
+		kindScale
+	)
+
+	inNumber := false
+	total, current := 0, 0
+	last := kindNone
+
+	flush := func() {
+		if inNumber {
+			out = append(out, strconv.Itoa(total+current))
+		}
+		inNumber = false
+		total, current = 0, 0
+		last = kindNone
+	}
+
+	for i, w := range words {
+		lw := strings.ToLower(w)
+
+		if v, ok := unitWords[lw]; ok {
+			if last == kindUnit || last == kindTeen {
+				flush()
+			}
+			current += v
+			inNumber = true
+			last = kindUnit
+			continue
+		}
+		if v, ok := teenWords[lw]; ok {
+			if last == kindUnit || last == kindTeen || last == kindTens {
+				flush()
+			}
+			current += v
+			inNumber = true
+			last = kindTeen
+			continue
+		}
+		if v, ok := tensWords[lw]; ok {
+			if last == kindUnit || last == kindTeen || last == kindTens {
+				flush()
+			}
+			current += v
+			inNumber = true
+			last = kindTens
+			continue
+		}
+		if lw == "hundred" && inNumber {
+			if current == 0 {
+				current = 1
+			}
+			current *= 100
+			last = kindHundred
+			continue
+		}
+		if v, ok := scaleWords[lw]; ok && inNumber {
+			if current == 0 {
+				current = 1
+			}
+			total += current * v
+			current = 0
+			last = kindScale
+			continue
+		}
+		if lw == "and" && inNumber && (last == kindHundred || last == kindScale) && i+1 < len(words) {
+			next := strings.ToLower(words[i+1])
+			_, isUnit := unitWords[next]
+			_, isTeen := teenWords[next]
+			_, isTens := tensWords[next]
+			if isUnit || isTeen || isTens {
+				continue
+			}
+		}
+
+		flush()
+		out = append(out, w)
+	}
+	flush()
+
+	return out
+}
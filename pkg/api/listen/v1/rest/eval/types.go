@@ -0,0 +1,91 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package eval
+
+import (
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+)
+
+// Normalization controls how text is cleaned before comparing
+type Normalization struct {
+	Lowercase         bool
+	RemovePunctuation bool
+	Numerals          bool // converts spelled out numbers to digits ("twenty one" -> "21")
+	RemoveFillers     bool // drops filler words (ie "um", "uh")
+}
+
+// AlignedWord is a single step in the word alignment between the reference and the hypothesis
+type AlignedWord struct {
+	Op         Op     `json:"op"`
+	Reference  string `json:"reference,omitempty"`
+	Hypothesis string `json:"hypothesis,omitempty"`
+}
+
+// Result is the evaluation of a hypothesis against a reference
+type Result struct {
+	WER float64 `json:"wer"`
+	CER float64 `json:"cer"`
+
+	Hits          int `json:"hits"`
+	Substitutions int `json:"substitutions"`
+	Insertions    int `json:"insertions"`
+	Deletions     int `json:"deletions"`
+
+	ReferenceWords  int `json:"reference_words"`
+	HypothesisWords int `json:"hypothesis_words"`
+	ReferenceChars  int `json:"reference_chars"`
+	CharErrors      int `json:"char_errors"`
+
+	Alignment []AlignedWord `json:"alignment,omitempty"`
+}
+
+// BatchOptions configures EvaluateDir
+type BatchOptions struct {
+	// Options sent with every transcription request
+	Transcription *interfaces.PreRecordedTranscriptionOptions
+
+	// Normalization applied to both the reference and the hypothesis. Defaults to DefaultNormalization when nil.
+	Normalization *Normalization
+
+	// ReferenceDir holds the reference transcripts. Defaults to the audio directory.
+	ReferenceDir string
+
+	// ReferenceExt is the extension of the reference files. Defaults to ".txt".
+	ReferenceExt string
+
+	// AudioExtensions restricts which files are evaluated. Defaults to DefaultAudioExtensions.
+	AudioExtensions []string
+
+	// Concurrency is the number of simultaneous transcription requests. Defaults to 4.
+	Concurrency int
+
+	// IncludeAlignment keeps the word alignment for every item in the report
+	IncludeAlignment bool
+}
+
+// ItemResult is the evaluation of a single audio file
+type ItemResult struct {
+	File       string  `json:"file"`
+	Reference  string  `json:"reference,omitempty"`
+	Hypothesis string  `json:"hypothesis,omitempty"`
+	RequestID  string  `json:"request_id,omitempty"`
+	Result     *Result `json:"result,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// Report is the output of a batch evaluation
+type Report struct {
+	Model string       `json:"model,omitempty"`
+	Items []ItemResult `json:"items"`
+
+	// corpus level totals across every successful item
+	Total Result `json:"total"`
+}
+
+// Evaluator runs batch evaluations against the prerecorded API
+type Evaluator struct {
+	client *client.Client
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package redact
+
+import (
+	"errors"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// labels used by the built-in rules
+const (
+	LabelCreditCard string = "pci"
+	LabelSSN        string = "ssn"
+	LabelEmail      string = "email"
+	LabelPhone      string = "phone"
+)
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrNoRules no redaction rules were supplied
+	ErrNoRules = errors.New("no redaction rules were supplied")
+
+	// ErrInvalidRule the rule definition is not valid
+	ErrInvalidRule = errors.New("invalid redaction rule")
+)
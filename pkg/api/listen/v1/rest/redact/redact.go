@@ -0,0 +1,461 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package provides client-side redaction of PreRecordedResponse transcripts.
+
+Rules are evaluated once over the words of each channel so that Word, Sentence, Paragraph
+and Utterance text are all redacted consistently, and the redacted regions are reported as
+time spans which can be used to bleep the original audio.
+*/
+package redact
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	klog "k8s.io/klog/v2"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+)
+
+// trailingPunctuation is stripped from punctuated words before searching the text fields
+const trailingPunctuation = ".,?!;:"
+
+// alignLookahead is how many transcript tokens ahead a word is looked for when aligning words with text
+const alignLookahead = 3
+
+// trackedWord keeps the original text of a word so its value can be removed from the text fields.
+// label is empty for words which were not redacted.
+type trackedWord struct {
+	label    string
+	original []string
+	start    float64
+	end      float64
+}
+
+// token is a whitespace separated run of text, without its trailing punctuation
+type token struct {
+	start int
+	end   int
+}
+
+// New creates a Redactor
+func New(opts Options) (*Redactor, error) {
+	if len(opts.Rules) == 0 {
+		klog.V(1).Infof("redact.New: no rules provided\n")
+		return nil, ErrNoRules
+	}
+
+	r := &Redactor{
+		rules:   opts.Rules,
+		replace: opts.Replace,
+		keep:    opts.KeepUnredacted,
+	}
+	if r.replace == nil {
+		r.replace = defaultReplace
+	}
+
+	return r, nil
+}
+
+// NewWithDefaults creates a Redactor using the built-in detectors
+func NewWithDefaults() *Redactor {
+	r, _ := New(Options{Rules: DefaultRules()})
+	return r
+}
+
+// Redact returns a redacted copy of the response along with the redacted time spans.
+// The response passed in is not modified.
+func (r *Redactor) Redact(resp *api.PreRecordedResponse) (*Result, error) {
+	klog.V(6).Infof("redact.Redact ENTER\n")
+
+	if resp == nil {
+		klog.V(1).Infof("redact.Redact: response is nil\n")
+		klog.V(6).Infof("redact.Redact LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+
+	redacted, err := deepCopy(resp)
+	if err != nil {
+		klog.V(1).Infof("deepCopy failed. Err: %v\n", err)
+		klog.V(6).Infof("redact.Redact LEAVE\n")
+		return nil, err
+	}
+
+	result := &Result{
+		Redacted: redacted,
+		Spans:    make([]Span, 0),
+	}
+	if r.keep {
+		result.Unredacted, err = deepCopy(resp)
+		if err != nil {
+			klog.V(1).Infof("deepCopy failed. Err: %v\n", err)
+			klog.V(6).Infof("redact.Redact LEAVE\n")
+			return nil, err
+		}
+	}
+
+	if redacted.Results == nil {
+		klog.V(3).Infof("redact.Redact: response has no results\n")
+		klog.V(6).Infof("redact.Redact LEAVE\n")
+		return result, nil
+	}
+
+	for chIdx := range redacted.Results.Channels {
+		channel := &redacted.Results.Channels[chIdx]
+		for altIdx := range channel.Alternatives {
+			spans := r.redactAlternative(&channel.Alternatives[altIdx], chIdx)
+
+			// alternatives share audio, so only report spans once per channel
+			if altIdx == 0 {
+				result.Spans = append(result.Spans, spans...)
+			}
+		}
+	}
+
+	useUtterances := len(redacted.Results.Channels) == 0
+	for i := range redacted.Results.Utterances {
+		utterance := &redacted.Results.Utterances[i]
+
+		spans, words := r.redactWords(utterance.Words, utterance.Channel)
+		utterance.Transcript = r.redactWindow(utterance.Transcript, words, utterance.Start, utterance.End)
+
+		if useUtterances {
+			result.Spans = append(result.Spans, spans...)
+		}
+	}
+
+	sort.SliceStable(result.Spans, func(i, j int) bool {
+		if result.Spans[i].Start == result.Spans[j].Start {
+			return result.Spans[i].Channel < result.Spans[j].Channel
+		}
+		return result.Spans[i].Start < result.Spans[j].Start
+	})
+
+	klog.V(4).Infof("redact.Redact produced %d spans\n", len(result.Spans))
+	klog.V(6).Infof("redact.Redact LEAVE\n")
+
+	return result, nil
+}
+
+// RedactJSON redacts a stored PreRecordedResponse and returns the JSON encoded Result
+func (r *Redactor) RedactJSON(byData []byte) ([]byte, error) {
+	var resp api.PreRecordedResponse
+	if err := json.Unmarshal(byData, &resp); err != nil {
+		klog.V(1).Infof("json.Unmarshal(PreRecordedResponse) failed. Err: %v\n", err)
+		return nil, err
+	}
+
+	result, err := r.Redact(&resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
+
+// RedactText applies the rules to a standalone piece of text
+func (r *Redactor) RedactText(text string) (string, []Match) {
+	matches := r.find(text)
+	return r.apply(text, matches), matches
+}
+
+func (r *Redactor) redactAlternative(alt *api.Alternative, channel int) []Span {
+	spans, words := r.redactWords(alt.Words, channel)
+
+	alt.Transcript = r.redactWindow(alt.Transcript, words, -1, -1)
+
+	if alt.Paragraphs != nil {
+		alt.Paragraphs.Transcript = r.redactWindow(alt.Paragraphs.Transcript, words, -1, -1)
+
+		for p := range alt.Paragraphs.Paragraphs {
+			paragraph := &alt.Paragraphs.Paragraphs[p]
+			for s := range paragraph.Sentences {
+				sentence := &paragraph.Sentences[s]
+				sentence.Text = r.redactWindow(sentence.Text, words, sentence.Start, sentence.End)
+			}
+		}
+	}
+
+	return spans
+}
+
+// redactWords runs the rules over the joined words and redacts every word touched by a match. It
+// returns every word, in order, for redactWindow.
+func (r *Redactor) redactWords(words []api.Word, channel int) ([]Span, []trackedWord) {
+	spans := make([]Span, 0)
+	tracked := make([]trackedWord, len(words))
+	if len(words) == 0 {
+		return spans, tracked
+	}
+
+	for i := range words {
+		original := []string{words[i].Word}
+		if words[i].PunctuatedWord != "" {
+			original = append(original, words[i].PunctuatedWord)
+		}
+		tracked[i] = trackedWord{original: original, start: words[i].Start, end: words[i].End}
+	}
+
+	// build the text once so matches can span multiple words
+	offsets := make([]int, len(words))
+	var sb strings.Builder
+	for i := range words {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		offsets[i] = sb.Len()
+		sb.WriteString(wordText(&words[i]))
+	}
+	text := sb.String()
+
+	for _, m := range r.find(text) {
+		first, last := -1, -1
+		for i := range words {
+			wStart := offsets[i]
+			wEnd := wStart + len(wordText(&words[i]))
+			if wEnd <= m.Start || wStart >= m.End {
+				continue
+			}
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+		if first < 0 {
+			continue
+		}
+
+		for i := first; i <= last; i++ {
+			word := &words[i]
+			if word.PunctuatedWord != "" {
+				word.PunctuatedWord = r.replace(m.Label, word.PunctuatedWord)
+			}
+			word.Word = r.replace(m.Label, word.Word)
+			tracked[i].label = m.Label
+		}
+
+		spans = append(spans, Span{
+			Label:     m.Label,
+			Channel:   channel,
+			Start:     words[first].Start,
+			End:       words[last].End,
+			FirstWord: first,
+			LastWord:  last,
+		})
+	}
+
+	return spans, tracked
+}
+
+/*
+redactWindow redacts text using the rules and the words already redacted within [start, end]. A
+negative start or end means the window is unbounded.
+
+The words of the window are aligned with the text in order, so a redacted value only removes its own
+occurrence and not the same text said elsewhere. A redacted word which can't be aligned, for example
+because the transcript is formatted differently, is looked for between its aligned neighbours.
+*/
+func (r *Redactor) redactWindow(text string, words []trackedWord, start, end float64) string {
+	if text == "" {
+		return text
+	}
+
+	matches := r.find(text)
+	tokens := tokenize(text)
+
+	next := 0        // the next token to align
+	regionStart := 0 // the end of the last aligned token
+	unaligned := make([]trackedWord, 0)
+
+	// unaligned redacted words are searched for in the text since the last aligned word
+	searchUnaligned := func(regionEnd int) {
+		for _, w := range unaligned {
+			for _, original := range w.original {
+				original = strings.TrimRight(original, trailingPunctuation)
+				for _, m := range findWord(text[regionStart:regionEnd], original, w.label) {
+					matches = append(matches, Match{Label: m.Label, Start: regionStart + m.Start, End: regionStart + m.End})
+				}
+			}
+		}
+		unaligned = unaligned[:0]
+	}
+
+	for _, w := range words {
+		if start >= 0 && w.end < start {
+			continue
+		}
+		if end >= 0 && w.start > end {
+			continue
+		}
+
+		idx := alignWord(text, tokens, next, w)
+		if idx < 0 {
+			if w.label != "" {
+				unaligned = append(unaligned, w)
+			}
+			continue
+		}
+
+		t := tokens[idx]
+		searchUnaligned(t.start)
+		if w.label != "" {
+			matches = append(matches, Match{Label: w.label, Start: t.start, End: t.end})
+		}
+		next = idx + 1
+		regionStart = t.end
+	}
+	searchUnaligned(len(text))
+
+	return r.apply(text, mergeMatches(matches))
+}
+
+// find runs every rule and returns the merged, non-overlapping matches
+func (r *Redactor) find(text string) []Match {
+	matches := make([]Match, 0)
+	for _, rule := range r.rules {
+		matches = append(matches, rule.Find(text)...)
+	}
+	return mergeMatches(matches)
+}
+
+// apply replaces the matches in text. matches must be sorted and non-overlapping.
+func (r *Redactor) apply(text string, matches []Match) string {
+	if len(matches) == 0 {
+		return text
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		sb.WriteString(text[last:m.Start])
+		sb.WriteString(r.replace(m.Label, text[m.Start:m.End]))
+		last = m.End
+	}
+	sb.WriteString(text[last:])
+
+	return sb.String()
+}
+
+/*
+helpers
+*/
+func defaultReplace(label string, _ string) string {
+	return "[" + strings.ToUpper(label) + "]"
+}
+
+func wordText(w *api.Word) string {
+	if w.PunctuatedWord != "" {
+		return w.PunctuatedWord
+	}
+	return w.Word
+}
+
+// tokenize splits text on spaces, leaving the trailing punctuation out of each token
+func tokenize(text string) []token {
+	tokens := make([]token, 0)
+	for i := 0; i < len(text); {
+		if text[i] == ' ' {
+			i++
+			continue
+		}
+		j := i
+		for j < len(text) && text[j] != ' ' {
+			j++
+		}
+		end := i + len(strings.TrimRight(text[i:j], trailingPunctuation))
+		if end > i {
+			tokens = append(tokens, token{start: i, end: end})
+		}
+		i = j
+	}
+	return tokens
+}
+
+// alignWord returns the index of the token within alignLookahead of next which is the word, or -1
+func alignWord(text string, tokens []token, next int, w trackedWord) int {
+	for idx := next; idx < len(tokens) && idx < next+alignLookahead; idx++ {
+		t := tokens[idx]
+		for _, original := range w.original {
+			if text[t.start:t.end] == strings.TrimRight(original, trailingPunctuation) {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+// findWord locates whole word occurrences of word in text
+func findWord(text, word, label string) []Match {
+	matches := make([]Match, 0)
+	if word == "" {
+		return matches
+	}
+
+	offset := 0
+	for {
+		idx := strings.Index(text[offset:], word)
+		if idx < 0 {
+			break
+		}
+		start := offset + idx
+		end := start + len(word)
+		if (start == 0 || text[start-1] == ' ') && (end == len(text) || !isWordByte(text[end])) {
+			matches = append(matches, Match{Label: label, Start: start, End: end})
+		}
+		offset = end
+	}
+
+	return matches
+}
+
+func isWordByte(c byte) bool {
+	return c != ' ' && !strings.ContainsRune(trailingPunctuation, rune(c))
+}
+
+// mergeMatches sorts matches and collapses overlaps, keeping the label of the earliest match
+func mergeMatches(matches []Match) []Match {
+	if len(matches) < 2 {
+		return matches
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Start == matches[j].Start {
+			return matches[i].End > matches[j].End
+		}
+		return matches[i].Start < matches[j].Start
+	})
+
+	merged := make([]Match, 0, len(matches))
+	current := matches[0]
+	for _, m := range matches[1:] {
+		if m.Start < current.End {
+			if m.End > current.End {
+				current.End = m.End
+			}
+			continue
+		}
+		merged = append(merged, current)
+		current = m
+	}
+	merged = append(merged, current)
+
+	return merged
+}
+
+func deepCopy(resp *api.PreRecordedResponse) (*api.PreRecordedResponse, error) {
+	byData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var cp api.PreRecordedResponse
+	if err := json.Unmarshal(byData, &cp); err != nil {
+		return nil, err
+	}
+
+	return &cp, nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package redact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	creditCardRegex = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	ssnRegex        = regexp.MustCompile(`\b(\d{3})[- ]?(\d{2})[- ]?(\d{4})\b`)
+	emailRegex      = regexp.MustCompile(`(?i)\b[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}\b`)
+	phoneRegex      = regexp.MustCompile(`(?:\+?\d{1,3}[ .\-]?)?(?:\(\d{2,4}\)|\d{2,4})[ .\-]?\d{3,4}[ .\-]?\d{3,4}\b`)
+)
+
+// DefaultRules returns the built-in detectors for card numbers, SSNs, emails and phone numbers
+func DefaultRules() []Rule {
+	return []Rule{
+		NewCreditCardRule(),
+		NewSSNRule(),
+		NewEmailRule(),
+		NewPhoneRule(),
+	}
+}
+
+/*
+Regex rule
+*/
+type regexRule struct {
+	label    string
+	re       *regexp.Regexp
+	validate func(match string) bool
+}
+
+// NewRegexRule creates a rule which redacts anything matching pattern
+func NewRegexRule(label, pattern string) (Rule, error) {
+	if label == "" || pattern == "" {
+		return nil, ErrInvalidRule
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return &regexRule{label: label, re: re}, nil
+}
+
+// Label returns the label for the rule
+func (r *regexRule) Label() string {
+	return r.label
+}
+
+// Find returns all matches in text
+func (r *regexRule) Find(text string) []Match {
+	matches := make([]Match, 0)
+	for _, loc := range r.re.FindAllStringIndex(text, -1) {
+		if r.validate != nil && !r.validate(text[loc[0]:loc[1]]) {
+			continue
+		}
+		matches = append(matches, Match{Label: r.label, Start: loc[0], End: loc[1]})
+	}
+	return matches
+}
+
+// NewCreditCardRule detects 13 to 19 digit card numbers which pass the Luhn check
+func NewCreditCardRule() Rule {
+	return &regexRule{
+		label: LabelCreditCard,
+		re:    creditCardRegex,
+		validate: func(match string) bool {
+			return luhnValid(digitsOnly(match))
+		},
+	}
+}
+
+// NewSSNRule detects US social security numbers
+func NewSSNRule() Rule {
+	return &regexRule{
+		label: LabelSSN,
+		re:    ssnRegex,
+		validate: func(match string) bool {
+			parts := ssnRegex.FindStringSubmatch(match)
+			if len(parts) != 4 {
+				return false
+			}
+			area, group, serial := parts[1], parts[2], parts[3]
+			if area == "000" || area == "666" || area[0] == '9' {
+				return false
+			}
+			return group != "00" && serial != "0000"
+		},
+	}
+}
+
+// NewEmailRule detects email addresses
+func NewEmailRule() Rule {
+	return &regexRule{
+		label: LabelEmail,
+		re:    emailRegex,
+	}
+}
+
+// NewPhoneRule detects phone numbers containing 10 to 15 digits
+func NewPhoneRule() Rule {
+	return &regexRule{
+		label: LabelPhone,
+		re:    phoneRegex,
+		validate: func(match string) bool {
+			cnt := len(digitsOnly(match))
+			return cnt >= 10 && cnt <= 15
+		},
+	}
+}
+
+/*
+Dictionary rule
+*/
+type dictionaryRule struct {
+	label    string
+	patterns []*regexp.Regexp
+}
+
+// NewDictionaryRule creates a rule which redacts any of the given terms when they appear as whole words
+func NewDictionaryRule(label string, terms []string, caseSensitive bool) (Rule, error) {
+	if label == "" || len(terms) == 0 {
+		return nil, ErrInvalidRule
+	}
+
+	cleaned := make([]string, 0, len(terms))
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		cleaned = append(cleaned, term)
+	}
+	if len(cleaned) == 0 {
+		return nil, ErrInvalidRule
+	}
+
+	// longest first so multi-word terms win over their prefixes
+	sort.SliceStable(cleaned, func(i, j int) bool {
+		return len(cleaned[i]) > len(cleaned[j])
+	})
+
+	// matching the original text keeps the offsets valid, which lowercasing it would not for every rune
+	flags := ""
+	if !caseSensitive {
+		flags = "(?i)"
+	}
+	patterns := make([]*regexp.Regexp, 0, len(cleaned))
+	for _, term := range cleaned {
+		patterns = append(patterns, regexp.MustCompile(flags+regexp.QuoteMeta(term)))
+	}
+
+	return &dictionaryRule{
+		label:    label,
+		patterns: patterns,
+	}, nil
+}
+
+// Label returns the label for the rule
+func (r *dictionaryRule) Label() string {
+	return r.label
+}
+
+// Find returns all whole word occurrences of the dictionary terms in text
+func (r *dictionaryRule) Find(text string) []Match {
+	matches := make([]Match, 0)
+	for _, re := range r.patterns {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			if isBoundaryBefore(text, loc[0]) && isBoundaryAfter(text, loc[1]) {
+				matches = append(matches, Match{Label: r.label, Start: loc[0], End: loc[1]})
+			}
+		}
+	}
+
+	return matches
+}
+
+/*
+helpers
+*/
+// isBoundaryBefore is true when the rune ending at idx is not part of a word
+func isBoundaryBefore(text string, idx int) bool {
+	if idx <= 0 {
+		return true
+	}
+	c, _ := utf8.DecodeLastRuneInString(text[:idx])
+	return !isWordRune(c)
+}
+
+// isBoundaryAfter is true when the rune starting at idx is not part of a word
+func isBoundaryAfter(text string, idx int) bool {
+	if idx >= len(text) {
+		return true
+	}
+	c, _ := utf8.DecodeRuneInString(text[idx:])
+	return !isWordRune(c)
+}
+
+func isWordRune(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_'
+}
+
+func digitsOnly(s string) string {
+	var sb strings.Builder
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+// luhnValid performs the Luhn checksum used by payment card numbers
+func luhnValid(digits string) bool {
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
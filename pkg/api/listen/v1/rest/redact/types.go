@@ -0,0 +1,64 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package redact
+
+import (
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+)
+
+// Rule finds sensitive text to be redacted
+type Rule interface {
+	// Label is the name reported for anything this rule matches (ie "pci", "ssn", etc)
+	Label() string
+
+	// Find returns the byte offsets of every match in text
+	Find(text string) []Match
+}
+
+// ReplaceFunc returns the text substituted for a redacted value
+type ReplaceFunc func(label string, original string) string
+
+// Options configures a Redactor
+type Options struct {
+	// Rules to apply. Use DefaultRules() for the built-in detectors.
+	Rules []Rule
+
+	// Replace overrides the replacement text. Defaults to "[LABEL]".
+	Replace ReplaceFunc
+
+	// KeepUnredacted keeps a copy of the original response in Result.Unredacted
+	KeepUnredacted bool
+}
+
+// Match is a single rule hit within a piece of text
+type Match struct {
+	Label string
+	Start int // byte offset, inclusive
+	End   int // byte offset, exclusive
+}
+
+// Span is a redacted region of audio derived from word timestamps
+type Span struct {
+	Label     string  `json:"label"`
+	Channel   int     `json:"channel"`
+	Start     float64 `json:"start"`
+	End       float64 `json:"end"`
+	FirstWord int     `json:"first_word"`
+	LastWord  int     `json:"last_word"`
+}
+
+// Result is the output of a redaction pass
+type Result struct {
+	Redacted   *api.PreRecordedResponse `json:"redacted"`
+	Unredacted *api.PreRecordedResponse `json:"unredacted,omitempty"`
+	Spans      []Span                   `json:"spans,omitempty"`
+}
+
+// Redactor applies a set of rules to transcripts
+type Redactor struct {
+	rules   []Rule
+	replace ReplaceFunc
+	keep    bool
+}
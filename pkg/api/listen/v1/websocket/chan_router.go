@@ -5,6 +5,7 @@
 package websocketv1
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"strings"
@@ -13,6 +14,8 @@ import (
 	klog "k8s.io/klog/v2"
 
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+	delivery "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1/delivery"
+	clientinterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
 )
 
 // NewWithDefault creates a ChanRouter with the default callback handler
@@ -31,6 +34,12 @@ func NewChanWithDefault() *ChanRouter {
 // New creates a ChanRouter with a user-defined channels
 // gocritic:ignore
 func NewChanRouter(chans interfaces.LiveMessageChan) *ChanRouter {
+	return NewChanRouterWithOptions(context.Background(), chans, nil)
+}
+
+// NewChanRouterWithOptions creates a ChanRouter which applies the delivery options to every channel.
+// Blocked sends are abandoned once ctx is done.
+func NewChanRouterWithOptions(ctx context.Context, chans interfaces.LiveMessageChan, opts *clientinterfaces.ChanDeliveryOptions) *ChanRouter {
 	var debugStr string
 	if v := os.Getenv("DEEPGRAM_DEBUG"); v != "" {
 		klog.V(4).Infof("DEEPGRAM_DEBUG found")
@@ -39,6 +48,7 @@ func NewChanRouter(chans interfaces.LiveMessageChan) *ChanRouter {
 
 	router := &ChanRouter{
 		debugWebsocket:    strings.EqualFold(strings.ToLower(debugStr), "true"),
+		dispatcher:        delivery.New(ctx, opts),
 		openChan:          make([]*chan *interfaces.OpenResponse, 0),
 		messageChan:       make([]*chan *interfaces.MessageResponse, 0),
 		metadataChan:      make([]*chan *interfaces.MetadataResponse, 0),
@@ -60,6 +70,8 @@ func NewChanRouter(chans interfaces.LiveMessageChan) *ChanRouter {
 		router.unhandledChan = append(router.unhandledChan, chans.GetUnhandled()...)
 	}
 
+	router.events = delivery.NewHub[interfaces.Event](router.dispatcher)
+
 	return router
 }
 
@@ -79,8 +91,11 @@ func (r *ChanRouter) Open(or *interfaces.OpenResponse) error {
 		}
 
 		for _, ch := range r.openChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeOpenResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeOpenResponse), Open: &msg})
 		return nil
 	}
 
@@ -103,8 +118,11 @@ func (r *ChanRouter) Close(cr *interfaces.CloseResponse) error {
 		}
 
 		for _, ch := range r.closeChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeCloseResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeCloseResponse), Close: &msg})
 		return nil
 	}
 
@@ -127,8 +145,11 @@ func (r *ChanRouter) Error(er *interfaces.ErrorResponse) error {
 		}
 
 		for _, ch := range r.errorChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeErrorResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeErrorResponse), Error: &msg})
 		return nil
 	}
 
@@ -161,8 +182,11 @@ func (r *ChanRouter) processMessage(byMsg []byte) error {
 		}
 
 		for _, ch := range r.messageChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeMessageResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeMessageResponse), Message: &msg})
 		return nil
 	}
 
@@ -178,8 +202,11 @@ func (r *ChanRouter) processMetadata(byMsg []byte) error {
 		}
 
 		for _, ch := range r.metadataChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeMetadataResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeMetadataResponse), Metadata: &msg})
 		return nil
 	}
 
@@ -195,8 +222,11 @@ func (r *ChanRouter) processSpeechStartedResponse(byMsg []byte) error {
 		}
 
 		for _, ch := range r.speechStartedChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeSpeechStartedResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeSpeechStartedResponse), SpeechStarted: &msg})
 		return nil
 	}
 
@@ -212,8 +242,11 @@ func (r *ChanRouter) processUtteranceEndResponse(byMsg []byte) error {
 		}
 
 		for _, ch := range r.utteranceEndChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeUtteranceEndResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeUtteranceEndResponse), UtteranceEnd: &msg})
 		return nil
 	}
 
@@ -229,8 +262,11 @@ func (r *ChanRouter) processErrorResponse(byMsg []byte) error {
 		}
 
 		for _, ch := range r.errorChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeErrorResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeErrorResponse), Error: &msg})
 		return nil
 	}
 
@@ -289,14 +325,28 @@ func (r *ChanRouter) UnhandledMessage(byMsg []byte) error {
 	r.printDebugMessages(3, "UnhandledMessage", byMsg)
 
 	for _, ch := range r.unhandledChan {
-		*ch <- &byMsg
+		if err := delivery.Send(r.dispatcher, delivery.MessageTypeUnhandled, ch, &byMsg); err != nil {
+			break
+		}
 	}
+	r.events.Publish(interfaces.Event{Type: delivery.MessageTypeUnhandled, Unhandled: byMsg})
 
 	klog.V(1).Infof("Unknown Event was received\n")
 	klog.V(6).Infof("router.UnhandledMessage LEAVE\n")
 	return ErrInvalidMessageType
 }
 
+// Subscribe adds a subscriber which receives the events matching filter, see interfaces.EventFilter.
+// Call cancel to unsubscribe, which closes the channel.
+func (r *ChanRouter) Subscribe(filter *interfaces.EventFilter) (<-chan interfaces.Event, func()) {
+	return r.events.Subscribe(filter.Match, defaultSubscriberBuffer)
+}
+
+// DeliveryStats returns the delivery counters for each channel, keyed by message type and index
+func (r *ChanRouter) DeliveryStats() map[string]delivery.Stats {
+	return r.dispatcher.Stats()
+}
+
 // printDebugMessages formats and logs debugging messages
 func (r *ChanRouter) printDebugMessages(level klog.Level, function string, byMsg []byte) {
 	prettyJSON, err := prettyjson.Format(byMsg)
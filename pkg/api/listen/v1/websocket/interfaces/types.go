@@ -128,3 +128,87 @@ type CloseResponse = commoninterfaces.CloseResponse
 
 // ErrorResponse is the Deepgram specific response error
 type ErrorResponse = interfaces.DeepgramError
+
+/***********************************/
+// Subscriptions
+/***********************************/
+// Event is a single message from the websocket. Type is the message type and only the matching field is set.
+type Event struct {
+	Type string
+
+	Open          *OpenResponse
+	Message       *MessageResponse
+	Metadata      *MetadataResponse
+	SpeechStarted *SpeechStartedResponse
+	UtteranceEnd  *UtteranceEndResponse
+	Close         *CloseResponse
+	Error         *ErrorResponse
+	Unhandled     []byte
+}
+
+/*
+EventFilter selects the events a subscriber receives. A nil or empty filter receives everything.
+
+The ChannelIndex, IsFinal and Speaker conditions only apply to events which carry those fields.
+Combine them with Types to receive nothing but Results.
+*/
+type EventFilter struct {
+	Types        []string // message types, e.g. string(TypeMessageResponse)
+	ChannelIndex *int     // audio channel of Results, SpeechStarted and UtteranceEnd
+	IsFinal      bool     // only final Results
+	Speaker      *int     // only Results containing a word from this speaker
+}
+
+// Match reports whether the event passes the filter
+func (f *EventFilter) Match(e Event) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if t == e.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.ChannelIndex != nil {
+		var channel []int
+		switch {
+		case e.Message != nil:
+			channel = e.Message.ChannelIndex
+		case e.SpeechStarted != nil:
+			channel = e.SpeechStarted.Channel
+		case e.UtteranceEnd != nil:
+			channel = e.UtteranceEnd.Channel
+		}
+		if len(channel) > 0 && channel[0] != *f.ChannelIndex {
+			return false
+		}
+	}
+
+	if e.Message == nil {
+		return true
+	}
+	if f.IsFinal && !e.Message.IsFinal {
+		return false
+	}
+	if f.Speaker != nil {
+		for _, alt := range e.Message.Channel.Alternatives {
+			for _, w := range alt.Words {
+				if w.Speaker != nil && *w.Speaker == *f.Speaker {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	return true
+}
@@ -31,7 +31,18 @@ func (c *Client) ListBalances(ctx context.Context, projectID string) (*api.Balan
 	}
 
 	klog.V(6).Infof("manage.ListBalances() LEAVE\n")
-	return &resp, nil
+	return &resp, err
+}
+
+// IterateBalances returns an Iterator over the balances for a project. The list is fetched on the first call to Next.
+func (c *Client) IterateBalances(ctx context.Context, projectID string) *Iterator[api.Balance] {
+	return newIterator(ctx, 0, nil, singlePage(func(ctx context.Context) ([]api.Balance, error) {
+		resp, err := c.ListBalances(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Balances, nil
+	}))
 }
 
 // GetBalance gets a balance for a project
@@ -47,5 +58,5 @@ func (c *Client) GetBalance(ctx context.Context, projectID, balanceID string) (*
 	}
 
 	klog.V(6).Infof("manage.GetBalance() LEAVE\n")
-	return &resp, nil
+	return &resp, err
 }
@@ -0,0 +1,218 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package provides a cached model catalog built on the Manage API.
+
+It answers capability queries such as "streaming STT models for es-419" or "TTS voices with an
+American accent", and validates transcription and speak options before they are sent.
+*/
+package catalog
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+)
+
+// New creates a Catalog which lazily fetches models with the manage client
+func New(c *manage.Client, opts Options) (*Catalog, error) {
+	if c == nil {
+		klog.V(1).Infof("catalog.New: client is required\n")
+		return nil, ErrInvalidInput
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaultTTL
+	}
+
+	return &Catalog{
+		client: c,
+		opts:   opts,
+	}, nil
+}
+
+// NewFromModels creates a static Catalog from a model list, ie one loaded from disk
+func NewFromModels(models *api.ModelsResult) (*Catalog, error) {
+	if models == nil {
+		return nil, ErrInvalidInput
+	}
+
+	return &Catalog{
+		models:  models,
+		fetched: time.Now(),
+	}, nil
+}
+
+// Refresh fetches the model list now, replacing the cache
+func (c *Catalog) Refresh(ctx context.Context) error {
+	klog.V(6).Infof("catalog.Refresh ENTER\n")
+	defer klog.V(6).Infof("catalog.Refresh LEAVE\n")
+
+	if c.client == nil {
+		// static catalog
+		return nil
+	}
+
+	req := &api.ModelRequest{IncludeOutdated: c.opts.IncludeOutdated}
+
+	var models *api.ModelsResult
+	var err error
+	if c.opts.ProjectID != "" {
+		models, err = c.client.GetProjectModels(ctx, c.opts.ProjectID, req)
+	} else {
+		models, err = c.client.GetModels(ctx, req)
+	}
+	if err != nil {
+		klog.V(1).Infof("GetModels failed. Err: %v\n", err)
+		return err
+	}
+
+	c.mu.Lock()
+	c.models = models
+	c.fetched = time.Now()
+	c.mu.Unlock()
+
+	klog.V(3).Infof("Catalog has %d STT and %d TTS models\n", len(models.Stt), len(models.Tts))
+	return nil
+}
+
+// STT returns the speech-to-text models matching the filter
+func (c *Catalog) STT(ctx context.Context, filter STTFilter) ([]api.Stt, error) {
+	models, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]api.Stt, 0)
+	for _, model := range models.Stt {
+		if filter.Name != "" && !sttNameMatches(&model, filter.Name) {
+			continue
+		}
+		if filter.Architecture != "" && !strings.EqualFold(model.Architecture, filter.Architecture) {
+			continue
+		}
+		if filter.Language != "" && !supportsLanguage(model.Languages, filter.Language) {
+			continue
+		}
+		if (filter.Batch && !model.Batch) || (filter.Streaming && !model.Streaming) ||
+			(filter.FormattedOutput && !model.FormattedOutput) {
+			continue
+		}
+		list = append(list, model)
+	}
+
+	return list, nil
+}
+
+// TTS returns the text-to-speech voices matching the filter
+func (c *Catalog) TTS(ctx context.Context, filter TTSFilter) ([]api.Tts, error) {
+	models, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]api.Tts, 0)
+	for _, voice := range models.Tts {
+		if filter.Name != "" && !ttsNameMatches(&voice, filter.Name) {
+			continue
+		}
+		if filter.Language != "" && !supportsLanguage(voice.Languages, filter.Language) {
+			continue
+		}
+		if filter.Accent != "" && !strings.EqualFold(voice.Metadata.Accent, filter.Accent) {
+			continue
+		}
+		if filter.Tag != "" && !containsFold(voice.Metadata.Tags, filter.Tag) {
+			continue
+		}
+		list = append(list, voice)
+	}
+
+	return list, nil
+}
+
+/*
+helpers
+*/
+func (c *Catalog) get(ctx context.Context) (*api.ModelsResult, error) {
+	c.mu.Lock()
+	models := c.models
+	stale := c.client != nil && (models == nil || time.Since(c.fetched) > c.opts.TTL)
+	c.mu.Unlock()
+
+	if !stale {
+		return models, nil
+	}
+
+	if err := c.Refresh(ctx); err != nil {
+		if models != nil {
+			// keep serving the stale list rather than failing every request
+			klog.V(1).Infof("Catalog refresh failed, using cached models. Err: %v\n", err)
+			return models, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.models, nil
+}
+
+// sttNameMatches accepts the name, canonical name, or a prefix of the canonical name (ie "nova-3" for "nova-3-general")
+func sttNameMatches(model *api.Stt, name string) bool {
+	return nameMatches(name, model.Name, model.CanonicalName, model.Architecture)
+}
+
+func ttsNameMatches(voice *api.Tts, name string) bool {
+	return nameMatches(name, voice.Name, voice.CanonicalName)
+}
+
+func nameMatches(name string, candidates ...string) bool {
+	name = strings.ToLower(name)
+	for _, candidate := range candidates {
+		candidate = strings.ToLower(candidate)
+		if candidate == "" {
+			continue
+		}
+		if candidate == name || strings.HasPrefix(candidate, name+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsLanguage matches exactly or on the base language, so "en" and "en-US" match each other
+func supportsLanguage(languages []string, language string) bool {
+	base := baseLanguage(language)
+	for _, l := range languages {
+		if strings.EqualFold(l, language) || strings.EqualFold(l, languageMulti) {
+			return true
+		}
+		if strings.EqualFold(baseLanguage(l), base) && (!strings.Contains(l, "-") || !strings.Contains(language, "-")) {
+			return true
+		}
+	}
+	return false
+}
+
+func baseLanguage(language string) string {
+	if i := strings.Index(language, "-"); i > 0 {
+		return language[:i]
+	}
+	return language
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package catalog
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// ProblemKind is the type of a validation Problem
+type ProblemKind string
+
+const (
+	ProblemUnknownModel        ProblemKind = "unknown_model"
+	ProblemUnsupportedLanguage ProblemKind = "unsupported_language"
+	ProblemBatchOnly           ProblemKind = "batch_only"
+	ProblemStreamingOnly       ProblemKind = "streaming_only"
+	ProblemUnknownVoice        ProblemKind = "unknown_voice"
+)
+
+// languageMulti is the code for multilingual transcription
+const languageMulti string = "multi"
+
+const defaultTTL = time.Hour
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+)
@@ -0,0 +1,77 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package catalog
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+)
+
+// Options configures a Catalog
+type Options struct {
+	// ProjectID restricts the catalog to the models available to a project
+	ProjectID string
+
+	// IncludeOutdated includes older model versions
+	IncludeOutdated bool
+
+	// TTL is how long the model list is cached. Defaults to 1 hour.
+	TTL time.Duration
+}
+
+// STTFilter selects speech-to-text models. Zero values match everything.
+type STTFilter struct {
+	Name            string
+	Architecture    string
+	Language        string
+	Batch           bool
+	Streaming       bool
+	FormattedOutput bool
+}
+
+// TTSFilter selects text-to-speech voices. Zero values match everything.
+type TTSFilter struct {
+	Name     string
+	Language string
+	Accent   string
+	Tag      string
+}
+
+// Problem is a single reason options are not supported by the catalog
+type Problem struct {
+	Kind    ProblemKind `json:"kind"`
+	Field   string      `json:"field"`
+	Value   string      `json:"value"`
+	Message string      `json:"message"`
+}
+
+// ValidationError lists everything wrong with a set of options
+type ValidationError struct {
+	Problems []Problem
+}
+
+// Error string representation for a given error
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		messages = append(messages, p.Message)
+	}
+	return fmt.Sprintf("options are not supported: %s", strings.Join(messages, "; "))
+}
+
+// Catalog is a cached view of the models available from the platform
+type Catalog struct {
+	client *manage.Client
+	opts   Options
+
+	mu      sync.Mutex
+	models  *api.ModelsResult
+	fetched time.Time
+}
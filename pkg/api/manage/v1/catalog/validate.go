@@ -0,0 +1,165 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	klog "k8s.io/klog/v2"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+)
+
+// ValidatePreRecorded checks the model and language of prerecorded options. Problems are returned as a *ValidationError.
+func (c *Catalog) ValidatePreRecorded(ctx context.Context, options *interfaces.PreRecordedTranscriptionOptions) error {
+	if options == nil {
+		return ErrInvalidInput
+	}
+
+	models, err := c.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	language := options.Language
+	if options.DetectLanguage {
+		language = ""
+	}
+
+	return result(checkSTT(models, options.Model, language, false))
+}
+
+// ValidateLive checks the model and language of streaming options. Problems are returned as a *ValidationError.
+func (c *Catalog) ValidateLive(ctx context.Context, options *interfaces.LiveTranscriptionOptions) error {
+	if options == nil {
+		return ErrInvalidInput
+	}
+
+	models, err := c.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	return result(checkSTT(models, options.Model, options.Language, true))
+}
+
+// ValidateSpeak checks the voice of REST speak options. Problems are returned as a *ValidationError.
+func (c *Catalog) ValidateSpeak(ctx context.Context, options *interfaces.SpeakOptions) error {
+	if options == nil {
+		return ErrInvalidInput
+	}
+	return c.validateVoice(ctx, options.Model)
+}
+
+// ValidateWSSpeak checks the voice of websocket speak options. Problems are returned as a *ValidationError.
+func (c *Catalog) ValidateWSSpeak(ctx context.Context, options *interfaces.WSSpeakOptions) error {
+	if options == nil {
+		return ErrInvalidInput
+	}
+	return c.validateVoice(ctx, options.Model)
+}
+
+/*
+helpers
+*/
+func (c *Catalog) validateVoice(ctx context.Context, voice string) error {
+	if voice == "" {
+		// the platform default
+		return nil
+	}
+
+	models, err := c.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range models.Tts {
+		if ttsNameMatches(&models.Tts[i], voice) {
+			return nil
+		}
+	}
+
+	return result([]Problem{{
+		Kind:    ProblemUnknownVoice,
+		Field:   "model",
+		Value:   voice,
+		Message: fmt.Sprintf("unknown voice %q", voice),
+	}})
+}
+
+func checkSTT(models *api.ModelsResult, model, language string, streaming bool) []Problem {
+	problems := make([]Problem, 0)
+
+	candidates := make([]*api.Stt, 0)
+	for i := range models.Stt {
+		if model == "" || sttNameMatches(&models.Stt[i], model) {
+			candidates = append(candidates, &models.Stt[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return append(problems, Problem{
+			Kind:    ProblemUnknownModel,
+			Field:   "model",
+			Value:   model,
+			Message: fmt.Sprintf("unknown model %q", model),
+		})
+	}
+
+	usable := make([]*api.Stt, 0, len(candidates))
+	for _, candidate := range candidates {
+		if (streaming && candidate.Streaming) || (!streaming && candidate.Batch) {
+			usable = append(usable, candidate)
+		}
+	}
+	if len(usable) == 0 {
+		problem := Problem{Field: "model", Value: model}
+		if streaming {
+			problem.Kind = ProblemBatchOnly
+			problem.Message = fmt.Sprintf("model %q does not support streaming", model)
+		} else {
+			problem.Kind = ProblemStreamingOnly
+			problem.Message = fmt.Sprintf("model %q does not support prerecorded audio", model)
+		}
+		problems = append(problems, problem)
+		usable = candidates
+	}
+
+	if language != "" {
+		supported := false
+		for _, candidate := range usable {
+			if supportsLanguage(candidate.Languages, language) {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			name := model
+			if name == "" {
+				name = "any model"
+			}
+			problems = append(problems, Problem{
+				Kind:    ProblemUnsupportedLanguage,
+				Field:   "language",
+				Value:   language,
+				Message: fmt.Sprintf("language %q is not supported by %s", language, name),
+			})
+		}
+	}
+
+	return problems
+}
+
+func result(problems []Problem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	for _, p := range problems {
+		klog.V(1).Infof("Catalog validation: %s\n", p.Message)
+	}
+	return &ValidationError{Problems: problems}
+}
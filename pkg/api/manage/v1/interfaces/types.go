@@ -48,11 +48,13 @@ type APIKeyPermission struct {
 
 // Key provides a key
 type APIKey struct {
-	APIKeyID string   `json:"api_key_id,omitempty"`
-	Key      string   `json:"key,omitempty"`
-	Comment  string   `json:"comment,omitempty"`
-	Scopes   []string `json:"scopes,omitempty"`
-	Created  string   `json:"created,omitempty"`
+	APIKeyID       string   `json:"api_key_id,omitempty"`
+	Key            string   `json:"key,omitempty"`
+	Comment        string   `json:"comment,omitempty"`
+	Scopes         []string `json:"scopes,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Created        string   `json:"created,omitempty"`
+	ExpirationDate string   `json:"expiration_date,omitempty"`
 }
 
 // KeyList provides a list of keys
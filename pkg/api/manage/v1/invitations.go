@@ -38,6 +38,17 @@ func (c *Client) ListInvitations(ctx context.Context, projectID string) (*api.In
 	return &resp, err
 }
 
+// IterateInvitations returns an Iterator over the invitations for a project. The list is fetched on the first call to Next.
+func (c *Client) IterateInvitations(ctx context.Context, projectID string) *Iterator[api.Invite] {
+	return newIterator(ctx, 0, nil, singlePage(func(ctx context.Context) ([]api.Invite, error) {
+		resp, err := c.ListInvitations(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Invites, nil
+	}))
+}
+
 // SendInvitation sends an invitation to a project
 func (c *Client) SendInvitation(ctx context.Context, projectID string, invite *api.InvitationRequest) (*api.MessageResult, error) {
 	klog.V(6).Infof("manage.SendInvitation() ENTER\n")
@@ -0,0 +1,216 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package manage
+
+import (
+	"context"
+	"sync"
+
+	klog "k8s.io/klog/v2"
+
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+)
+
+// IteratorOptions configures how an Iterator fetches pages
+type IteratorOptions struct {
+	// Prefetch is the number of pages fetched ahead of the caller concurrently.
+	// 0 fetches each page only when the previous one has been consumed.
+	Prefetch int
+}
+
+/*
+Iterator walks the items of a list endpoint, fetching pages lazily.
+
+	it := mgClient.IterateRequests(ctx, projectID, &api.UsageListRequest{Limit: 100}, nil)
+	defer it.Close()
+	for it.Next() {
+		req := it.Value()
+		...
+	}
+	if err := it.Err(); err != nil {
+		...
+	}
+*/
+type Iterator[T any] struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	fetch    pageFunc[T]
+	prefetch int
+
+	page  int
+	items []T
+	cur   T
+	err   error
+	done  bool
+
+	queue chan chan pageResult[T]
+	wg    sync.WaitGroup
+}
+
+// pageFunc fetches a single page and reports whether it is the last one
+type pageFunc[T any] func(ctx context.Context, page int) (items []T, last bool, err error)
+
+type pageResult[T any] struct {
+	items []T
+	last  bool
+	err   error
+}
+
+func newIterator[T any](ctx context.Context, startPage int, opts *IteratorOptions, fetch pageFunc[T]) *Iterator[T] {
+	if opts == nil {
+		opts = &IteratorOptions{}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Iterator[T]{
+		ctx:      ctx,
+		cancel:   cancel,
+		fetch:    fetch,
+		prefetch: opts.Prefetch,
+		page:     startPage,
+	}
+}
+
+// Next advances to the next item, fetching the next page if needed. It returns false at the end or on error.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	// the context is canceled internally once the last page arrives
+	if err := it.ctx.Err(); err != nil && !it.done {
+		it.err = err
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.done {
+			return false
+		}
+
+		res := it.nextPage()
+		if res.err != nil {
+			klog.V(1).Infof("Iterator page fetch failed. Err: %v\n", res.err)
+			it.err = res.err
+			it.cancel()
+			return false
+		}
+
+		it.items = res.items
+		if res.last {
+			it.done = true
+			it.cancel()
+		}
+	}
+
+	it.cur = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and waits for any outstanding prefetches to return
+func (it *Iterator[T]) Close() {
+	it.done = true
+	it.items = nil
+	it.cancel()
+	it.wg.Wait()
+}
+
+// All drains the iterator into a slice
+func (it *Iterator[T]) All() ([]T, error) {
+	defer it.Close()
+
+	items := make([]T, 0)
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+
+	return items, it.Err()
+}
+
+func (it *Iterator[T]) nextPage() pageResult[T] {
+	if it.prefetch <= 0 {
+		items, last, err := it.fetch(it.ctx, it.page)
+		it.page++
+		return pageResult[T]{items: items, last: last, err: err}
+	}
+
+	if it.queue == nil {
+		it.startPrefetch()
+	}
+
+	var ch chan pageResult[T]
+	select {
+	case ch = <-it.queue:
+	case <-it.ctx.Done():
+		return pageResult[T]{err: it.ctx.Err()}
+	}
+
+	select {
+	case res := <-ch:
+		return res
+	case <-it.ctx.Done():
+		return pageResult[T]{err: it.ctx.Err()}
+	}
+}
+
+// startPrefetch fetches pages ahead of the caller. Results are queued in page order.
+func (it *Iterator[T]) startPrefetch() {
+	it.queue = make(chan chan pageResult[T], it.prefetch)
+
+	it.wg.Add(1)
+	go func() {
+		defer it.wg.Done()
+		for page := it.page; ; page++ {
+			ch := make(chan pageResult[T], 1)
+			select {
+			case it.queue <- ch:
+			case <-it.ctx.Done():
+				return
+			}
+
+			it.wg.Add(1)
+			go func(page int) {
+				defer it.wg.Done()
+				items, last, err := it.fetch(it.ctx, page)
+				ch <- pageResult[T]{items: items, last: last, err: err}
+			}(page)
+		}
+	}()
+}
+
+// withParameters adds query parameters to any already present on the context
+func withParameters(ctx context.Context, params map[string][]string) context.Context {
+	if existing, ok := ctx.Value(interfaces.ParametersContext{}).(map[string][]string); ok {
+		merged := make(map[string][]string, len(existing)+len(params))
+		for k, v := range existing {
+			merged[k] = v
+		}
+		for k, v := range params {
+			merged[k] = v
+		}
+		params = merged
+	}
+
+	return interfaces.WithCustomParameters(ctx, params)
+}
+
+// singlePage adapts an endpoint which returns everything in one response
+func singlePage[T any](fetch func(ctx context.Context) ([]T, error)) pageFunc[T] {
+	return func(ctx context.Context, _ int) ([]T, bool, error) {
+		items, err := fetch(ctx)
+		return items, true, err
+	}
+}
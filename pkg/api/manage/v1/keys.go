@@ -36,7 +36,18 @@ func (c *Client) ListKeys(ctx context.Context, projectID string) (*api.KeysResul
 	}
 
 	klog.V(6).Infof("manage.ListKeys() LEAVE\n")
-	return &resp, nil
+	return &resp, err
+}
+
+// IterateKeys returns an Iterator over the keys for a project. The list is fetched on the first call to Next.
+func (c *Client) IterateKeys(ctx context.Context, projectID string) *Iterator[api.APIKeyPermission] {
+	return newIterator(ctx, 0, nil, singlePage(func(ctx context.Context) ([]api.APIKeyPermission, error) {
+		resp, err := c.ListKeys(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.APIKeys, nil
+	}))
 }
 
 // GetKey gets a key for a project
@@ -52,7 +63,7 @@ func (c *Client) GetKey(ctx context.Context, projectID, keyID string) (*api.KeyR
 	}
 
 	klog.V(6).Infof("manage.GetKey() LEAVE\n")
-	return &resp, nil
+	return &resp, err
 }
 
 // CreateKey creates a key for a project
@@ -69,14 +80,14 @@ func (c *Client) CreateKey(ctx context.Context, projectID string, key *api.KeyCr
 		Scopes         []string `json:"scopes"`
 		ExpirationDate string   `json:"expiration_date,omitempty"`
 		TimeToLive     int      `json:"time_to_live_in_seconds,omitempty"`
-		// Tags           []string `json:"tags"`
+		Tags           []string `json:"tags,omitempty"`
 	}
 	internalKey := InternalKeyCreateRequest{
 		Comment:        key.Comment,
 		Scopes:         key.Scopes,
 		ExpirationDate: expirationStr,
 		TimeToLive:     key.TimeToLive,
-		// Tags:           key.Tags,
+		Tags:           key.Tags,
 	}
 
 	jsonStr, err := json.Marshal(internalKey)
@@ -95,7 +106,7 @@ func (c *Client) CreateKey(ctx context.Context, projectID string, key *api.KeyCr
 	}
 
 	klog.V(6).Infof("manage.CreateKey() LEAVE\n")
-	return &resp, nil
+	return &resp, err
 }
 
 // DeleteKey deletes a key for a project
@@ -111,5 +122,5 @@ func (c *Client) DeleteKey(ctx context.Context, projectID, keyID string) (*api.M
 	}
 
 	klog.V(6).Infof("manage.DeleteKey() LEAVE\n")
-	return &resp, nil
+	return &resp, err
 }
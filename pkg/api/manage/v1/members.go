@@ -31,7 +31,18 @@ func (c *Client) ListMembers(ctx context.Context, projectID string) (*api.Member
 	}
 
 	klog.V(6).Infof("manage.ListMembers() LEAVE\n")
-	return &resp, nil
+	return &resp, err
+}
+
+// IterateMembers returns an Iterator over the members for a project. The list is fetched on the first call to Next.
+func (c *Client) IterateMembers(ctx context.Context, projectID string) *Iterator[api.Member] {
+	return newIterator(ctx, 0, nil, singlePage(func(ctx context.Context) ([]api.Member, error) {
+		resp, err := c.ListMembers(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Members, nil
+	}))
 }
 
 // RemoveMember removes a member from a project
@@ -47,5 +58,5 @@ func (c *Client) RemoveMember(ctx context.Context, projectID, memberID string) (
 	}
 
 	klog.V(6).Infof("manage.RemoveMember() LEAVE\n")
-	return &resp, nil
+	return &resp, err
 }
@@ -0,0 +1,67 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"context"
+
+	klog "k8s.io/klog/v2"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+)
+
+/*
+Apply runs the actions of a plan in order and returns the ones which succeeded.
+It stops at the first failure, so re-planning afterwards picks up where it left off.
+*/
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) ([]Action, error) {
+	klog.V(6).Infof("reconcile.Apply ENTER\n")
+	defer klog.V(6).Infof("reconcile.Apply LEAVE\n")
+
+	if plan == nil {
+		return nil, ErrInvalidInput
+	}
+
+	applied := make([]Action, 0, len(plan.Actions))
+	for _, action := range plan.Actions {
+		if err := ctx.Err(); err != nil {
+			return applied, err
+		}
+
+		err := r.apply(ctx, &action)
+		if r.opts.OnAction != nil {
+			r.opts.OnAction(action, err)
+		}
+		if err != nil {
+			klog.V(1).Infof("%s %s in project %s failed. Err: %v\n", action.Kind, action.Email, action.ProjectID, err)
+			return applied, err
+		}
+
+		klog.V(4).Infof("%s %s in project %s succeeded\n", action.Kind, action.Email, action.ProjectID)
+		applied = append(applied, action)
+	}
+
+	klog.V(3).Infof("Applied %d actions\n", len(applied))
+	return applied, nil
+}
+
+func (r *Reconciler) apply(ctx context.Context, action *Action) error {
+	var err error
+
+	switch action.Kind {
+	case ActionUpdateScope:
+		_, err = r.client.UpdateMemberScopes(ctx, action.ProjectID, action.MemberID, &api.ScopeUpdateRequest{Scope: action.To})
+	case ActionSendInvite:
+		_, err = r.client.SendInvitation(ctx, action.ProjectID, &api.InvitationRequest{Email: action.Email, Scope: action.To})
+	case ActionDeleteInvite:
+		_, err = r.client.DeleteInvitation(ctx, action.ProjectID, action.Email)
+	case ActionRemoveMember:
+		_, err = r.client.RemoveMember(ctx, action.ProjectID, action.MemberID)
+	default:
+		err = ErrInvalidInput
+	}
+
+	return err
+}
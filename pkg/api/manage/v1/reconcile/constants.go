@@ -0,0 +1,60 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"errors"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// ActionKind is the type of change an Action makes
+type ActionKind string
+
+const (
+	ActionUpdateScope  ActionKind = "update_scope"
+	ActionSendInvite   ActionKind = "send_invite"
+	ActionDeleteInvite ActionKind = "delete_invite"
+	ActionRemoveMember ActionKind = "remove_member"
+)
+
+// ScopeOwner members are never removed or demoted by a plan
+const ScopeOwner string = "owner"
+
+// project roles
+const (
+	ScopeAdmin  string = "admin"
+	ScopeMember string = "member"
+)
+
+// roleRank orders the project roles. A member's effective role is the highest one they hold.
+var roleRank = map[string]int{
+	ScopeMember: 1,
+	ScopeAdmin:  2,
+	ScopeOwner:  3,
+}
+
+// actionOrder is the order actions are applied in within a project. Stale invitations are
+// deleted before they are re-sent, and members are removed last.
+var actionOrder = map[ActionKind]int{
+	ActionUpdateScope:  0,
+	ActionDeleteInvite: 1,
+	ActionSendInvite:   2,
+	ActionRemoveMember: 3,
+}
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrInvalidState the desired state is not valid
+	ErrInvalidState = errors.New("invalid desired state")
+
+	// ErrUnknownFormat the desired state file is not YAML or JSON
+	ErrUnknownFormat = errors.New("desired state must be a .yaml, .yml or .json file")
+)
@@ -0,0 +1,253 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package reconciles project membership against a declarative desired state.
+
+Plan reads the live members and invitations of each project with the manage client and
+computes the changes needed to match the desired state. Apply runs those changes in order.
+
+	desired, err := reconcile.LoadFile("access.yaml")
+	r, err := reconcile.New(mgClient, reconcile.Options{})
+	plan, err := r.Plan(ctx, desired)
+	fmt.Print(plan)
+	applied, err := r.Apply(ctx, plan)
+*/
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	klog "k8s.io/klog/v2"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+)
+
+// New creates a Reconciler
+func New(c *manage.Client, opts Options) (*Reconciler, error) {
+	if c == nil {
+		klog.V(1).Infof("reconcile.New: client is required\n")
+		return nil, ErrInvalidInput
+	}
+
+	return &Reconciler{
+		client: c,
+		opts:   opts,
+	}, nil
+}
+
+// Plan compares the desired state with the live state of each project
+func (r *Reconciler) Plan(ctx context.Context, desired *State) (*Plan, error) {
+	klog.V(6).Infof("reconcile.Plan ENTER\n")
+	defer klog.V(6).Infof("reconcile.Plan LEAVE\n")
+
+	if desired == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := desired.Validate(); err != nil {
+		klog.V(1).Infof("State.Validate failed. Err: %v\n", err)
+		return nil, err
+	}
+
+	plan := &Plan{
+		Actions: make([]Action, 0),
+	}
+	for i := range desired.Projects {
+		project := &desired.Projects[i]
+
+		members, err := r.client.IterateMembers(ctx, project.ID).All()
+		if err != nil {
+			klog.V(1).Infof("IterateMembers(%s) failed. Err: %v\n", project.ID, err)
+			return nil, err
+		}
+		for j := range members {
+			if len(members[j].Scopes) > 0 {
+				continue
+			}
+			scopes, err := r.client.GetMemberScopes(ctx, project.ID, members[j].MemberID)
+			if err != nil {
+				klog.V(1).Infof("GetMemberScopes(%s) failed. Err: %v\n", members[j].MemberID, err)
+				return nil, err
+			}
+			members[j].Scopes = scopes.Scopes
+		}
+
+		invites, err := r.client.IterateInvitations(ctx, project.ID).All()
+		if err != nil {
+			klog.V(1).Infof("IterateInvitations(%s) failed. Err: %v\n", project.ID, err)
+			return nil, err
+		}
+
+		diff(plan, project, members, invites)
+	}
+
+	klog.V(3).Infof("Plan has %d actions\n", len(plan.Actions))
+	return plan, nil
+}
+
+// Empty returns true if there is nothing to change
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// String renders the plan for review, in the spirit of terraform plan
+func (p *Plan) String() string {
+	var sb strings.Builder
+
+	adds, changes, removes := 0, 0, 0
+	project := ""
+	for _, action := range p.Actions {
+		if action.ProjectID != project {
+			project = action.ProjectID
+			fmt.Fprintf(&sb, "project %s:\n", project)
+		}
+
+		switch action.Kind {
+		case ActionUpdateScope:
+			changes++
+			fmt.Fprintf(&sb, "  ~ member %s: %s -> %s\n", action.Email, action.From, action.To)
+		case ActionSendInvite:
+			adds++
+			fmt.Fprintf(&sb, "  + invite %s (%s)\n", action.Email, action.To)
+		case ActionDeleteInvite:
+			removes++
+			fmt.Fprintf(&sb, "  - invite %s (%s)\n", action.Email, action.From)
+		case ActionRemoveMember:
+			removes++
+			fmt.Fprintf(&sb, "  - member %s (%s)\n", action.Email, action.From)
+		}
+	}
+
+	for _, warning := range p.Warnings {
+		fmt.Fprintf(&sb, "  ! %s\n", warning)
+	}
+
+	if p.Empty() {
+		sb.WriteString("No changes. Projects match the desired state.\n")
+	} else {
+		fmt.Fprintf(&sb, "Plan: %d to add, %d to change, %d to remove.\n", adds, changes, removes)
+	}
+
+	return sb.String()
+}
+
+/*
+helpers
+*/
+func diff(plan *Plan, project *ProjectState, members []api.Member, invites []api.Invite) {
+	liveMembers := make(map[string]*api.Member, len(members))
+	for i := range members {
+		liveMembers[normalizeEmail(members[i].Email)] = &members[i]
+	}
+	liveInvites := make(map[string]*api.Invite, len(invites))
+	for i := range invites {
+		liveInvites[normalizeEmail(invites[i].Email)] = &invites[i]
+	}
+
+	actions := make([]Action, 0)
+	wanted := make(map[string]bool)
+
+	invite := func(email, scope string) {
+		if current, ok := liveInvites[normalizeEmail(email)]; ok {
+			if current.Scope == scope {
+				return
+			}
+			actions = append(actions, Action{Kind: ActionDeleteInvite, ProjectID: project.ID, Email: current.Email, From: current.Scope})
+		}
+		actions = append(actions, Action{Kind: ActionSendInvite, ProjectID: project.ID, Email: email, To: scope})
+	}
+
+	for _, desired := range project.Members {
+		key := normalizeEmail(desired.Email)
+		wanted[key] = true
+
+		member, ok := liveMembers[key]
+		if !ok {
+			invite(desired.Email, desired.Scope)
+			continue
+		}
+		// compare the effective role, so a member who also holds a higher scope is demoted
+		role := effectiveRole(member.Scopes)
+		if role == desired.Scope || (role == "" && hasScope(member.Scopes, desired.Scope)) {
+			continue
+		}
+		if role == ScopeOwner {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s is an owner of project %s and will not be changed to %s", member.Email, project.ID, desired.Scope))
+			continue
+		}
+		actions = append(actions, Action{
+			Kind:      ActionUpdateScope,
+			ProjectID: project.ID,
+			MemberID:  member.MemberID,
+			Email:     member.Email,
+			From:      strings.Join(member.Scopes, ","),
+			To:        desired.Scope,
+		})
+	}
+
+	for _, desired := range project.Invites {
+		key := normalizeEmail(desired.Email)
+		wanted[key] = true
+
+		if _, ok := liveMembers[key]; ok {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s is already a member of project %s, skipping invite", desired.Email, project.ID))
+			continue
+		}
+		invite(desired.Email, desired.Scope)
+	}
+
+	if project.Prune {
+		for _, member := range members {
+			if wanted[normalizeEmail(member.Email)] {
+				continue
+			}
+			if hasScope(member.Scopes, ScopeOwner) {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("%s is an owner of project %s and will not be removed", member.Email, project.ID))
+				continue
+			}
+			actions = append(actions, Action{
+				Kind:      ActionRemoveMember,
+				ProjectID: project.ID,
+				MemberID:  member.MemberID,
+				Email:     member.Email,
+				From:      strings.Join(member.Scopes, ","),
+			})
+		}
+		for _, current := range invites {
+			if wanted[normalizeEmail(current.Email)] {
+				continue
+			}
+			actions = append(actions, Action{Kind: ActionDeleteInvite, ProjectID: project.ID, Email: current.Email, From: current.Scope})
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actionOrder[actions[i].Kind] < actionOrder[actions[j].Kind]
+	})
+	plan.Actions = append(plan.Actions, actions...)
+}
+
+// effectiveRole returns the highest project role in scopes, or "" if there is none
+func effectiveRole(scopes []string) string {
+	role, rank := "", 0
+	for _, s := range scopes {
+		if r := roleRank[s]; r > rank {
+			role, rank = s, r
+		}
+	}
+	return role
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+	klog "k8s.io/klog/v2"
+)
+
+// LoadFile reads the desired state from a .yaml, .yml or .json file
+func LoadFile(path string) (*State, error) {
+	byData, err := os.ReadFile(path)
+	if err != nil {
+		klog.V(1).Infof("os.ReadFile(%s) failed. Err: %v\n", path, err)
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ParseYAML(byData)
+	case ".json":
+		return ParseJSON(byData)
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// ParseYAML parses and validates a YAML desired state
+func ParseYAML(byData []byte) (*State, error) {
+	var state State
+	if err := yaml.Unmarshal(byData, &state); err != nil {
+		klog.V(1).Infof("yaml.Unmarshal failed. Err: %v\n", err)
+		return nil, err
+	}
+	return &state, state.Validate()
+}
+
+// ParseJSON parses and validates a JSON desired state
+func ParseJSON(byData []byte) (*State, error) {
+	var state State
+	if err := json.Unmarshal(byData, &state); err != nil {
+		klog.V(1).Infof("json.Unmarshal failed. Err: %v\n", err)
+		return nil, err
+	}
+	return &state, state.Validate()
+}
+
+// Validate checks for missing fields and duplicate projects or emails
+func (s *State) Validate() error {
+	projects := make(map[string]bool, len(s.Projects))
+	for _, project := range s.Projects {
+		if project.ID == "" {
+			return fmt.Errorf("%w: project without an id", ErrInvalidState)
+		}
+		if projects[project.ID] {
+			return fmt.Errorf("%w: project %s is listed twice", ErrInvalidState, project.ID)
+		}
+		projects[project.ID] = true
+
+		emails := make(map[string]bool)
+		for _, member := range project.Members {
+			if err := checkEntry(project.ID, member.Email, member.Scope, emails); err != nil {
+				return err
+			}
+		}
+		for _, invite := range project.Invites {
+			if err := checkEntry(project.ID, invite.Email, invite.Scope, emails); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkEntry(projectID, email, scope string, emails map[string]bool) error {
+	if email == "" || scope == "" {
+		return fmt.Errorf("%w: project %s has an entry without an email or scope", ErrInvalidState, projectID)
+	}
+
+	key := normalizeEmail(email)
+	if emails[key] {
+		return fmt.Errorf("%w: %s is listed twice in project %s", ErrInvalidState, email, projectID)
+	}
+	emails[key] = true
+
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
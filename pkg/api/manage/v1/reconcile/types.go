@@ -0,0 +1,78 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package reconcile
+
+import (
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+)
+
+/*
+State is the desired membership of one or more projects:
+
+	projects:
+	  - id: 3c8d1a9e-...
+	    prune: true
+	    members:
+	      - email: alice@example.com
+	        scope: admin
+	      - email: bob@example.com
+	        scope: member
+	    invites:
+	      - email: carol@example.com
+	        scope: member
+*/
+type State struct {
+	Projects []ProjectState `json:"projects" yaml:"projects"`
+}
+
+// ProjectState is the desired membership of a single project
+type ProjectState struct {
+	ID      string        `json:"id" yaml:"id"`
+	Members []MemberState `json:"members,omitempty" yaml:"members,omitempty"`
+	Invites []InviteState `json:"invites,omitempty" yaml:"invites,omitempty"`
+
+	// Prune removes members and invitations which are not listed
+	Prune bool `json:"prune,omitempty" yaml:"prune,omitempty"`
+}
+
+// MemberState is a desired member. Members who have not joined yet are invited.
+type MemberState struct {
+	Email string `json:"email" yaml:"email"`
+	Scope string `json:"scope" yaml:"scope"`
+}
+
+// InviteState is a desired pending invitation
+type InviteState struct {
+	Email string `json:"email" yaml:"email"`
+	Scope string `json:"scope" yaml:"scope"`
+}
+
+// Action is a single change to a project
+type Action struct {
+	Kind      ActionKind `json:"kind"`
+	ProjectID string     `json:"project_id"`
+	MemberID  string     `json:"member_id,omitempty"`
+	Email     string     `json:"email"`
+	From      string     `json:"from,omitempty"`
+	To        string     `json:"to,omitempty"`
+}
+
+// Plan is the ordered list of actions which bring the live state to the desired state
+type Plan struct {
+	Actions  []Action `json:"actions"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Options configures a Reconciler
+type Options struct {
+	// OnAction is called after each action is applied, with the error if it failed
+	OnAction func(action Action, err error)
+}
+
+// Reconciler plans and applies membership changes
+type Reconciler struct {
+	client *manage.Client
+	opts   Options
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package report
+
+import (
+	"errors"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// Dimension is a field usage can be grouped by
+type Dimension string
+
+const (
+	DimensionTag    Dimension = "tag"
+	DimensionModel  Dimension = "model"
+	DimensionAPIKey Dimension = "api_key"
+	DimensionMethod Dimension = "method"
+	DimensionDay    Dimension = "day"
+)
+
+// AlertKind is the reason an Alert was raised
+type AlertKind string
+
+const (
+	AlertBudget     AlertKind = "budget"
+	AlertLowBalance AlertKind = "low_balance"
+)
+
+const (
+	// KeyNone groups requests which have no value for a dimension (ie no tags)
+	KeyNone string = "(none)"
+
+	// balanceUnitsUSD is the unit of balances which can be compared with spend
+	balanceUnitsUSD string = "usd"
+
+	dayFormat string = "2006-01-02"
+
+	defaultPageSize int = 100
+)
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrInvalidDimension the dimension is not supported
+	ErrInvalidDimension = errors.New("invalid report dimension")
+)
+
+// AllDimensions are the default dimensions of a report
+var AllDimensions = []Dimension{DimensionTag, DimensionModel, DimensionAPIKey, DimensionMethod, DimensionDay}
+
+// DefaultThresholds are the fractions of the budget which raise an alert
+var DefaultThresholds = []float64{0.5, 0.8, 1.0}
@@ -0,0 +1,63 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes one row per dimension and key followed by a TOTAL row
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"dimension", "key", "requests", "hours", "usd", "input_tokens", "output_tokens", "tts_characters"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, d := range AllDimensions {
+		rows, ok := r.Breakdown[d]
+		if !ok {
+			continue
+		}
+		for _, row := range rows {
+			if err := writer.Write(csvRow(string(d), row)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := writer.Write(csvRow("TOTAL", r.Total)); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+/*
+helpers
+*/
+func csvRow(dimension string, row Row) []string {
+	return []string{
+		dimension,
+		row.Key,
+		strconv.Itoa(row.Requests),
+		strconv.FormatFloat(row.Hours, 'f', 4, 64),
+		strconv.FormatFloat(row.USD, 'f', 4, 64),
+		strconv.Itoa(row.InputTokens),
+		strconv.Itoa(row.OutputTokens),
+		strconv.Itoa(row.TTSCharacters),
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package aggregates Manage API request logs into usage and cost reports.
+
+Spend and hours are grouped by tag, model, API key, method and day, compared against the
+project balances, and checked against a budget. Reports can be exported as CSV or JSON.
+*/
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+)
+
+// New creates a Reporter for a project
+func New(c *manage.Client, projectID string, opts Options) (*Reporter, error) {
+	if c == nil || projectID == "" {
+		klog.V(1).Infof("report.New: client and project ID are required\n")
+		return nil, ErrInvalidInput
+	}
+	if err := checkDimensions(opts.Dimensions); err != nil {
+		return nil, err
+	}
+
+	return &Reporter{
+		client:    c,
+		projectID: projectID,
+		opts:      opts,
+	}, nil
+}
+
+// Generate fetches every request in the period and the project balances, then builds the report
+func (r *Reporter) Generate(ctx context.Context) (*Report, error) {
+	klog.V(6).Infof("report.Generate ENTER\n")
+
+	pageSize := r.opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	use := &api.UsageListRequest{Limit: pageSize}
+	if !r.opts.Start.IsZero() {
+		use.Start = r.opts.Start.UTC().Format(time.RFC3339)
+	}
+	if !r.opts.End.IsZero() {
+		use.End = r.opts.End.UTC().Format(time.RFC3339)
+	}
+
+	requests, err := r.client.IterateRequests(ctx, r.projectID, use, &manage.IteratorOptions{Prefetch: r.opts.Prefetch}).All()
+	if err != nil {
+		klog.V(1).Infof("IterateRequests failed. Err: %v\n", err)
+		klog.V(6).Infof("report.Generate LEAVE\n")
+		return nil, err
+	}
+	klog.V(4).Infof("Fetched %d requests\n", len(requests))
+
+	balances, err := r.client.ListBalances(ctx, r.projectID)
+	if err != nil {
+		klog.V(1).Infof("ListBalances failed. Err: %v\n", err)
+		klog.V(6).Infof("report.Generate LEAVE\n")
+		return nil, err
+	}
+
+	report, err := Build(requests, balances.Balances, r.opts)
+	if err != nil {
+		klog.V(1).Infof("Build failed. Err: %v\n", err)
+		klog.V(6).Infof("report.Generate LEAVE\n")
+		return nil, err
+	}
+	report.ProjectID = r.projectID
+
+	if r.opts.OnAlert != nil {
+		for _, alert := range report.Alerts {
+			r.opts.OnAlert(alert)
+		}
+	}
+
+	klog.V(3).Infof("report.Generate Succeeded\n")
+	klog.V(6).Infof("report.Generate LEAVE\n")
+
+	return report, nil
+}
+
+// Build aggregates requests and balances which have already been fetched
+func Build(requests []api.Request, balances []api.Balance, opts Options) (*Report, error) {
+	if err := checkDimensions(opts.Dimensions); err != nil {
+		return nil, err
+	}
+	dimensions := opts.Dimensions
+	if len(dimensions) == 0 {
+		dimensions = AllDimensions
+	}
+
+	report := &Report{
+		Start:     opts.Start,
+		End:       opts.End,
+		Breakdown: make(map[Dimension][]Row),
+		Balances:  balances,
+	}
+
+	groups := make(map[Dimension]map[string]*Row, len(dimensions))
+	for _, d := range dimensions {
+		groups[d] = make(map[string]*Row)
+	}
+
+	for i := range requests {
+		req := &requests[i]
+
+		created, _ := time.Parse(time.RFC3339, req.Created)
+		if !inRange(created, opts.Start, opts.End) {
+			continue
+		}
+
+		report.Total.add(req)
+		for _, d := range dimensions {
+			for _, key := range keysOf(req, d, created) {
+				row, ok := groups[d][key]
+				if !ok {
+					row = &Row{Key: key}
+					groups[d][key] = row
+				}
+				row.add(req)
+			}
+		}
+	}
+
+	for d, rows := range groups {
+		list := make([]Row, 0, len(rows))
+		for _, row := range rows {
+			list = append(list, *row)
+		}
+		sortRows(d, list)
+		report.Breakdown[d] = list
+	}
+
+	report.Remaining = remainingUSD(balances)
+	report.Alerts = alerts(report, opts)
+
+	return report, nil
+}
+
+// Rows returns the breakdown for a dimension
+func (r *Report) Rows(d Dimension) []Row {
+	return r.Breakdown[d]
+}
+
+/*
+helpers
+*/
+func (row *Row) add(req *api.Request) {
+	details := &req.Response.Details
+
+	row.Requests++
+	row.Hours += details.Duration / 3600
+	row.USD += details.Usd
+	for _, token := range req.Response.TokenDetails {
+		row.InputTokens += token.Input
+		row.OutputTokens += token.Output
+	}
+	if req.Response.TTSDetails != nil {
+		for _, segment := range req.Response.TTSDetails.SpeechSegments {
+			row.TTSCharacters += segment.Characters
+		}
+	}
+}
+
+func keysOf(req *api.Request, d Dimension, created time.Time) []string {
+	var keys []string
+
+	switch d {
+	case DimensionTag:
+		keys = req.Response.Details.Tags
+	case DimensionModel:
+		keys = req.Response.Details.Models
+		if len(keys) == 0 && req.Response.TTSDetails != nil {
+			for _, segment := range req.Response.TTSDetails.SpeechSegments {
+				keys = append(keys, segment.Model)
+			}
+		}
+	case DimensionAPIKey:
+		keys = []string{req.APIKeyID}
+	case DimensionMethod:
+		keys = []string{req.Response.Details.Method}
+	case DimensionDay:
+		if !created.IsZero() {
+			keys = []string{created.UTC().Format(dayFormat)}
+		}
+	}
+
+	// count a request once per distinct key
+	seen := make(map[string]bool, len(keys))
+	unique := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, key)
+	}
+	if len(unique) == 0 {
+		unique = append(unique, KeyNone)
+	}
+
+	return unique
+}
+
+// sortRows orders days chronologically and everything else by spend
+func sortRows(d Dimension, rows []Row) {
+	sort.Slice(rows, func(i, j int) bool {
+		if d == DimensionDay || rows[i].USD == rows[j].USD {
+			return rows[i].Key < rows[j].Key
+		}
+		return rows[i].USD > rows[j].USD
+	})
+}
+
+func inRange(t, start, end time.Time) bool {
+	if t.IsZero() {
+		return true
+	}
+	if !start.IsZero() && t.Before(start) {
+		return false
+	}
+	if !end.IsZero() && !t.Before(end) {
+		return false
+	}
+	return true
+}
+
+func remainingUSD(balances []api.Balance) float64 {
+	total := 0.0
+	for _, balance := range balances {
+		if strings.EqualFold(balance.Units, balanceUnitsUSD) {
+			total += balance.Amount
+		}
+	}
+	return total
+}
+
+func alerts(report *Report, opts Options) []Alert {
+	var list []Alert
+
+	if opts.Budget > 0 {
+		thresholds := opts.Thresholds
+		if len(thresholds) == 0 {
+			thresholds = DefaultThresholds
+		}
+
+		// only the highest threshold crossed is reported
+		crossed := -1.0
+		for _, threshold := range thresholds {
+			if report.Total.USD >= opts.Budget*threshold && threshold > crossed {
+				crossed = threshold
+			}
+		}
+		if crossed >= 0 {
+			list = append(list, Alert{
+				Kind:      AlertBudget,
+				Threshold: crossed,
+				Budget:    opts.Budget,
+				Spent:     report.Total.USD,
+				Message:   fmt.Sprintf("spent $%.2f, %.0f%% of the $%.2f budget", report.Total.USD, report.Total.USD/opts.Budget*100, opts.Budget),
+			})
+		}
+	}
+
+	if opts.LowBalance > 0 && len(report.Balances) > 0 && report.Remaining < opts.LowBalance {
+		list = append(list, Alert{
+			Kind:    AlertLowBalance,
+			Spent:   report.Total.USD,
+			Balance: report.Remaining,
+			Message: fmt.Sprintf("remaining balance $%.2f is below $%.2f", report.Remaining, opts.LowBalance),
+		})
+	}
+
+	for _, alert := range list {
+		klog.V(3).Infof("Usage alert: %s\n", alert.Message)
+	}
+
+	return list
+}
+
+func checkDimensions(dimensions []Dimension) error {
+	for _, d := range dimensions {
+		switch d {
+		case DimensionTag, DimensionModel, DimensionAPIKey, DimensionMethod, DimensionDay:
+		default:
+			klog.V(1).Infof("invalid dimension: %s\n", d)
+			return ErrInvalidDimension
+		}
+	}
+	return nil
+}
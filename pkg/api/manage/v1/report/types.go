@@ -0,0 +1,83 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package report
+
+import (
+	"time"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+)
+
+// Options configures a report
+type Options struct {
+	// Start and End bound the report. End is exclusive. Zero values are unbounded.
+	Start time.Time
+	End   time.Time
+
+	// Dimensions to group by. Defaults to AllDimensions.
+	Dimensions []Dimension
+
+	// Budget in USD for the period. 0 disables budget alerts.
+	Budget float64
+	// Thresholds are the fractions of Budget which raise an alert. Defaults to DefaultThresholds.
+	Thresholds []float64
+
+	// LowBalance raises an alert when the remaining USD balance falls below it. 0 disables it.
+	LowBalance float64
+
+	// OnAlert is called for every alert raised by Generate
+	OnAlert func(alert Alert)
+
+	// PageSize and Prefetch control how request logs are fetched
+	PageSize int
+	Prefetch int
+}
+
+// Row is the usage for a single key of a dimension
+type Row struct {
+	Key           string  `json:"key"`
+	Requests      int     `json:"requests"`
+	Hours         float64 `json:"hours"`
+	USD           float64 `json:"usd"`
+	InputTokens   int     `json:"input_tokens,omitempty"`
+	OutputTokens  int     `json:"output_tokens,omitempty"`
+	TTSCharacters int     `json:"tts_characters,omitempty"`
+}
+
+// Alert is raised when spend crosses a budget threshold or the balance runs low
+type Alert struct {
+	Kind      AlertKind `json:"kind"`
+	Threshold float64   `json:"threshold,omitempty"`
+	Budget    float64   `json:"budget,omitempty"`
+	Spent     float64   `json:"spent"`
+	Balance   float64   `json:"balance,omitempty"`
+	Message   string    `json:"message"`
+}
+
+/*
+Report is the aggregated usage of a project.
+
+A request with several tags or models is counted once for each of them, so the rows of those
+dimensions may add up to more than the Total.
+*/
+type Report struct {
+	ProjectID string    `json:"project_id,omitempty"`
+	Start     time.Time `json:"start,omitempty"`
+	End       time.Time `json:"end,omitempty"`
+
+	Total     Row                 `json:"total"`
+	Breakdown map[Dimension][]Row `json:"breakdown"`
+	Balances  []api.Balance       `json:"balances,omitempty"`
+	Remaining float64             `json:"remaining_usd,omitempty"`
+	Alerts    []Alert             `json:"alerts,omitempty"`
+}
+
+// Reporter builds reports for a project from the Manage API
+type Reporter struct {
+	client    *manage.Client
+	projectID string
+	opts      Options
+}
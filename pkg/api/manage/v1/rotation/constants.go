@@ -0,0 +1,28 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package rotation
+
+import (
+	"errors"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrNoSink a secret sink is required unless running in dry-run mode
+	ErrNoSink = errors.New("a secret sink is required to rotate keys")
+
+	// ErrKeyNotFound the key does not exist in the project
+	ErrKeyNotFound = errors.New("api key not found")
+
+	// ErrNoSecret the platform did not return the new key
+	ErrNoSecret = errors.New("the new api key was not returned")
+)
@@ -0,0 +1,231 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package manages the lifecycle of API keys.
+
+A rotation creates a new key with the same comment, scopes and tags as the old one, publishes it to
+a SecretSink, waits a grace period so consumers pick it up, and then deletes the old key. Keys which
+expire within a window can be listed and rotated across projects, optionally as a dry run.
+*/
+package rotation
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+)
+
+// New creates a key Manager
+func New(c *manage.Client, opts Options) (*Manager, error) {
+	if c == nil {
+		klog.V(1).Infof("rotation.New: client is required\n")
+		return nil, ErrInvalidInput
+	}
+	if opts.Sink == nil && !opts.DryRun {
+		klog.V(1).Infof("rotation.New: sink is required\n")
+		return nil, ErrNoSink
+	}
+
+	return &Manager{
+		client: c,
+		opts:   opts,
+	}, nil
+}
+
+// Rotate replaces keyID in the project with a new key
+func (m *Manager) Rotate(ctx context.Context, projectID, keyID string) (*Rotation, error) {
+	klog.V(6).Infof("rotation.Rotate ENTER\n")
+	defer klog.V(6).Infof("rotation.Rotate LEAVE\n")
+
+	if projectID == "" || keyID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	resp, err := m.client.GetKey(ctx, projectID, keyID)
+	if err != nil {
+		klog.V(1).Infof("GetKey failed. Err: %v\n", err)
+		return nil, err
+	}
+	if resp.APIKey.APIKeyID == "" {
+		return nil, ErrKeyNotFound
+	}
+
+	return m.rotate(ctx, projectID, &resp.APIKey)
+}
+
+// ListExpiring returns the keys in the project which expire within the window, soonest first
+func (m *Manager) ListExpiring(ctx context.Context, projectID string, within time.Duration) ([]ExpiringKey, error) {
+	klog.V(6).Infof("rotation.ListExpiring ENTER\n")
+	defer klog.V(6).Infof("rotation.ListExpiring LEAVE\n")
+
+	keys, err := m.client.IterateKeys(ctx, projectID).All()
+	if err != nil {
+		klog.V(1).Infof("IterateKeys failed. Err: %v\n", err)
+		return nil, err
+	}
+
+	return expiring(projectID, keys, time.Now(), within), nil
+}
+
+// ListExpiringDays is ListExpiring with the window given in days
+func (m *Manager) ListExpiringDays(ctx context.Context, projectID string, days int) ([]ExpiringKey, error) {
+	return m.ListExpiring(ctx, projectID, time.Duration(days)*24*time.Hour)
+}
+
+/*
+RotateExpiring rotates every key which expires within the window across the projects.
+In dry-run mode it returns the plan without changing anything.
+
+Rotation stops at the first failure and returns the rotations completed so far.
+*/
+func (m *Manager) RotateExpiring(ctx context.Context, projectIDs []string, within time.Duration) ([]Rotation, error) {
+	klog.V(6).Infof("rotation.RotateExpiring ENTER\n")
+	defer klog.V(6).Infof("rotation.RotateExpiring LEAVE\n")
+
+	rotations := make([]Rotation, 0)
+	for _, projectID := range projectIDs {
+		keys, err := m.ListExpiring(ctx, projectID, within)
+		if err != nil {
+			return rotations, err
+		}
+
+		for i := range keys {
+			rotation, err := m.rotate(ctx, projectID, &keys[i].Key)
+			if err != nil {
+				return rotations, err
+			}
+			rotations = append(rotations, *rotation)
+		}
+	}
+
+	return rotations, nil
+}
+
+/*
+helpers
+*/
+func (m *Manager) rotate(ctx context.Context, projectID string, old *api.APIKey) (*Rotation, error) {
+	rotation := &Rotation{
+		ProjectID: projectID,
+		OldKeyID:  old.APIKeyID,
+		Request: api.KeyCreateRequest{
+			Comment: old.Comment,
+			Scopes:  old.Scopes,
+			Tags:    old.Tags,
+		},
+		DryRun: m.opts.DryRun,
+	}
+	if m.opts.ExpiresIn > 0 {
+		rotation.Request.ExpirationDate = time.Now().Add(m.opts.ExpiresIn).UTC().Truncate(time.Second)
+	}
+
+	if m.opts.DryRun {
+		klog.V(3).Infof("[dry-run] would rotate key %s in project %s\n", old.APIKeyID, projectID)
+		return rotation, nil
+	}
+
+	created, err := m.client.CreateKey(ctx, projectID, &rotation.Request)
+	if err != nil {
+		klog.V(1).Infof("CreateKey failed. Err: %v\n", err)
+		return nil, err
+	}
+	if created.Key == "" {
+		m.rollback(projectID, created.APIKeyID)
+		return nil, ErrNoSecret
+	}
+	rotation.NewKeyID = created.APIKeyID
+	rotation.CreatedAt = time.Now()
+	klog.V(4).Infof("Created key %s to replace %s\n", created.APIKeyID, old.APIKeyID)
+
+	secret := &Secret{
+		ProjectID:     projectID,
+		APIKeyID:      created.APIKeyID,
+		Key:           created.Key,
+		Comment:       rotation.Request.Comment,
+		Scopes:        rotation.Request.Scopes,
+		Tags:          rotation.Request.Tags,
+		Expiration:    rotation.Request.ExpirationDate,
+		ReplacesKeyID: old.APIKeyID,
+	}
+	if err := m.opts.Sink.Publish(ctx, secret); err != nil {
+		// nobody can be using the new key yet, so remove it and leave the old one in place
+		klog.V(1).Infof("SecretSink.Publish failed. Err: %v\n", err)
+		m.rollback(projectID, created.APIKeyID)
+		return nil, err
+	}
+
+	if m.opts.GracePeriod > 0 {
+		klog.V(4).Infof("Waiting %v before deleting key %s\n", m.opts.GracePeriod, old.APIKeyID)
+		timer := time.NewTimer(m.opts.GracePeriod)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			// the new key is published, so the old one is left for the next run to clean up
+			klog.V(1).Infof("Rotation of %s interrupted after publishing %s\n", old.APIKeyID, created.APIKeyID)
+			return rotation, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if _, err := m.client.DeleteKey(ctx, projectID, old.APIKeyID); err != nil {
+		klog.V(1).Infof("DeleteKey(%s) failed. Err: %v\n", old.APIKeyID, err)
+		return rotation, err
+	}
+	rotation.DeletedAt = time.Now()
+
+	klog.V(3).Infof("Rotated key %s to %s in project %s\n", old.APIKeyID, created.APIKeyID, projectID)
+	return rotation, nil
+}
+
+func (m *Manager) rollback(projectID, keyID string) {
+	if keyID == "" {
+		return
+	}
+
+	// use a fresh context since the original may be what failed
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := m.client.DeleteKey(ctx, projectID, keyID); err != nil {
+		klog.V(1).Infof("Rollback of key %s failed. Err: %v\n", keyID, err)
+	}
+}
+
+func expiring(projectID string, keys []api.APIKeyPermission, now time.Time, within time.Duration) []ExpiringKey {
+	list := make([]ExpiringKey, 0)
+	for _, key := range keys {
+		if key.APIKey.ExpirationDate == "" {
+			continue
+		}
+		expiresAt, err := time.Parse(time.RFC3339, key.APIKey.ExpirationDate)
+		if err != nil {
+			klog.V(1).Infof("Key %s has an invalid expiration date: %s\n", key.APIKey.APIKeyID, key.APIKey.ExpirationDate)
+			continue
+		}
+
+		remaining := expiresAt.Sub(now)
+		if remaining > within {
+			continue
+		}
+		list = append(list, ExpiringKey{
+			ProjectID: projectID,
+			Key:       key.APIKey,
+			Member:    key.Member,
+			ExpiresAt: expiresAt,
+			Remaining: remaining,
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ExpiresAt.Before(list[j].ExpiresAt)
+	})
+
+	return list
+}
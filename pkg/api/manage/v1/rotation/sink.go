@@ -0,0 +1,39 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package rotation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// FileSink writes the new key to a file, replacing it atomically so readers never see a partial key
+type FileSink struct {
+	Path string
+}
+
+// Publish writes the key to s.Path with 0600 permissions
+func (s *FileSink) Publish(_ context.Context, secret *Secret) error {
+	if s.Path == "" || secret == nil {
+		return ErrInvalidInput
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(secret.Key); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
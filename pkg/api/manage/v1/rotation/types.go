@@ -0,0 +1,80 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package rotation
+
+import (
+	"context"
+	"time"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+)
+
+// Secret is a newly created key handed to a SecretSink
+type Secret struct {
+	ProjectID     string
+	APIKeyID      string
+	Key           string
+	Comment       string
+	Scopes        []string
+	Tags          []string
+	Expiration    time.Time
+	ReplacesKeyID string
+}
+
+// SecretSink publishes a new key to wherever consumers read it from (ie a secrets manager)
+type SecretSink interface {
+	Publish(ctx context.Context, secret *Secret) error
+}
+
+// SinkFunc adapts a function to the SecretSink interface
+type SinkFunc func(ctx context.Context, secret *Secret) error
+
+// Publish calls f(ctx, secret)
+func (f SinkFunc) Publish(ctx context.Context, secret *Secret) error {
+	return f(ctx, secret)
+}
+
+// Options configures a Manager
+type Options struct {
+	// Sink receives each new key. Required unless DryRun is set.
+	Sink SecretSink
+
+	// GracePeriod is how long to wait after publishing before the old key is deleted,
+	// giving consumers time to pick up the new one
+	GracePeriod time.Duration
+
+	// ExpiresIn sets the expiration of new keys. 0 creates keys which do not expire.
+	ExpiresIn time.Duration
+
+	// DryRun plans rotations without creating, publishing or deleting anything
+	DryRun bool
+}
+
+// Rotation describes a rotation which was performed, or would be in dry-run mode
+type Rotation struct {
+	ProjectID string               `json:"project_id"`
+	OldKeyID  string               `json:"old_key_id"`
+	NewKeyID  string               `json:"new_key_id,omitempty"`
+	Request   api.KeyCreateRequest `json:"request"`
+	DryRun    bool                 `json:"dry_run,omitempty"`
+	CreatedAt time.Time            `json:"created_at,omitempty"`
+	DeletedAt time.Time            `json:"deleted_at,omitempty"`
+}
+
+// ExpiringKey is a key which expires soon
+type ExpiringKey struct {
+	ProjectID string        `json:"project_id"`
+	Key       api.APIKey    `json:"key"`
+	Member    api.Member    `json:"member"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	Remaining time.Duration `json:"remaining"`
+}
+
+// Manager rotates API keys and tracks their expiration
+type Manager struct {
+	client *manage.Client
+	opts   Options
+}
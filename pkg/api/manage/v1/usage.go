@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"strconv"
 
 	klog "k8s.io/klog/v2"
 
@@ -41,7 +42,55 @@ func (c *Client) ListRequests(ctx context.Context, projectID string, use *api.Us
 	}
 
 	klog.V(6).Infof("manage.ListRequests() LEAVE\n")
-	return &resp, nil
+	return &resp, err
+}
+
+/*
+IterateRequests returns an Iterator over the requests for a project, starting at use.Page.
+
+Pages of use.Limit requests are fetched lazily as the iterator advances, or ahead of time
+when opts.Prefetch is set. Iteration stops after the first short or empty page.
+*/
+func (c *Client) IterateRequests(ctx context.Context, projectID string, use *api.UsageListRequest, opts *IteratorOptions) *Iterator[api.Request] {
+	var base api.UsageListRequest
+	if use != nil {
+		base = *use
+	}
+
+	return newIterator(ctx, base.Page, opts, func(ctx context.Context, page int) ([]api.Request, bool, error) {
+		req := base
+		req.Page = page
+
+		params := map[string][]string{
+			"page": {strconv.Itoa(page)},
+		}
+		if req.Limit > 0 {
+			params["limit"] = []string{strconv.Itoa(req.Limit)}
+		}
+		if req.Start != "" {
+			params["start"] = []string{req.Start}
+		}
+		if req.End != "" {
+			params["end"] = []string{req.End}
+		}
+		if req.Status != "" {
+			params["status"] = []string{req.Status}
+		}
+
+		resp, err := c.ListRequests(withParameters(ctx, params), projectID, &req)
+		if err != nil {
+			return nil, false, err
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = resp.Limit
+		}
+		last := len(resp.Requests) == 0 || (limit > 0 && len(resp.Requests) < limit)
+
+		klog.V(4).Infof("IterateRequests page %d: %d requests (last: %t)\n", page, len(resp.Requests), last)
+		return resp.Requests, last, nil
+	})
 }
 
 // GetRequest gets a request by ID
@@ -57,7 +106,7 @@ func (c *Client) GetRequest(ctx context.Context, projectID, requestID string) (*
 	}
 
 	klog.V(6).Infof("manage.GetRequest() LEAVE\n")
-	return &resp, nil
+	return &resp, err
 }
 
 // GetFields gets a list of fields for a project
@@ -79,7 +128,7 @@ func (c *Client) GetFields(ctx context.Context, projectID string, use *api.Usage
 	}
 
 	klog.V(6).Infof("manage.GetFields() LEAVE\n")
-	return &resp, nil
+	return &resp, err
 }
 
 // GetUsage gets a usage by ID
@@ -101,5 +150,5 @@ func (c *Client) GetUsage(ctx context.Context, projectID string, use *api.UsageR
 	}
 
 	klog.V(6).Infof("manage.GetUsage() LEAVE\n")
-	return &resp, nil
+	return &resp, err
 }
@@ -135,10 +135,14 @@ func (c *Client) performAction(action func() (map[string]string, error)) (*api.S
 		return nil, err
 	}
 
-	charCnt, err := strconv.Atoi(retVal["char-count"])
-	if err != nil {
-		klog.V(1).Infof("strconv.Atoi failed. Err: %v\n", err)
-		return nil, err
+	// char-count is not returned when the audio is delivered to a callback
+	charCnt := 0
+	if value := retVal["char-count"]; value != "" {
+		charCnt, err = strconv.Atoi(value)
+		if err != nil {
+			klog.V(1).Infof("strconv.Atoi failed. Err: %v\n", err)
+			return nil, err
+		}
 	}
 
 	resp.ContextType = retVal["content-type"]
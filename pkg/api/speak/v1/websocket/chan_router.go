@@ -5,6 +5,7 @@
 package websocketv1
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"os"
@@ -14,6 +15,8 @@ import (
 	klog "k8s.io/klog/v2"
 
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/speak/v1/websocket/interfaces"
+	delivery "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1/delivery"
+	clientinterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
 )
 
 // NewWithDefault creates a ChanRouter with the default callback handler
@@ -32,6 +35,12 @@ func NewChanRouterWithDefault() *ChanRouter {
 // New creates a ChanRouter with a user-defined channels
 // gocritic:ignore
 func NewChanRouter(chans interfaces.SpeakMessageChan) *ChanRouter {
+	return NewChanRouterWithOptions(context.Background(), chans, nil)
+}
+
+// NewChanRouterWithOptions creates a ChanRouter which applies the delivery options to every channel.
+// Blocked sends are abandoned once ctx is done.
+func NewChanRouterWithOptions(ctx context.Context, chans interfaces.SpeakMessageChan, opts *clientinterfaces.ChanDeliveryOptions) *ChanRouter {
 	var debugStr string
 	if v := os.Getenv("DEEPGRAM_DEBUG"); v != "" {
 		klog.V(4).Infof("DEEPGRAM_DEBUG found")
@@ -40,6 +49,7 @@ func NewChanRouter(chans interfaces.SpeakMessageChan) *ChanRouter {
 
 	router := &ChanRouter{
 		debugWebsocket: strings.EqualFold(strings.ToLower(debugStr), "true"),
+		dispatcher:     delivery.New(ctx, opts),
 		binaryChan:     make([]*chan *[]byte, 0),
 		openChan:       make([]*chan *interfaces.OpenResponse, 0),
 		metadataChan:   make([]*chan *interfaces.MetadataResponse, 0),
@@ -63,6 +73,8 @@ func NewChanRouter(chans interfaces.SpeakMessageChan) *ChanRouter {
 		router.unhandledChan = append(router.unhandledChan, chans.GetUnhandled()...)
 	}
 
+	router.events = delivery.NewHub[interfaces.Event](router.dispatcher)
+
 	return router
 }
 
@@ -82,8 +94,11 @@ func (r *ChanRouter) Open(or *interfaces.OpenResponse) error {
 		}
 
 		for _, ch := range r.openChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeOpenResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeOpenResponse), Open: &msg})
 		return nil
 	}
 
@@ -106,8 +121,11 @@ func (r *ChanRouter) Close(cr *interfaces.CloseResponse) error {
 		}
 
 		for _, ch := range r.closeChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeCloseResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeCloseResponse), Close: &msg})
 		return nil
 	}
 
@@ -130,8 +148,11 @@ func (r *ChanRouter) Error(er *interfaces.ErrorResponse) error {
 		}
 
 		for _, ch := range r.errorChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeErrorResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeErrorResponse), Error: &msg})
 		return nil
 	}
 
@@ -164,8 +185,11 @@ func (r *ChanRouter) processMetadata(byMsg []byte) error {
 		}
 
 		for _, ch := range r.metadataChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeMetadataResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeMetadataResponse), Metadata: &msg})
 		return nil
 	}
 
@@ -181,8 +205,11 @@ func (r *ChanRouter) processFlushed(byMsg []byte) error {
 		}
 
 		for _, ch := range r.flushedChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeFlushedResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeFlushedResponse), Flushed: &msg})
 		return nil
 	}
 
@@ -198,8 +225,11 @@ func (r *ChanRouter) processCleared(byMsg []byte) error {
 		}
 
 		for _, ch := range r.clearedChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeClearedResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeClearedResponse), Cleared: &msg})
 		return nil
 	}
 
@@ -215,8 +245,11 @@ func (r *ChanRouter) processWarningResponse(byMsg []byte) error {
 		}
 
 		for _, ch := range r.warningChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeWarningResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeWarningResponse), Warning: &msg})
 		return nil
 	}
 
@@ -232,8 +265,11 @@ func (r *ChanRouter) processErrorResponse(byMsg []byte) error {
 		}
 
 		for _, ch := range r.errorChan {
-			*ch <- &msg
+			if err := delivery.Send(r.dispatcher, string(interfaces.TypeErrorResponse), ch, &msg); err != nil {
+				return err
+			}
 		}
+		r.events.Publish(interfaces.Event{Type: string(interfaces.TypeErrorResponse), Error: &msg})
 		return nil
 	}
 
@@ -286,8 +322,13 @@ func (r *ChanRouter) Binary(byMsg []byte) error {
 
 	klog.V(5).Infof("Binary Message:\n%s...\n", hex.EncodeToString(byMsg[:20]))
 	for _, ch := range r.binaryChan {
-		*ch <- &byMsg
+		if err := delivery.Send(r.dispatcher, delivery.MessageTypeBinary, ch, &byMsg); err != nil {
+			klog.V(1).Infof("router.Binary delivery failed. Err: %v\n", err)
+			klog.V(6).Infof("router.Binary LEAVE\n")
+			return err
+		}
 	}
+	r.events.Publish(interfaces.Event{Type: delivery.MessageTypeBinary, Binary: byMsg})
 
 	klog.V(6).Infof("router.Binary LEAVE\n")
 	return nil
@@ -299,8 +340,11 @@ func (r *ChanRouter) UnhandledMessage(byMsg []byte) error {
 	r.printDebugMessages(3, "UnhandledMessage", byMsg)
 
 	for _, ch := range r.unhandledChan {
-		*ch <- &byMsg
+		if err := delivery.Send(r.dispatcher, delivery.MessageTypeUnhandled, ch, &byMsg); err != nil {
+			break
+		}
 	}
+	r.events.Publish(interfaces.Event{Type: delivery.MessageTypeUnhandled, Unhandled: byMsg})
 
 	klog.V(1).Infof("Unknown Event was received\n")
 	klog.V(6).Infof("router.UnhandledMessage LEAVE\n")
@@ -308,6 +352,17 @@ func (r *ChanRouter) UnhandledMessage(byMsg []byte) error {
 	return ErrInvalidMessageType
 }
 
+// Subscribe adds a subscriber which receives the events matching filter, see interfaces.EventFilter.
+// Call cancel to unsubscribe, which closes the channel.
+func (r *ChanRouter) Subscribe(filter *interfaces.EventFilter) (<-chan interfaces.Event, func()) {
+	return r.events.Subscribe(filter.Match, defaultSubscriberBuffer)
+}
+
+// DeliveryStats returns the delivery counters for each channel, keyed by message type and index
+func (r *ChanRouter) DeliveryStats() map[string]delivery.Stats {
+	return r.dispatcher.Stats()
+}
+
 // printDebugMessages formats and logs debugging messages
 func (r *ChanRouter) printDebugMessages(level klog.Level, function string, byMsg []byte) {
 	prettyJSON, err := prettyjson.Format(byMsg)
@@ -12,6 +12,11 @@ const (
 	PackageVersion string = "v1.0"
 )
 
+const (
+	// channel buffer for each Subscribe subscriber
+	defaultSubscriberBuffer int = 100
+)
+
 // errors
 var (
 	// ErrInvalidInput required input was not found
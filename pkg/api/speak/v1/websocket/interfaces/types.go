@@ -59,3 +59,39 @@ type WarningResponse = interfaces.DeepgramWarning
 
 // ErrorResponse is the Deepgram specific response error
 type ErrorResponse = interfaces.DeepgramError
+
+/***********************************/
+// Subscriptions
+/***********************************/
+// Event is a single message from the websocket. Type is the message type and only the matching field is set.
+type Event struct {
+	Type string
+
+	Open      *OpenResponse
+	Metadata  *MetadataResponse
+	Flushed   *FlushedResponse
+	Cleared   *ClearedResponse
+	Warning   *WarningResponse
+	Close     *CloseResponse
+	Error     *ErrorResponse
+	Binary    []byte // audio, Type is "Binary"
+	Unhandled []byte
+}
+
+// EventFilter selects the events a subscriber receives. A nil or empty filter receives everything.
+type EventFilter struct {
+	Types []string // message types, e.g. string(TypeFlushedResponse) or "Binary"
+}
+
+// Match reports whether the event passes the filter
+func (f *EventFilter) Match(e Event) bool {
+	if f == nil || len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
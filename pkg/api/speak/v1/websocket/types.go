@@ -6,6 +6,7 @@ package websocketv1
 
 import (
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/speak/v1/websocket/interfaces"
+	delivery "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1/delivery"
 )
 
 /*
@@ -31,6 +32,8 @@ type DefaultChanHandler struct {
 // ChanRouter routes events
 type ChanRouter struct {
 	debugWebsocket bool
+	dispatcher     *delivery.Dispatcher
+	events         *delivery.Hub[interfaces.Event]
 
 	// call out to channels
 	binaryChan    []*chan *[]byte
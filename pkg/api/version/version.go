@@ -41,7 +41,7 @@ func getAPIURL(ctx context.Context, apiType, host, version, path string, options
 
 	// check if the host has a protocol
 	r := regexp.MustCompile(`^(https?)://(.+)$`)
-	if apiType == APITypeLive || apiType == APITypeSpeakStream {
+	if apiType == APITypeLive || apiType == APITypeSpeakStream || apiType == APITypeAgent {
 		r = regexp.MustCompile(`^(wss?)://(.+)$`)
 	}
 
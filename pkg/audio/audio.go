@@ -0,0 +1,144 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+// Package audio defines Source, the common interface of the SDK's audio inputs, and generic sources
+package audio
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	wav "github.com/youpy/go-wav"
+)
+
+// BytesPerSample is the size of one sample of one channel, 0 for an unknown encoding
+func (f Format) BytesPerSample() int {
+	switch f.Encoding {
+	case EncodingLinear16:
+		return 2
+	case EncodingLinear32:
+		return 4
+	case EncodingMulaw, EncodingAlaw:
+		return 1
+	}
+	return 0
+}
+
+// BytesPerSecond is the data rate of the audio, 0 if the format is incomplete
+func (f Format) BytesPerSecond() int {
+	return f.BytesPerSample() * f.channels() * f.SampleRate
+}
+
+// Duration is the length of byteCount bytes of audio
+func (f Format) Duration(byteCount int64) time.Duration {
+	bytesPerSecond := int64(f.BytesPerSecond())
+	if bytesPerSecond == 0 {
+		return 0
+	}
+	return time.Duration(byteCount * int64(time.Second) / bytesPerSecond)
+}
+
+// Validate checks the format describes raw audio which can be streamed
+func (f Format) Validate() error {
+	if f.SampleRate <= 0 {
+		return ErrInvalidInput
+	}
+	if f.BytesPerSample() == 0 {
+		return ErrUnsupportedFormat
+	}
+	return nil
+}
+
+// Equal reports whether two formats describe the same audio, treating 0 channels as mono
+func (f Format) Equal(other Format) bool {
+	return f.Encoding == other.Encoding && f.SampleRate == other.SampleRate && f.channels() == other.channels()
+}
+
+func (f Format) channels() int {
+	if f.Channels <= 0 {
+		return 1
+	}
+	return f.Channels
+}
+
+// bytes is the size of d of audio, in whole sample frames
+func (f Format) bytes(d time.Duration) int64 {
+	blockAlign := int64(f.BytesPerSample() * f.channels())
+	frames := int64(d) * int64(f.SampleRate) / int64(time.Second)
+	return frames * blockAlign
+}
+
+// FormatFromWAV converts the format of a wav file
+func FormatFromWAV(f *wav.WavFormat) (Format, error) {
+	if f == nil {
+		return Format{}, ErrInvalidInput
+	}
+
+	format := Format{
+		SampleRate: int(f.SampleRate),
+		Channels:   int(f.NumChannels),
+	}
+	switch {
+	case f.AudioFormat == wav.AudioFormatPCM && f.BitsPerSample == 16:
+		format.Encoding = EncodingLinear16
+	case f.AudioFormat == wav.AudioFormatPCM && f.BitsPerSample == 32:
+		format.Encoding = EncodingLinear32
+	case f.AudioFormat == wav.AudioFormatALaw:
+		format.Encoding = EncodingAlaw
+	case f.AudioFormat == wav.AudioFormatMULaw:
+		format.Encoding = EncodingMulaw
+	default:
+		return Format{}, ErrUnsupportedFormat
+	}
+	return format, nil
+}
+
+/*
+DetectContentType returns the content type of the audio container in the leading bytes of a file, or
+"" when it is not one of WAV, FLAC, MP3, Ogg, WebM, MP4/M4A or AAC. Headerless audio, like linear16,
+can't be detected.
+*/
+func DetectContentType(header []byte) string {
+	switch {
+	case len(header) >= 12 && bytes.HasPrefix(header, []byte("RIFF")) && string(header[8:12]) == "WAVE":
+		return ContentTypeWAV
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		return ContentTypeFLAC
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return ContentTypeOgg
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		// EBML, which matroska shares
+		return ContentTypeWebM
+	case len(header) >= 8 && string(header[4:8]) == "ftyp":
+		return ContentTypeMP4
+	case bytes.HasPrefix(header, []byte("ID3")):
+		return ContentTypeMP3
+	case bytes.HasPrefix(header, []byte("ADIF")):
+		return ContentTypeAAC
+	case len(header) >= 2 && header[0] == 0xFF && header[1]&0xF6 == 0xF0:
+		// ADTS sync, with mpeg layer 0
+		return ContentTypeAAC
+	case len(header) >= 2 && header[0] == 0xFF && header[1]&0xE0 == 0xE0 && header[1]&0x06 != 0:
+		// mpeg audio frame sync, layers I to III
+		return ContentTypeMP3
+	}
+	return ""
+}
+
+/*
+ContextReader wraps r so a read fails with ctx.Err() once ctx is done. A read already blocked in r
+is not interrupted.
+*/
+func ContextReader(ctx context.Context, r io.Reader) io.Reader {
+	return &contextReader{ctx: ctx, r: r}
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+// Implementation for muting or bleeping regions of PCM audio, typically the spans of redacted words
+package bleep
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+
+	klog "k8s.io/klog/v2"
+)
+
+// NewWriter creates a Writer which scrubs opts.Spans from the PCM audio written to w
+func NewWriter(w io.Writer, opts Options) (*Writer, error) {
+	klog.V(6).Infof("bleep.NewWriter ENTER\n")
+
+	if w == nil {
+		klog.V(1).Infof("bleep.NewWriter: io.Writer is nil\n")
+		klog.V(6).Infof("bleep.NewWriter LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+	if err := opts.Format.check(); err != nil {
+		klog.V(1).Infof("bleep.NewWriter: invalid format. Err: %v\n", err)
+		klog.V(6).Infof("bleep.NewWriter LEAVE\n")
+		return nil, err
+	}
+	if opts.ToneFrequency <= 0 {
+		opts.ToneFrequency = defaultToneFrequency
+	}
+	if opts.ToneVolume <= 0 || opts.ToneVolume > 1 {
+		opts.ToneVolume = defaultToneVolume
+	}
+
+	bytesPerSample := opts.Format.BitsPerSample / 8
+	writer := &Writer{
+		w:              w,
+		opts:           opts,
+		spans:          mergeSpans(opts.Spans),
+		bytesPerSample: bytesPerSample,
+		frameSize:      bytesPerSample * opts.Format.Channels,
+	}
+
+	klog.V(4).Infof("bleep.NewWriter: %d spans to scrub\n", len(writer.spans))
+	klog.V(6).Infof("bleep.NewWriter LEAVE\n")
+
+	return writer, nil
+}
+
+/*
+Write scrubs and writes PCM data. Partial frames are held until the rest of the frame arrives.
+This implements the io.Writer interface so it can be handed to replay.Client.Stream().
+*/
+func (w *Writer) Write(p []byte) (int, error) {
+	byteLen := len(p)
+
+	data := p
+	if len(w.remainder) > 0 {
+		data = append(w.remainder, p...)
+		w.remainder = nil
+	}
+
+	usable := len(data) - (len(data) % w.frameSize)
+	if usable < len(data) {
+		w.remainder = append([]byte{}, data[usable:]...)
+	}
+
+	out := make([]byte, usable)
+	copy(out, data[:usable])
+	for offset := 0; offset < usable; offset += w.frameSize {
+		w.processFrame(out[offset : offset+w.frameSize])
+		w.frames++
+	}
+
+	if usable > 0 {
+		if _, err := w.w.Write(out); err != nil {
+			klog.V(1).Infof("bleep.Write failed. Err: %v\n", err)
+			return 0, err
+		}
+	}
+
+	return byteLen, nil
+}
+
+// Flush writes any trailing partial frame unchanged
+func (w *Writer) Flush() error {
+	if len(w.remainder) == 0 {
+		return nil
+	}
+
+	_, err := w.w.Write(w.remainder)
+	w.remainder = nil
+	return err
+}
+
+// Position returns the amount of audio which has been processed
+func (w *Writer) Position() time.Duration {
+	return time.Duration(float64(w.frames) / float64(w.opts.Format.SampleRate) * float64(time.Second))
+}
+
+// processFrame scrubs the samples of a single frame in place if it falls within a span
+func (w *Writer) processFrame(frame []byte) {
+	t := float64(w.frames) / float64(w.opts.Format.SampleRate)
+	pad := w.opts.Padding.Seconds()
+
+	// spans are sorted and the position only moves forward
+	for w.next < len(w.spans) && w.spans[w.next].End+pad < t {
+		w.next++
+	}
+
+	for i := w.next; i < len(w.spans) && w.spans[i].Start-pad <= t; i++ {
+		span := w.spans[i]
+		if span.End+pad < t {
+			continue
+		}
+
+		for ch := 0; ch < w.opts.Format.Channels; ch++ {
+			if span.Channel != AllChannels && span.Channel != ch {
+				continue
+			}
+			offset := ch * w.bytesPerSample
+			w.writeSample(frame[offset:offset+w.bytesPerSample], w.sampleValue(t))
+		}
+	}
+}
+
+// sampleValue returns the replacement value in the range -1.0 to 1.0
+func (w *Writer) sampleValue(t float64) float64 {
+	if w.opts.Mode != ModeTone {
+		return 0
+	}
+	return w.opts.ToneVolume * math.Sin(2*math.Pi*w.opts.ToneFrequency*t)
+}
+
+// writeSample encodes v into a little-endian sample
+func (w *Writer) writeSample(sample []byte, v float64) {
+	switch w.bytesPerSample {
+	case 1:
+		// 8-bit PCM is unsigned
+		sample[0] = byte(128 + int(v*127))
+	case 2:
+		binary.LittleEndian.PutUint16(sample, uint16(int16(v*math.MaxInt16)))
+	case 3:
+		i := int32(v * 8388607)
+		sample[0] = byte(i)
+		sample[1] = byte(i >> 8)
+		sample[2] = byte(i >> 16)
+	case 4:
+		binary.LittleEndian.PutUint32(sample, uint32(int32(v*math.MaxInt32)))
+	}
+}
+
+func (f Format) check() error {
+	if f.SampleRate <= 0 || f.Channels <= 0 {
+		return ErrInvalidInput
+	}
+	switch f.BitsPerSample {
+	case 8, 16, 24, 32:
+		return nil
+	}
+	return ErrUnsupportedFormat
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package bleep
+
+import (
+	"errors"
+)
+
+// constants
+const (
+	// AllChannels applies a span to every channel in the audio
+	AllChannels int = -1
+
+	defaultToneFrequency float64 = 1000
+	defaultToneVolume    float64 = 0.5
+	defaultBytesToRead   int     = 2048
+
+	// wav layout
+	riffHeaderSize  int   = 12
+	chunkHeaderSize int   = 8
+	fmtChunkSize    int64 = 16
+)
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrUnsupportedFormat the audio format is not linear PCM
+	ErrUnsupportedFormat = errors.New("unsupported audio format. only linear PCM is supported")
+
+	// ErrDataBeforeFmt the WAV data chunk comes before the fmt chunk which describes it
+	ErrDataBeforeFmt = errors.New("wav data chunk comes before the fmt chunk")
+)
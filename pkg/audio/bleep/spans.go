@@ -0,0 +1,114 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package bleep
+
+import (
+	"sort"
+	"strings"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+	redact "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/redact"
+)
+
+// IsRedactedWord returns true for words replaced by the Redact feature (ie "[PCI]", "[SSN_1]")
+func IsRedactedWord(w *api.Word) bool {
+	word := w.Word
+	if w.PunctuatedWord != "" {
+		word = strings.TrimRight(w.PunctuatedWord, ".,?!;:")
+	}
+	return len(word) > 2 && strings.HasPrefix(word, "[") && strings.HasSuffix(word, "]")
+}
+
+// SpansFromWords returns the spans covered by redacted words. Adjacent redacted words are combined.
+func SpansFromWords(words []api.Word, channel int) []Span {
+	spans := make([]Span, 0)
+	for i := range words {
+		if !IsRedactedWord(&words[i]) {
+			continue
+		}
+		spans = append(spans, Span{
+			Start:   words[i].Start,
+			End:     words[i].End,
+			Channel: channel,
+		})
+	}
+	return mergeSpans(spans)
+}
+
+/*
+SpansFromResponse returns the spans of all redacted words in a transcript generated with the Redact option.
+
+When the response contains more than one channel (ie Multichannel), each span only applies to its own channel.
+*/
+func SpansFromResponse(resp *api.PreRecordedResponse) []Span {
+	spans := make([]Span, 0)
+	if resp == nil || resp.Results == nil {
+		return spans
+	}
+
+	multichannel := len(resp.Results.Channels) > 1
+	for idx, channel := range resp.Results.Channels {
+		if len(channel.Alternatives) == 0 {
+			continue
+		}
+		ch := AllChannels
+		if multichannel {
+			ch = idx
+		}
+		spans = append(spans, SpansFromWords(channel.Alternatives[0].Words, ch)...)
+	}
+
+	return mergeSpans(spans)
+}
+
+// SpansFromRedaction converts the spans reported by the redact package
+func SpansFromRedaction(redacted []redact.Span, multichannel bool) []Span {
+	spans := make([]Span, 0, len(redacted))
+	for _, s := range redacted {
+		ch := AllChannels
+		if multichannel {
+			ch = s.Channel
+		}
+		spans = append(spans, Span{
+			Start:   s.Start,
+			End:     s.End,
+			Channel: ch,
+		})
+	}
+	return mergeSpans(spans)
+}
+
+// mergeSpans sorts the spans and combines overlapping spans on the same channel
+func mergeSpans(spans []Span) []Span {
+	sorted := make([]Span, 0, len(spans))
+	for _, s := range spans {
+		if s.End < s.Start {
+			s.Start, s.End = s.End, s.Start
+		}
+		sorted = append(sorted, s)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	merged := make([]Span, 0, len(sorted))
+	for _, s := range sorted {
+		combined := false
+		for i := len(merged) - 1; i >= 0; i-- {
+			if merged[i].Channel == s.Channel && s.Start <= merged[i].End {
+				if s.End > merged[i].End {
+					merged[i].End = s.End
+				}
+				combined = true
+				break
+			}
+		}
+		if !combined {
+			merged = append(merged, s)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package bleep
+
+import (
+	"io"
+	"time"
+)
+
+// Mode selects how redacted spans are scrubbed
+type Mode int
+
+const (
+	// ModeMute replaces the span with silence
+	ModeMute Mode = iota
+
+	// ModeTone replaces the span with a sine tone
+	ModeTone
+)
+
+// Format describes little-endian linear PCM audio
+type Format struct {
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+}
+
+// Span is a region of audio, in seconds, to be scrubbed
+type Span struct {
+	Start   float64
+	End     float64
+	Channel int // AllChannels or a zero based channel index
+}
+
+// Options for scrubbing audio
+type Options struct {
+	Format Format
+	Spans  []Span
+	Mode   Mode
+
+	ToneFrequency float64       // Hz, defaults to 1000
+	ToneVolume    float64       // 0.0 to 1.0, defaults to 0.5
+	Padding       time.Duration // extends every span on both sides
+}
+
+// Writer scrubs PCM audio as it is written through to the underlying io.Writer
+type Writer struct {
+	w      io.Writer
+	opts   Options
+	spans  []Span
+	next   int
+	frames int64
+
+	bytesPerSample int
+	frameSize      int
+	remainder      []byte
+}
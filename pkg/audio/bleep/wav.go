@@ -0,0 +1,223 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package bleep
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+
+	wav "github.com/youpy/go-wav"
+	klog "k8s.io/klog/v2"
+)
+
+// FormatFromWAV converts the format of a WAV file
+func FormatFromWAV(f *wav.WavFormat) (Format, error) {
+	if f == nil || f.AudioFormat != wav.AudioFormatPCM {
+		return Format{}, ErrUnsupportedFormat
+	}
+	return Format{
+		SampleRate:    int(f.SampleRate),
+		Channels:      int(f.NumChannels),
+		BitsPerSample: int(f.BitsPerSample),
+	}, nil
+}
+
+// ProcessPCM copies raw PCM from r to w, scrubbing opts.Spans along the way
+func ProcessPCM(r io.Reader, w io.Writer, opts Options) error {
+	klog.V(6).Infof("bleep.ProcessPCM ENTER\n")
+
+	writer, err := NewWriter(w, opts)
+	if err != nil {
+		klog.V(1).Infof("NewWriter failed. Err: %v\n", err)
+		klog.V(6).Infof("bleep.ProcessPCM LEAVE\n")
+		return err
+	}
+
+	buf := make([]byte, defaultBytesToRead)
+	if _, err := io.CopyBuffer(writer, r, buf); err != nil {
+		klog.V(1).Infof("io.Copy failed. Err: %v\n", err)
+		klog.V(6).Infof("bleep.ProcessPCM LEAVE\n")
+		return err
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		klog.V(1).Infof("Flush failed. Err: %v\n", err)
+	}
+	klog.V(6).Infof("bleep.ProcessPCM LEAVE\n")
+
+	return err
+}
+
+/*
+ProcessWAV reads a PCM WAV file from r and writes a WAV file to w with opts.Spans scrubbed.
+Everything but the samples in the data chunk, including the header and any other chunks before or
+after it, is copied unchanged. opts.Format is ignored in favor of the fmt chunk, which must come before the data.
+r is read once from start to end, so it can be a pipe or a network stream.
+*/
+func ProcessWAV(r io.Reader, w io.Writer, opts Options) error {
+	klog.V(6).Infof("bleep.ProcessWAV ENTER\n")
+
+	header := make([]byte, riffHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		klog.V(1).Infof("reading the RIFF header failed. Err: %v\n", err)
+		klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+		return err
+	}
+	if !IsWAV(header) {
+		klog.V(1).Infof("bleep.ProcessWAV: not a WAV file\n")
+		klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+		return ErrUnsupportedFormat
+	}
+	if _, err := w.Write(header); err != nil {
+		klog.V(1).Infof("w.Write failed. Err: %v\n", err)
+		klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+		return err
+	}
+
+	var format *wav.WavFormat
+	chunkHeader := make([]byte, chunkHeaderSize)
+	for {
+		_, err := io.ReadFull(r, chunkHeader)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			klog.V(1).Infof("reading a chunk header failed. Err: %v\n", err)
+			klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+			return err
+		}
+		if _, err := w.Write(chunkHeader); err != nil {
+			klog.V(1).Infof("w.Write failed. Err: %v\n", err)
+			klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+			return err
+		}
+
+		id := string(chunkHeader[:4])
+		size := int64(binary.LittleEndian.Uint32(chunkHeader[4:]))
+		// chunks are padded to an even size
+		padded := size + size%2
+
+		switch {
+		case id == "fmt ":
+			body := make([]byte, padded)
+			if _, err := io.ReadFull(r, body); err != nil {
+				klog.V(1).Infof("reading the fmt chunk failed. Err: %v\n", err)
+				klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+				return err
+			}
+			if size < fmtChunkSize {
+				klog.V(1).Infof("bleep.ProcessWAV: fmt chunk of %d bytes is too short\n", size)
+				klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+				return ErrUnsupportedFormat
+			}
+			format = &wav.WavFormat{
+				AudioFormat:   binary.LittleEndian.Uint16(body[0:]),
+				NumChannels:   binary.LittleEndian.Uint16(body[2:]),
+				SampleRate:    binary.LittleEndian.Uint32(body[4:]),
+				ByteRate:      binary.LittleEndian.Uint32(body[8:]),
+				BlockAlign:    binary.LittleEndian.Uint16(body[12:]),
+				BitsPerSample: binary.LittleEndian.Uint16(body[14:]),
+			}
+			if _, err := w.Write(body); err != nil {
+				klog.V(1).Infof("w.Write failed. Err: %v\n", err)
+				klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+				return err
+			}
+		case id == "data":
+			// without the format, the samples can't be scrubbed, and they must not be copied as is
+			if format == nil {
+				klog.V(1).Infof("bleep.ProcessWAV: %v\n", ErrDataBeforeFmt)
+				klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+				return ErrDataBeforeFmt
+			}
+			opts.Format, err = FormatFromWAV(format)
+			if err != nil {
+				klog.V(1).Infof("FormatFromWAV failed. Err: %v\n", err)
+				klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+				return err
+			}
+			if err := ProcessPCM(io.LimitReader(r, size), w, opts); err != nil {
+				klog.V(1).Infof("ProcessPCM failed. Err: %v\n", err)
+				klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+				return err
+			}
+			if _, err := io.CopyN(w, r, padded-size); err != nil {
+				klog.V(1).Infof("copying the data chunk padding failed. Err: %v\n", err)
+				klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+				return err
+			}
+		default:
+			// LIST, cue, etc
+			if _, err := io.CopyN(w, r, padded); err != nil {
+				klog.V(1).Infof("copying the %q chunk failed. Err: %v\n", id, err)
+				klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+				return err
+			}
+		}
+	}
+
+	if format == nil {
+		klog.V(1).Infof("bleep.ProcessWAV: no fmt chunk\n")
+		klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+		return ErrUnsupportedFormat
+	}
+
+	klog.V(3).Infof("bleep.ProcessWAV Succeeded\n")
+	klog.V(6).Infof("bleep.ProcessWAV LEAVE\n")
+
+	return nil
+}
+
+/*
+ProcessFile scrubs the audio in inFile and writes the result to outFile.
+
+WAV files are detected by their header, anything else is treated as raw PCM described by opts.Format.
+*/
+func ProcessFile(inFile, outFile string, opts Options) error {
+	klog.V(6).Infof("bleep.ProcessFile ENTER\n")
+
+	in, err := os.Open(inFile)
+	if err != nil {
+		klog.V(1).Infof("os.Open(%s) failed. Err: %v\n", inFile, err)
+		klog.V(6).Infof("bleep.ProcessFile LEAVE\n")
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(outFile)
+	if err != nil {
+		klog.V(1).Infof("os.Create(%s) failed. Err: %v\n", outFile, err)
+		klog.V(6).Infof("bleep.ProcessFile LEAVE\n")
+		return err
+	}
+	defer out.Close()
+
+	header := make([]byte, 12)
+	n, err := in.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		klog.V(1).Infof("ReadAt failed. Err: %v\n", err)
+		klog.V(6).Infof("bleep.ProcessFile LEAVE\n")
+		return err
+	}
+
+	if IsWAV(header[:n]) {
+		err = ProcessWAV(in, out, opts)
+	} else {
+		err = ProcessPCM(in, out, opts)
+	}
+	if err != nil {
+		klog.V(1).Infof("ProcessFile failed. Err: %v\n", err)
+	}
+	klog.V(6).Infof("bleep.ProcessFile LEAVE\n")
+
+	return err
+}
+
+// IsWAV returns true if the header starts with a RIFF/WAVE signature
+func IsWAV(header []byte) bool {
+	return len(header) >= 12 && bytes.Equal(header[0:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WAVE"))
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package audio
+
+import (
+	"errors"
+)
+
+// encodings, named as the Deepgram API names them
+const (
+	EncodingLinear16 string = "linear16"
+	EncodingLinear32 string = "linear32"
+	EncodingMulaw    string = "mulaw"
+	EncodingAlaw     string = "alaw"
+)
+
+// content types of the containers DetectContentType recognizes
+const (
+	ContentTypeWAV  string = "audio/wav"
+	ContentTypeFLAC string = "audio/flac"
+	ContentTypeMP3  string = "audio/mpeg"
+	ContentTypeOgg  string = "audio/ogg"
+	ContentTypeWebM string = "audio/webm"
+	ContentTypeMP4  string = "audio/mp4"
+	ContentTypeAAC  string = "audio/aac"
+)
+
+// constants
+const (
+	// SniffLen is the number of leading bytes DetectContentType considers
+	SniffLen int = 12
+
+	defaultToneVolume float64 = 0.5
+
+	// silence in the companded encodings
+	mulawSilence byte = 0xFF
+	alawSilence  byte = 0xD5
+)
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrUnsupportedFormat the audio format is not supported
+	ErrUnsupportedFormat = errors.New("unsupported audio format")
+
+	// ErrFormatMismatch the sources do not share the same format
+	ErrFormatMismatch = errors.New("audio formats do not match")
+)
@@ -13,6 +13,8 @@ import (
 
 	"github.com/gordonklaus/portaudio"
 	klog "k8s.io/klog/v2"
+
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
 )
 
 // Initialize inits the library. This handles OS level init of the library.
@@ -39,6 +41,11 @@ func New(cfg AudioConfig) (*Microphone, error) {
 		stopChan: make(chan struct{}),
 		intBuf:   make([]int16, defaultBytesToRead),
 		muted:    false,
+		format: audio.Format{
+			Encoding:   audio.EncodingLinear16,
+			SampleRate: int(cfg.SamplingRate),
+			Channels:   cfg.InputChannels,
+		},
 	}
 
 	stream, err := portaudio.OpenDefaultStream(cfg.InputChannels, 0, float64(cfg.SamplingRate), len(m.intBuf), m.intBuf)
@@ -106,6 +113,40 @@ func (m *Microphone) Stream(w io.Writer) error {
 	}
 }
 
+// Source returns the microphone as an audio.Source, which honors Mute. Closing it stops the mic.
+func (m *Microphone) Source() audio.Source {
+	return &source{m: m}
+}
+
+func (s *source) Read(p []byte) (int, error) {
+	if len(s.pending) == 0 {
+		select {
+		case <-s.m.stopChan:
+			return 0, io.EOF
+		default:
+		}
+
+		err := s.m.stream.Read()
+		if err != nil {
+			klog.V(1).Infof("stream.Read failed. Err: %v\n", err)
+			return 0, err
+		}
+		s.pending = s.m.int16ToLittleEndianByte(s.m.intBuf)
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+func (s *source) Format() audio.Format {
+	return s.m.format
+}
+
+func (s *source) Close() error {
+	return s.m.Stop()
+}
+
 // Mute silences the mic
 func (m *Microphone) Mute() {
 	m.mute.Lock()
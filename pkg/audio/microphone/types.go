@@ -8,6 +8,8 @@ import (
 	"sync"
 
 	"github.com/gordonklaus/portaudio"
+
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
 )
 
 // AudioConfig init config for library
@@ -20,6 +22,7 @@ type AudioConfig struct {
 type Microphone struct {
 	// microphone
 	stream *portaudio.Stream
+	format audio.Format
 
 	// buffer
 	intBuf []int16
@@ -29,3 +32,9 @@ type Microphone struct {
 	mute     sync.Mutex
 	muted    bool
 }
+
+// source is the microphone as an audio.Source
+type source struct {
+	m       *Microphone
+	pending []byte
+}
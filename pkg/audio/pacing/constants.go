@@ -0,0 +1,33 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package pacing
+
+import (
+	"errors"
+)
+
+// constants
+const (
+	defaultFrameMs  int     = 20
+	defaultChannels int     = 1
+	defaultSpeed    float64 = 1
+)
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrUnsupportedEncoding the duration of the audio can't be computed from its encoding
+	ErrUnsupportedEncoding = errors.New("unsupported encoding. pacing requires linear16, linear32, mulaw or alaw")
+)
+
+// bytes per sample of the encodings which can be paced
+var bytesPerSample = map[string]int{
+	"linear16": 2,
+	"linear32": 4,
+	"mulaw":    1,
+	"alaw":     1,
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+// Implementation for sending raw audio at real time speed, so a file behaves like a live source
+package pacing
+
+import (
+	"context"
+	"io"
+	"time"
+
+	klog "k8s.io/klog/v2"
+)
+
+// New creates a Pacer for the audio described by opts
+func New(opts Options) (*Pacer, error) {
+	klog.V(6).Infof("pacing.New ENTER\n")
+
+	sampleSize, ok := bytesPerSample[opts.Encoding]
+	if !ok {
+		klog.V(1).Infof("pacing.New: encoding %q. Err: %v\n", opts.Encoding, ErrUnsupportedEncoding)
+		klog.V(6).Infof("pacing.New LEAVE\n")
+		return nil, ErrUnsupportedEncoding
+	}
+	if opts.SampleRate <= 0 {
+		klog.V(1).Infof("pacing.New: sample rate is required\n")
+		klog.V(6).Infof("pacing.New LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+	if opts.Channels <= 0 {
+		opts.Channels = defaultChannels
+	}
+	if opts.FrameMs <= 0 {
+		opts.FrameMs = defaultFrameMs
+	}
+	if opts.Speed <= 0 {
+		opts.Speed = defaultSpeed
+	}
+
+	blockAlign := sampleSize * opts.Channels
+	p := &Pacer{
+		speed:          opts.Speed,
+		bytesPerSecond: blockAlign * opts.SampleRate,
+	}
+
+	// whole samples only, but at least one
+	p.frameBytes = p.bytesPerSecond * opts.FrameMs / 1000
+	p.frameBytes -= p.frameBytes % blockAlign
+	if p.frameBytes == 0 {
+		p.frameBytes = blockAlign
+	}
+
+	klog.V(4).Infof("pacing.New: %d bytes per %dms frame at %.2fx\n", p.frameBytes, opts.FrameMs, p.speed)
+	klog.V(6).Infof("pacing.New LEAVE\n")
+
+	return p, nil
+}
+
+// FrameBytes is the size of a frame of FrameMs of audio
+func (p *Pacer) FrameBytes() int {
+	return p.frameBytes
+}
+
+// Duration is the length of the audio in byteCount bytes
+func (p *Pacer) Duration(byteCount int) time.Duration {
+	return time.Duration(int64(byteCount) * int64(time.Second) / int64(p.bytesPerSecond))
+}
+
+/*
+Position is how much audio has been sent. Compared with the end time of a transcript, it gives the
+latency of the transcription. It is safe to call from any goroutine.
+*/
+func (p *Pacer) Position() time.Duration {
+	return time.Duration(p.position.Load())
+}
+
+/*
+Wait blocks until byteCount more bytes of audio are due to be sent, then advances the position.
+The first call returns immediately and starts the clock.
+*/
+func (p *Pacer) Wait(ctx context.Context, byteCount int) error {
+	now := time.Now()
+	if p.start.IsZero() {
+		p.start = now
+	}
+
+	due := p.start.Add(time.Duration(float64(p.Position()) / p.speed))
+	if delay := due.Sub(now); delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+
+	p.position.Add(int64(p.Duration(byteCount)))
+	return nil
+}
+
+/*
+Copy reads r a frame at a time and writes each frame to w when it is due. It returns the number of
+bytes written and, like io.Copy, a nil error once r is exhausted.
+*/
+func (p *Pacer) Copy(ctx context.Context, w io.Writer, r io.Reader) (int64, error) {
+	klog.V(6).Infof("pacing.Copy ENTER\n")
+
+	var written int64
+	buf := make([]byte, p.frameBytes)
+	for {
+		bytesRead, readErr := io.ReadFull(r, buf)
+		if bytesRead > 0 {
+			if err := p.Wait(ctx, bytesRead); err != nil {
+				klog.V(3).Infof("pacing.Copy: %v\n", err)
+				klog.V(6).Infof("pacing.Copy LEAVE\n")
+				return written, err
+			}
+
+			byteCount, err := w.Write(buf[:bytesRead])
+			written += int64(byteCount)
+			if err != nil {
+				klog.V(1).Infof("w.Write failed. Err: %v\n", err)
+				klog.V(6).Infof("pacing.Copy LEAVE\n")
+				return written, err
+			}
+			klog.V(7).Infof("pacing.Copy: %d bytes at %v\n", byteCount, p.Position())
+		}
+
+		switch readErr {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			klog.V(3).Infof("pacing.Copy EOF after %v of audio\n", p.Position())
+			klog.V(6).Infof("pacing.Copy LEAVE\n")
+			return written, nil
+		default:
+			klog.V(1).Infof("r.Read failed. Err: %v\n", readErr)
+			klog.V(6).Infof("pacing.Copy LEAVE\n")
+			return written, readErr
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package pacing
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Options describes the raw audio being paced
+type Options struct {
+	Encoding   string // linear16, linear32, mulaw or alaw
+	SampleRate int
+	Channels   int // defaults to 1
+
+	FrameMs int     // audio sent per frame, defaults to 20ms
+	Speed   float64 // multiple of real time, e.g. 2 sends 2s of audio every second. Defaults to 1.
+}
+
+// Pacer sends audio no faster than it would be captured by a live source
+type Pacer struct {
+	speed          float64
+	bytesPerSecond int
+	frameBytes     int
+
+	start    time.Time
+	position atomic.Int64 // nanoseconds of audio sent
+}
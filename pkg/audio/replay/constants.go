@@ -6,15 +6,38 @@ package replay
 
 import (
 	"errors"
+
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
 )
 
 // constants
 const (
 	defaultBytesToRead int = 2048
+
+	// opus decodes at 48kHz
+	opusSampleRate int = 48000
+	// the pion decoder outputs 20ms at a time
+	opusFrameBytes int = 1920
+)
+
+// containers
+const (
+	ContainerUnknown Container = ""
+	ContainerWAV     Container = "wav"
+	ContainerFLAC    Container = "flac"
+	ContainerMP3     Container = "mp3"
+	ContainerOgg     Container = "ogg"
 )
 
 // errors
 var (
 	// ErrInvalidInput required input was not found
 	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrUnsupportedFormat the file can't be decoded, paced or used as an audio.Source
+	ErrUnsupportedFormat = audio.ErrUnsupportedFormat
+
+	// ErrUnsupportedOpus the Opus packets aren't mono SILK wideband 20ms frames, the only kind the
+	// pure Go decoder handles. Use Options.Passthrough to send them to Deepgram instead.
+	ErrUnsupportedOpus = errors.New("only mono SILK wideband 20ms opus frames can be decoded")
 )
@@ -0,0 +1,237 @@
+// Copyright 2023-2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	mp3 "github.com/hajimehoshi/go-mp3"
+	flac "github.com/mewkiz/flac"
+	opus "github.com/pion/opus"
+	oggreader "github.com/pion/opus/pkg/oggreader"
+	wav "github.com/youpy/go-wav"
+	klog "k8s.io/klog/v2"
+
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
+)
+
+// detect finds the container from the magic bytes at the start of the file
+func detect(header []byte) Container {
+	switch audio.DetectContentType(header) {
+	case audio.ContentTypeWAV:
+		return ContainerWAV
+	case audio.ContentTypeFLAC:
+		return ContainerFLAC
+	case audio.ContentTypeOgg:
+		return ContainerOgg
+	case audio.ContentTypeMP3:
+		return ContainerMP3
+	}
+	return ContainerUnknown
+}
+
+// newDecoder creates the linear16 decoder for the container
+func newDecoder(container Container, r io.Reader) (decoder, error) {
+	switch container {
+	case ContainerFLAC:
+		return newFLACDecoder(bufio.NewReader(r))
+	case ContainerMP3:
+		return newMP3Decoder(bufio.NewReader(r))
+	case ContainerOgg:
+		return newOpusDecoder(bufio.NewReader(r))
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+func newFLACDecoder(r io.Reader) (*flacDecoder, error) {
+	stream, err := flac.New(r)
+	if err != nil {
+		klog.V(1).Infof("flac.New failed. Err: %v\n", err)
+		return nil, err
+	}
+
+	return &flacDecoder{
+		stream: stream,
+		format: pcmFormat(int(stream.Info.NChannels), int(stream.Info.SampleRate)),
+	}, nil
+}
+
+func (d *flacDecoder) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		frame, err := d.stream.ParseNext()
+		if err != nil {
+			return 0, err
+		}
+
+		// interleave the channels, scaled to 16 bits
+		shift := int(frame.BitsPerSample) - 16
+		for i := 0; i < frame.Subframes[0].NSamples; i++ {
+			for _, subframe := range frame.Subframes {
+				sample := subframe.Samples[i]
+				if shift > 0 {
+					sample >>= uint(shift)
+				} else {
+					sample <<= uint(-shift)
+				}
+				d.pending = binary.LittleEndian.AppendUint16(d.pending, uint16(int16(sample)))
+			}
+		}
+	}
+
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
+
+func (d *flacDecoder) Format() (*wav.WavFormat, error) {
+	return d.format, nil
+}
+
+func newMP3Decoder(r io.Reader) (*mp3Decoder, error) {
+	decoder, err := mp3.NewDecoder(r)
+	if err != nil {
+		klog.V(1).Infof("mp3.NewDecoder failed. Err: %v\n", err)
+		return nil, err
+	}
+
+	// go-mp3 always outputs two channels, even for mono files
+	return &mp3Decoder{
+		Decoder: decoder,
+		format:  pcmFormat(2, decoder.SampleRate()),
+	}, nil
+}
+
+func (d *mp3Decoder) Format() (*wav.WavFormat, error) {
+	return d.format, nil
+}
+
+func newOpusDecoder(r io.Reader) (*opusDecoder, error) {
+	ogg, header, err := oggreader.NewWith(r)
+	if err != nil {
+		klog.V(1).Infof("oggreader.NewWith failed. Err: %v\n", err)
+		return nil, fmt.Errorf("%w: ogg without opus: %v", ErrUnsupportedFormat, err)
+	}
+	if header.Channels != 1 {
+		klog.V(1).Infof("opus with %d channels is not supported\n", header.Channels)
+		return nil, ErrUnsupportedOpus
+	}
+
+	d := &opusDecoder{
+		ogg:     ogg,
+		opus:    opus.NewDecoder(),
+		format:  pcmFormat(1, opusSampleRate),
+		preSkip: int(header.PreSkip) * 2,
+	}
+
+	// most encoders produce CELT or hybrid packets, so fail in Start rather than partway through the stream
+	for len(d.pending) == 0 {
+		err := d.decodeNext()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			klog.V(1).Infof("decoding the first opus packet failed. Err: %v\n", err)
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+func (d *opusDecoder) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		if err := d.decodeNext(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
+
+// decodeNext decodes the next audio packet into pending
+func (d *opusDecoder) decodeNext() error {
+	packet, err := d.nextPacket()
+	if err != nil {
+		return err
+	}
+	if bytes.HasPrefix(packet, []byte("OpusTags")) {
+		return nil
+	}
+
+	out := make([]byte, opusFrameBytes)
+	bandwidth, _, err := d.opus.Decode(packet, out)
+	if err != nil {
+		klog.V(1).Infof("opus.Decode failed. Err: %v\n", err)
+		return fmt.Errorf("%w (%v). Use Options.Passthrough to send the file to Deepgram as is", ErrUnsupportedOpus, err)
+	}
+	if bandwidth != opus.BandwidthWideband {
+		klog.V(1).Infof("opus %s bandwidth is not supported\n", bandwidth)
+		return fmt.Errorf("%w (%s bandwidth). Use Options.Passthrough to send the file to Deepgram as is", ErrUnsupportedOpus, bandwidth)
+	}
+
+	// drop the encoder delay
+	if d.preSkip > 0 {
+		skip := d.preSkip
+		if skip > len(out) {
+			skip = len(out)
+		}
+		out = out[skip:]
+		d.preSkip -= skip
+	}
+	d.pending = out
+
+	return nil
+}
+
+// nextPacket joins the ogg segments of the next packet, which can continue onto the next page
+func (d *opusDecoder) nextPacket() ([]byte, error) {
+	for {
+		for len(d.segments) > 0 {
+			segment := d.segments[0]
+			d.segments = d.segments[1:]
+
+			d.packet = append(d.packet, segment...)
+			if len(segment) < 255 {
+				packet := d.packet
+				d.packet = nil
+				return packet, nil
+			}
+		}
+
+		segments, _, err := d.ogg.ParseNextPage()
+		if err != nil {
+			return nil, err
+		}
+		d.segments = segments
+	}
+}
+
+func (d *opusDecoder) Format() (*wav.WavFormat, error) {
+	return d.format, nil
+}
+
+func (d passthrough) Format() (*wav.WavFormat, error) {
+	return nil, ErrUnsupportedFormat
+}
+
+/*
+helpers
+*/
+// pcmFormat describes the linear16 audio a decoder outputs
+func pcmFormat(channels, sampleRate int) *wav.WavFormat {
+	return &wav.WavFormat{
+		AudioFormat:   wav.AudioFormatPCM,
+		NumChannels:   uint16(channels),
+		SampleRate:    uint32(sampleRate),
+		ByteRate:      uint32(sampleRate * channels * 2),
+		BlockAlign:    uint16(channels * 2),
+		BitsPerSample: 16,
+	}
+}
@@ -6,11 +6,16 @@
 package replay
 
 import (
+	"context"
 	"io"
 	"os"
+	"time"
 
 	wav "github.com/youpy/go-wav"
 	klog "k8s.io/klog/v2"
+
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
+	pacing "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/pacing"
 )
 
 // New creates an audio replay device
@@ -40,8 +45,35 @@ func New(opts Options) (*Client, error) {
 	return client, nil
 }
 
-// Start begins streaming the audio for the device
+/*
+Start begins streaming the audio for the device. The container is detected from the file's magic
+bytes: WAV, FLAC, MP3 and Ogg/Opus are decoded to linear16, unless Options.Passthrough is set.
+*/
 func (c *Client) Start() error {
+	header := make([]byte, audio.SniffLen)
+	n, err := c.file.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		klog.V(1).Infof("ReplayClient.Start ReadAt failed. Err: %v\n", err)
+		return err
+	}
+	c.container = detect(header[:n])
+	klog.V(4).Infof("ReplayClient.Start container: %q\n", c.container)
+
+	if c.options.Passthrough {
+		c.decoder = passthrough{Reader: c.file}
+		return nil
+	}
+
+	if c.container != ContainerWAV {
+		decoder, err := newDecoder(c.container, c.file)
+		if err != nil {
+			klog.V(1).Infof("ReplayClient.Start %q decoder failed. Err: %v\n", c.container, err)
+			return err
+		}
+		c.decoder = decoder
+		return nil
+	}
+
 	reader := wav.NewReader(c.file)
 	if reader == nil {
 		klog.V(1).Infof("ReplayClient.New wav.NewDecoder is nil\n")
@@ -55,9 +87,18 @@ func (c *Client) Start() error {
 	return nil
 }
 
+// Container returns the kind of file being replayed. Start must be called first.
+func (c *Client) Container() Container {
+	return c.container
+}
+
 // Read bits from the replay device
 func (c *Client) Read() ([]byte, error) {
-	buf := make([]byte, defaultBytesToRead)
+	return c.read(defaultBytesToRead)
+}
+
+func (c *Client) read(size int) ([]byte, error) {
+	buf := make([]byte, size)
 
 	byteCount, err := c.decoder.Read(buf)
 	if err == io.EOF {
@@ -69,18 +110,58 @@ func (c *Client) Read() ([]byte, error) {
 	}
 	klog.V(7).Infof("byteBuf.Read bytes copied: %d\n", byteCount)
 
-	return buf, nil
+	return buf[:byteCount], nil
+}
+
+/*
+Format returns the format of the audio being replayed, which is linear16 for decoded FLAC, MP3 and
+Ogg/Opus. It is ErrUnsupportedFormat with Options.Passthrough. Start must be called first.
+*/
+func (c *Client) Format() (*wav.WavFormat, error) {
+	if c.decoder == nil {
+		klog.V(1).Infof("ReplayClient.Format decoder is nil. Call Start() first.\n")
+		return nil, ErrInvalidInput
+	}
+	return c.decoder.Format()
 }
 
-// Stream is a helper function to stream the replay device data to a source
+/*
+Stream is a helper function to stream the replay device data to a source. With Options.RealTime, the
+audio is written a frame at a time, no faster than real time.
+*/
 func (c *Client) Stream(w io.Writer) error {
+	readSize := defaultBytesToRead
+
+	var pacer *pacing.Pacer
+	if c.options.RealTime {
+		var err error
+		pacer, err = c.newPacer()
+		if err != nil {
+			klog.V(1).Infof("replay pacing failed. Err: %v\n", err)
+			return err
+		}
+		c.pacer.Store(pacer)
+		readSize = pacer.FrameBytes()
+	}
+
+	// ends a paced wait on Stop
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	for {
 		select {
 		case <-c.stopChan:
 			klog.V(6).Infof("stopChan signal exit\n")
 			return nil
 		default:
-			byData, err := c.Read()
+			byData, err := c.read(readSize)
 			if err == io.EOF {
 				klog.V(3).Infof("decoder.Read EOF\n")
 				return nil
@@ -93,11 +174,19 @@ func (c *Client) Stream(w io.Writer) error {
 			isMuted := c.muted
 			c.mute.Unlock()
 
-			if isMuted {
+			// zeroing compressed audio would corrupt it
+			if isMuted && !c.options.Passthrough {
 				klog.V(7).Infof("Mic is MUTED!\n")
 				byData = make([]byte, len(byData))
 			}
 
+			if pacer != nil {
+				if err := pacer.Wait(ctx, len(byData)); err != nil {
+					klog.V(6).Infof("stopChan signal exit\n")
+					return nil
+				}
+			}
+
 			byteCount, err := w.Write(byData)
 			if err != nil {
 				klog.V(1).Infof("w.Write failed. Err: %v\n", err)
@@ -108,7 +197,54 @@ func (c *Client) Stream(w io.Writer) error {
 	}
 }
 
-// Mute silences the replay device
+// Position is how much audio a RealTime Stream has sent, for measuring transcription latency
+func (c *Client) Position() time.Duration {
+	if pacer := c.pacer.Load(); pacer != nil {
+		return pacer.Position()
+	}
+	return 0
+}
+
+/*
+Source returns the replay device as an audio.Source, which honors Mute. Closing it stops the device.
+Start must be called first.
+*/
+func (c *Client) Source() (audio.Source, error) {
+	format, err := c.audioFormat()
+	if err != nil {
+		return nil, err
+	}
+	return &source{c: c, format: format}, nil
+}
+
+func (s *source) Read(p []byte) (int, error) {
+	if s.c.decoder == nil {
+		return 0, io.EOF
+	}
+
+	byteCount, err := s.c.decoder.Read(p)
+
+	s.c.mute.Lock()
+	isMuted := s.c.muted
+	s.c.mute.Unlock()
+	if isMuted {
+		for i := range p[:byteCount] {
+			p[i] = 0
+		}
+	}
+
+	return byteCount, err
+}
+
+func (s *source) Format() audio.Format {
+	return s.format
+}
+
+func (s *source) Close() error {
+	return s.c.Stop()
+}
+
+// Mute silences the replay device. It has no effect with Options.Passthrough.
 func (c *Client) Mute() {
 	c.mute.Lock()
 	c.muted = true
@@ -136,3 +272,34 @@ func (c *Client) Stop() error {
 
 	return nil
 }
+
+// newPacer creates a pacer for the format of the audio
+func (c *Client) newPacer() (*pacing.Pacer, error) {
+	format, err := c.audioFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	return pacing.New(pacing.Options{
+		Encoding:   format.Encoding,
+		SampleRate: format.SampleRate,
+		Channels:   format.Channels,
+		FrameMs:    c.options.FrameMs,
+		Speed:      c.options.Speed,
+	})
+}
+
+// audioFormat converts the format of the audio
+func (c *Client) audioFormat() (audio.Format, error) {
+	wavFormat, err := c.Format()
+	if err != nil {
+		return audio.Format{}, err
+	}
+
+	format, err := audio.FormatFromWAV(wavFormat)
+	if err != nil {
+		klog.V(1).Infof("wav format %d with %d bits per sample is not supported\n", wavFormat.AudioFormat, wavFormat.BitsPerSample)
+		return audio.Format{}, err
+	}
+	return format, nil
+}
@@ -5,27 +5,95 @@
 package replay
 
 import (
+	"io"
 	"os"
 	"sync"
+	"sync/atomic"
 
+	mp3 "github.com/hajimehoshi/go-mp3"
+	flac "github.com/mewkiz/flac"
+	opus "github.com/pion/opus"
+	oggreader "github.com/pion/opus/pkg/oggreader"
 	wav "github.com/youpy/go-wav"
+
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
+	pacing "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/pacing"
 )
 
 // ReplayOpts defines options for this device
 type Options struct {
 	FullFilename string
+
+	// pacing, so the file behaves like a live source
+	RealTime bool    // send the audio no faster than real time
+	FrameMs  int     // audio per write when RealTime, defaults to 20ms
+	Speed    float64 // multiple of real time when RealTime, defaults to 1
+
+	// send the file as is, for Deepgram to decode, instead of decoding FLAC, MP3 and Ogg/Opus to
+	// linear16. The audio can't be paced, used as an audio.Source or muted.
+	//
+	// The Opus decoder only handles mono SILK wideband packets. Recordings from opusenc, browsers and
+	// WebRTC are usually CELT or hybrid fullband, which Start rejects with ErrUnsupportedOpus; replay
+	// those with Passthrough.
+	Passthrough bool
 }
 
+// Container is the kind of audio file being replayed, detected from its magic bytes
+type Container string
+
 // Client is a replay device. In this case, an audio stream.
 type Client struct {
 	options Options
 
-	// wav
-	file    *os.File
-	decoder *wav.Reader
+	// decoding
+	file      *os.File
+	container Container
+	decoder   decoder
 
 	// operational stuff
 	stopChan chan struct{}
 	mute     sync.Mutex
 	muted    bool
+	pacer    atomic.Pointer[pacing.Pacer]
+}
+
+// source is the replay device as an audio.Source
+type source struct {
+	c      *Client
+	format audio.Format
+}
+
+// decoder reads PCM from a container. *wav.Reader is one.
+type decoder interface {
+	io.Reader
+	Format() (*wav.WavFormat, error)
+}
+
+// passthrough reads the file as is
+type passthrough struct {
+	io.Reader
+}
+
+// flacDecoder reads FLAC as linear16
+type flacDecoder struct {
+	stream  *flac.Stream
+	format  *wav.WavFormat
+	pending []byte
+}
+
+// mp3Decoder reads MP3 as stereo linear16
+type mp3Decoder struct {
+	*mp3.Decoder
+	format *wav.WavFormat
+}
+
+// opusDecoder reads Ogg/Opus as 48kHz mono linear16
+type opusDecoder struct {
+	ogg      *oggreader.OggReader
+	opus     opus.Decoder
+	format   *wav.WavFormat
+	segments [][]byte // of the current page
+	packet   []byte   // a packet split across segments or pages
+	pending  []byte
+	preSkip  int // bytes of encoder delay still to drop
 }
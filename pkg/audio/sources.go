@@ -0,0 +1,250 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	wav "github.com/youpy/go-wav"
+	klog "k8s.io/klog/v2"
+)
+
+// NewReader creates a Source from raw audio in format. Close closes r if it is an io.Closer.
+func NewReader(r io.Reader, format Format) (Source, error) {
+	if r == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := format.Validate(); err != nil {
+		klog.V(1).Infof("audio.NewReader: invalid format %+v. Err: %v\n", format, err)
+		return nil, err
+	}
+
+	closer, _ := r.(io.Closer)
+	return &readerSource{Reader: r, format: format, closer: closer}, nil
+}
+
+func (s *readerSource) Format() Format {
+	return s.format
+}
+
+func (s *readerSource) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// NewFile creates a Source from a wav file, reading the format from its header
+func NewFile(filename string) (Source, error) {
+	klog.V(6).Infof("audio.NewFile ENTER\n")
+
+	f, err := os.Open(filename)
+	if err != nil {
+		klog.V(1).Infof("os.Open failed. Err: %v\n", err)
+		klog.V(6).Infof("audio.NewFile LEAVE\n")
+		return nil, err
+	}
+
+	reader := wav.NewReader(f)
+	wavFormat, err := reader.Format()
+	if err != nil {
+		f.Close()
+		klog.V(1).Infof("reader.Format failed. Err: %v\n", err)
+		klog.V(6).Infof("audio.NewFile LEAVE\n")
+		return nil, err
+	}
+	format, err := FormatFromWAV(wavFormat)
+	if err != nil {
+		f.Close()
+		klog.V(1).Infof("FormatFromWAV failed. Err: %v\n", err)
+		klog.V(6).Infof("audio.NewFile LEAVE\n")
+		return nil, err
+	}
+
+	klog.V(4).Infof("audio.NewFile: %s is %+v\n", filename, format)
+	klog.V(6).Infof("audio.NewFile LEAVE\n")
+
+	return &fileSource{Reader: reader, format: format, file: f}, nil
+}
+
+func (s *fileSource) Format() Format {
+	return s.format
+}
+
+func (s *fileSource) Close() error {
+	return s.file.Close()
+}
+
+/*
+NewTone creates a Source of a sine tone at frequency Hz, with volume from 0.0 to 1.0 (defaults to 0.5).
+Every channel carries the tone. A duration of 0 never ends. Only linear16 is supported.
+*/
+func NewTone(format Format, frequency, volume float64, duration time.Duration) (Source, error) {
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+	if format.Encoding != EncodingLinear16 {
+		klog.V(1).Infof("audio.NewTone: %s is not supported\n", format.Encoding)
+		return nil, ErrUnsupportedFormat
+	}
+	if frequency <= 0 {
+		return nil, ErrInvalidInput
+	}
+	if volume <= 0 || volume > 1 {
+		volume = defaultToneVolume
+	}
+
+	return &toneSource{
+		format:    format,
+		frequency: frequency,
+		volume:    volume,
+		remaining: remaining(format, duration),
+	}, nil
+}
+
+func (s *toneSource) Read(p []byte) (int, error) {
+	if s.remaining == 0 {
+		return 0, io.EOF
+	}
+	p = limit(p, s.remaining)
+
+	blockAlign := 2 * s.format.channels()
+	n := copy(p, s.partial)
+	s.partial = s.partial[n:]
+	for n < len(p) {
+		frame := make([]byte, blockAlign)
+		v := s.volume * math.Sin(2*math.Pi*s.frequency*float64(s.sample)/float64(s.format.SampleRate))
+		for ch := 0; ch < s.format.channels(); ch++ {
+			binary.LittleEndian.PutUint16(frame[ch*2:], uint16(int16(v*math.MaxInt16)))
+		}
+		s.sample++
+
+		copied := copy(p[n:], frame)
+		s.partial = frame[copied:]
+		n += copied
+	}
+
+	if s.remaining > 0 {
+		s.remaining -= int64(n)
+	}
+	return n, nil
+}
+
+func (s *toneSource) Format() Format {
+	return s.format
+}
+
+func (s *toneSource) Close() error {
+	return nil
+}
+
+// NewSilence creates a Source of silence. A duration of 0 never ends.
+func NewSilence(format Format, duration time.Duration) (Source, error) {
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &silenceSource{format: format, remaining: remaining(format, duration)}
+	switch format.Encoding {
+	case EncodingMulaw:
+		s.value = mulawSilence
+	case EncodingAlaw:
+		s.value = alawSilence
+	}
+	return s, nil
+}
+
+func (s *silenceSource) Read(p []byte) (int, error) {
+	if s.remaining == 0 {
+		return 0, io.EOF
+	}
+	p = limit(p, s.remaining)
+
+	for i := range p {
+		p[i] = s.value
+	}
+	if s.remaining > 0 {
+		s.remaining -= int64(len(p))
+	}
+	return len(p), nil
+}
+
+func (s *silenceSource) Format() Format {
+	return s.format
+}
+
+func (s *silenceSource) Close() error {
+	return nil
+}
+
+// Concat creates a Source which plays each of sources in turn. They must all have the same format.
+func Concat(sources ...Source) (Source, error) {
+	if len(sources) == 0 {
+		return nil, ErrInvalidInput
+	}
+
+	format := sources[0].Format()
+	for _, src := range sources[1:] {
+		if !src.Format().Equal(format) {
+			klog.V(1).Infof("audio.Concat: %+v does not match %+v\n", src.Format(), format)
+			return nil, ErrFormatMismatch
+		}
+	}
+
+	return &concatSource{format: format, sources: sources}, nil
+}
+
+func (s *concatSource) Read(p []byte) (int, error) {
+	for s.current < len(s.sources) {
+		n, err := s.sources[s.current].Read(p)
+		if err == io.EOF {
+			s.current++
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+	return 0, io.EOF
+}
+
+func (s *concatSource) Format() Format {
+	return s.format
+}
+
+// Close closes every source, returning the first error
+func (s *concatSource) Close() error {
+	var first error
+	for _, src := range s.sources {
+		if err := src.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+/*
+helpers
+*/
+// remaining is the size of duration of audio, or -1 for endless
+func remaining(format Format, duration time.Duration) int64 {
+	if duration <= 0 {
+		return -1
+	}
+	return format.bytes(duration)
+}
+
+// limit shortens p to the bytes remaining
+func limit(p []byte, remaining int64) []byte {
+	if remaining >= 0 && int64(len(p)) > remaining {
+		return p[:remaining]
+	}
+	return p
+}
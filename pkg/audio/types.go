@@ -0,0 +1,72 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package audio
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+// Format describes raw audio
+type Format struct {
+	Encoding   string // EncodingLinear16, EncodingLinear32, EncodingMulaw or EncodingAlaw
+	SampleRate int
+	Channels   int
+}
+
+/*
+Source is a stream of raw audio which reports its format, so a client can be configured to match.
+Read returns io.EOF once a finite source is exhausted.
+*/
+type Source interface {
+	io.Reader
+	Format() Format
+	Close() error
+}
+
+// readerSource is a Source over an io.Reader
+type readerSource struct {
+	io.Reader
+	format Format
+	closer io.Closer
+}
+
+// fileSource is the audio data in a wav file
+type fileSource struct {
+	io.Reader
+	format Format
+	file   *os.File
+}
+
+// toneSource generates a sine tone
+type toneSource struct {
+	format    Format
+	frequency float64
+	volume    float64
+	remaining int64 // bytes left, -1 if endless
+	sample    int64
+	partial   []byte
+}
+
+// silenceSource generates silence
+type silenceSource struct {
+	format    Format
+	value     byte
+	remaining int64 // bytes left, -1 if endless
+}
+
+// concatSource reads each of its sources in turn
+type concatSource struct {
+	format  Format
+	sources []Source
+	current int
+}
+
+// contextReader ends a read once its context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package vad
+
+import (
+	"errors"
+	"time"
+)
+
+// constants
+const (
+	defaultChannels          int           = 1
+	defaultFrameMs           int           = 20
+	defaultEnergyThreshold   float64       = 0.01
+	defaultZCRThreshold      float64       = 0.3
+	defaultHangover          time.Duration = 300 * time.Millisecond
+	defaultPreRoll           time.Duration = 300 * time.Millisecond
+	defaultKeepAliveInterval time.Duration = 5 * time.Second
+	defaultFinalizeAfter     time.Duration = 2 * time.Second
+
+	// quiet frames still count as unvoiced speech, e.g. "s" or "f", above this fraction of EnergyThreshold
+	unvoicedEnergyRatio float64 = 0.25
+
+	bytesPerSample int = 2
+)
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+)
@@ -0,0 +1,88 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package vad
+
+import (
+	"sync"
+	"time"
+)
+
+// Target receives the gated audio, e.g. a listen websocket client
+type Target interface {
+	Write(p []byte) (int, error)
+	KeepAlive() error
+	Finalize() error
+}
+
+/*
+Options for gating 16-bit little-endian linear PCM (linear16).
+
+Durations are measured in audio time, which for a live source is the same as wall clock time.
+*/
+type Options struct {
+	SampleRate int
+	Channels   int // defaults to 1
+	FrameMs    int // analysis frame, defaults to 20ms
+
+	// a frame is speech when its RMS level, as a fraction of full scale, reaches EnergyThreshold.
+	// Quieter frames with a zero crossing rate (crossings per sample) of ZCRThreshold or more also
+	// count, which keeps unvoiced sounds like "s" from being cut.
+	EnergyThreshold float64 // defaults to 0.01, about -40dBFS
+	ZCRThreshold    float64 // defaults to 0.3
+
+	Hangover time.Duration // audio still sent after speech ends, defaults to 300ms
+	PreRoll  time.Duration // audio from before speech starts which is sent with it, defaults to 300ms
+
+	KeepAliveInterval time.Duration // how often to send KeepAlive during silence, defaults to 5s
+	FinalizeAfter     time.Duration // send Finalize once a pause lasts this long, defaults to 2s. Negative disables.
+}
+
+// Offset maps the start of a run of sent audio back to the original audio
+type Offset struct {
+	Sent     time.Duration // position in the audio the server received
+	Original time.Duration // position in the audio written to the Gate
+}
+
+// Stats counts what the Gate has done
+type Stats struct {
+	Sent       time.Duration // audio sent to the target
+	Skipped    time.Duration // silence which was dropped, not counting the pre-roll still held
+	KeepAlives int
+	Finalizes  int
+}
+
+// Gate is an io.Writer which only passes speech on to its Target
+type Gate struct {
+	target Target
+	opts   Options
+
+	frameBytes    int
+	bytesPerSec   int64
+	hangover      int64 // the durations below, in bytes
+	preRollFrames int
+	keepAlive     int64
+	finalizeAfter int64
+
+	remainder []byte
+	preRoll   []frame
+
+	original   int64 // bytes of audio written to the Gate
+	sent       int64 // bytes of audio sent to the target
+	sentEnd    int64 // original position at the end of the last frame sent
+	lastSpeech int64 // original position at the end of the last speech frame
+	lastActive int64 // original position when the target was last sent anything
+	sending    bool
+	spoken     bool // speech was sent since the last Finalize
+
+	mu      sync.Mutex // offsets and stats are read from other goroutines
+	offsets []Offset
+	stats   Stats
+}
+
+// frame is a buffered frame and where it started in the original audio
+type frame struct {
+	data []byte
+	at   int64
+}
@@ -0,0 +1,288 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+// Implementation of a voice activity detector which only streams speech, keeping the connection alive in between
+package vad
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	klog "k8s.io/klog/v2"
+)
+
+// NewGate creates a Gate which writes the speech in the audio written to it to target
+func NewGate(target Target, opts Options) (*Gate, error) {
+	klog.V(6).Infof("vad.NewGate ENTER\n")
+
+	if target == nil || opts.SampleRate <= 0 {
+		klog.V(1).Infof("vad.NewGate: a target and sample rate are required\n")
+		klog.V(6).Infof("vad.NewGate LEAVE\n")
+		return nil, ErrInvalidInput
+	}
+	if opts.Channels <= 0 {
+		opts.Channels = defaultChannels
+	}
+	if opts.FrameMs <= 0 {
+		opts.FrameMs = defaultFrameMs
+	}
+	if opts.EnergyThreshold <= 0 {
+		opts.EnergyThreshold = defaultEnergyThreshold
+	}
+	if opts.ZCRThreshold <= 0 {
+		opts.ZCRThreshold = defaultZCRThreshold
+	}
+	if opts.Hangover <= 0 {
+		opts.Hangover = defaultHangover
+	}
+	if opts.PreRoll <= 0 {
+		opts.PreRoll = defaultPreRoll
+	}
+	if opts.KeepAliveInterval <= 0 {
+		opts.KeepAliveInterval = defaultKeepAliveInterval
+	}
+	if opts.FinalizeAfter == 0 {
+		opts.FinalizeAfter = defaultFinalizeAfter
+	}
+
+	blockAlign := bytesPerSample * opts.Channels
+	g := &Gate{
+		target:      target,
+		opts:        opts,
+		bytesPerSec: int64(blockAlign * opts.SampleRate),
+	}
+	g.frameBytes = int(g.bytesPerSec) * opts.FrameMs / 1000
+	g.frameBytes -= g.frameBytes % blockAlign
+	if g.frameBytes == 0 {
+		g.frameBytes = blockAlign
+	}
+	g.hangover = g.toBytes(opts.Hangover)
+	g.preRollFrames = int(g.toBytes(opts.PreRoll)) / g.frameBytes
+	g.keepAlive = g.toBytes(opts.KeepAliveInterval)
+	g.finalizeAfter = g.toBytes(opts.FinalizeAfter)
+
+	klog.V(4).Infof("vad.NewGate: %d byte frames, %d frames of pre-roll\n", g.frameBytes, g.preRollFrames)
+	klog.V(6).Infof("vad.NewGate LEAVE\n")
+
+	return g, nil
+}
+
+/*
+Write analyzes the audio a frame at a time and passes speech, plus the pre-roll and hangover around
+it, on to the target. This implements the io.Writer interface so it can be handed to Stream().
+*/
+func (g *Gate) Write(p []byte) (int, error) {
+	data := p
+	if len(g.remainder) > 0 {
+		data = append(g.remainder, p...)
+		g.remainder = nil
+	}
+
+	for len(data) >= g.frameBytes {
+		if err := g.process(data[:g.frameBytes]); err != nil {
+			klog.V(1).Infof("vad.Write failed. Err: %v\n", err)
+			return 0, err
+		}
+		data = data[g.frameBytes:]
+	}
+	if len(data) > 0 {
+		g.remainder = append([]byte{}, data...)
+	}
+
+	return len(p), nil
+}
+
+/*
+OriginalTime converts a position in the audio the server received, such as the start of a word, to
+the position in the audio written to the Gate. It is safe to call from any goroutine.
+*/
+func (g *Gate) OriginalTime(sent time.Duration) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for i := len(g.offsets) - 1; i >= 0; i-- {
+		if g.offsets[i].Sent <= sent {
+			return g.offsets[i].Original + sent - g.offsets[i].Sent
+		}
+	}
+	return sent
+}
+
+// OriginalSeconds is OriginalTime for the seconds used by transcript start times and durations
+func (g *Gate) OriginalSeconds(sent float64) float64 {
+	return g.OriginalTime(time.Duration(sent * float64(time.Second))).Seconds()
+}
+
+// Offsets returns the start of every run of sent audio
+func (g *Gate) Offsets() []Offset {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	offsets := make([]Offset, len(g.offsets))
+	copy(offsets, g.offsets)
+	return offsets
+}
+
+// Stats returns what the Gate has sent and skipped so far
+func (g *Gate) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.stats
+}
+
+// process gates a single frame
+func (g *Gate) process(data []byte) error {
+	at := g.original
+	g.original += int64(len(data))
+
+	speech := g.isSpeech(data)
+	switch {
+	case speech:
+		g.lastSpeech = g.original
+		if !g.sending {
+			klog.V(4).Infof("vad: speech at %v\n", g.toDuration(at))
+			g.sending = true
+			if err := g.sendPreRoll(at); err != nil {
+				return err
+			}
+		}
+		g.spoken = true
+		return g.send(data, at)
+	case g.sending && g.original-g.lastSpeech <= g.hangover:
+		return g.send(data, at)
+	}
+
+	if g.sending {
+		klog.V(4).Infof("vad: silence at %v\n", g.toDuration(at))
+		g.sending = false
+	}
+	g.bufferPreRoll(data, at)
+
+	if g.spoken && g.finalizeAfter > 0 && g.original-g.lastSpeech >= g.finalizeAfter {
+		klog.V(4).Infof("vad: Finalize after %v of silence\n", g.toDuration(g.original-g.lastSpeech))
+		g.spoken = false
+		g.lastActive = g.original
+		g.count(func(stats *Stats) { stats.Finalizes++ })
+		return g.target.Finalize()
+	}
+	if g.original-g.lastActive >= g.keepAlive {
+		klog.V(5).Infof("vad: KeepAlive\n")
+		g.lastActive = g.original
+		g.count(func(stats *Stats) { stats.KeepAlives++ })
+		return g.target.KeepAlive()
+	}
+
+	return nil
+}
+
+// sendPreRoll sends the frames buffered before speech started at
+func (g *Gate) sendPreRoll(at int64) error {
+	start := at
+	if len(g.preRoll) > 0 {
+		start = g.preRoll[0].at
+	}
+	g.addOffset(start)
+
+	for _, f := range g.preRoll {
+		if err := g.send(f.data, f.at); err != nil {
+			return err
+		}
+	}
+	g.preRoll = g.preRoll[:0]
+	return nil
+}
+
+func (g *Gate) send(data []byte, at int64) error {
+	if _, err := g.target.Write(data); err != nil {
+		return err
+	}
+
+	g.sent += int64(len(data))
+	g.sentEnd = at + int64(len(data))
+	g.lastActive = g.original
+	g.count(func(stats *Stats) { stats.Sent += g.toDuration(int64(len(data))) })
+	return nil
+}
+
+// bufferPreRoll holds a silent frame in case speech follows, dropping the oldest
+func (g *Gate) bufferPreRoll(data []byte, at int64) {
+	if g.preRollFrames == 0 {
+		g.count(func(stats *Stats) { stats.Skipped += g.toDuration(int64(len(data))) })
+		return
+	}
+	if len(g.preRoll) == g.preRollFrames {
+		dropped := int64(len(g.preRoll[0].data))
+		g.count(func(stats *Stats) { stats.Skipped += g.toDuration(dropped) })
+		copy(g.preRoll, g.preRoll[1:])
+		g.preRoll = g.preRoll[:len(g.preRoll)-1]
+	}
+	g.preRoll = append(g.preRoll, frame{data: append([]byte{}, data...), at: at})
+}
+
+// addOffset starts a new run of sent audio, unless it carries on from the last one
+func (g *Gate) addOffset(start int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.offsets) > 0 && start == g.sentEnd {
+		return
+	}
+	g.offsets = append(g.offsets, Offset{Sent: g.toDuration(g.sent), Original: g.toDuration(start)})
+}
+
+// isSpeech classifies a frame by its energy and zero crossing rate, mixing the channels down
+func (g *Gate) isSpeech(data []byte) bool {
+	channels := g.opts.Channels
+	samples := len(data) / (bytesPerSample * channels)
+	if samples == 0 {
+		return false
+	}
+
+	var sumSquares float64
+	var crossings int
+	var prev float64
+	for i := 0; i < samples; i++ {
+		var v float64
+		for ch := 0; ch < channels; ch++ {
+			offset := (i*channels + ch) * bytesPerSample
+			v += float64(int16(binary.LittleEndian.Uint16(data[offset:])))
+		}
+		v /= float64(channels) * math.MaxInt16
+
+		sumSquares += v * v
+		if i > 0 && (v >= 0) != (prev >= 0) {
+			crossings++
+		}
+		prev = v
+	}
+
+	rms := math.Sqrt(sumSquares / float64(samples))
+	zcr := float64(crossings) / float64(samples)
+	klog.V(7).Infof("vad: rms %.4f zcr %.3f\n", rms, zcr)
+
+	if rms >= g.opts.EnergyThreshold {
+		return true
+	}
+	return rms >= g.opts.EnergyThreshold*unvoicedEnergyRatio && zcr >= g.opts.ZCRThreshold
+}
+
+/*
+helpers
+*/
+func (g *Gate) count(update func(stats *Stats)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	update(&g.stats)
+}
+
+func (g *Gate) toBytes(d time.Duration) int64 {
+	return int64(d) * g.bytesPerSec / int64(time.Second)
+}
+
+func (g *Gate) toDuration(byteCount int64) time.Duration {
+	return time.Duration(byteCount * int64(time.Second) / g.bytesPerSec)
+}
@@ -17,11 +17,19 @@ import (
 	"github.com/dvonthenen/websocket"
 	klog "k8s.io/klog/v2"
 
+	websocketv1api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/agent/v1/websocket"
 	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/agent/v1/websocket/interfaces"
 	version "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/version"
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
+	pacing "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/pacing"
 	common "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1"
+	delivery "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1/delivery"
+	interfacesv1 "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces/v1"
 )
 
+// isAgentAudioDone reports whether a message is AgentAudioDone
+var isAgentAudioDone = common.MatchType(msginterfaces.TypeAgentAudioDoneResponse)
+
 func deleteEmptyProvider(m map[string]interface{}, key string) {
 	if sub, ok := m[key].(map[string]interface{}); ok {
 		if provider, ok := sub["provider"].(map[string]interface{}); ok && len(provider) == 0 {
@@ -47,6 +55,21 @@ func (c *WSChannel) ConnectWithCancel(ctx context.Context, ctxCancel context.Can
 	return c.WSClient.ConnectWithCancel(ctx, ctxCancel, retryCnt)
 }
 
+// ConnectContext performs a websocket connection with "DefaultConnectRetry" number of retries and
+// returns why the connection failed, see common.HandshakeError and common.ConnectError
+func (c *WSChannel) ConnectContext(ctx context.Context) error {
+	c.ctx, c.ctxCancel = context.WithCancel(ctx)
+	return c.ConnectContextWithCancel(c.ctx, c.ctxCancel, int(DefaultConnectRetry))
+}
+
+// ConnectContextWithCancel performs a websocket connection with specified number of retries and providing a
+// cancel function to stop the connection. It returns why the connection failed.
+func (c *WSChannel) ConnectContextWithCancel(ctx context.Context, ctxCancel context.CancelFunc, retryCnt int) error {
+	c.ctx = ctx
+	c.ctxCancel = ctxCancel
+	return c.WSClient.ConnectContextWithCancel(ctx, ctxCancel, retryCnt)
+}
+
 // AttemptReconnect performs a reconnect after failing retries
 func (c *WSChannel) AttemptReconnect(ctx context.Context, retries int64) bool {
 	c.ctx, c.ctxCancel = context.WithCancel(ctx)
@@ -129,12 +152,18 @@ func (c *WSChannel) ProcessMessage(wsType int, byMsg []byte) error {
 
 	switch wsType {
 	case websocket.TextMessage:
+		if isAgentAudioDone(byMsg) {
+			c.audioPending.Store(false)
+		}
+
 		// route the message
 		err := (*c.router).Message(byMsg)
 		if err != nil {
 			klog.V(1).Infof("agent.listen(): router.Message failed. Err: %v\n", err)
 		}
 	case websocket.BinaryMessage:
+		c.audioPending.Store(true)
+
 		// audio data!
 		err := (*c.router).Binary(byMsg)
 		if err != nil {
@@ -154,6 +183,11 @@ func (c *WSChannel) ProcessMessage(wsType int, byMsg []byte) error {
 func (c *WSChannel) Stream(r io.Reader) error {
 	klog.V(6).Infof("agent.Stream() ENTER\n")
 
+	if c.cOptions.StreamPacing != nil {
+		klog.V(6).Infof("agent.Stream() LEAVE\n")
+		return c.streamPaced(r)
+	}
+
 	chunk := make([]byte, ChunkSize)
 
 	for {
@@ -206,6 +240,105 @@ func (c *WSChannel) Stream(r io.Reader) error {
 	}
 }
 
+/*
+StreamSource streams src until it ends or ctx is done. When the client isn't connected yet, the input
+encoding and sample rate are set from src.Format() before connecting. Once connected the settings
+can't change, so src must match them. The agent only takes mono input. src is not closed.
+*/
+func (c *WSChannel) StreamSource(ctx context.Context, src audio.Source) error {
+	klog.V(6).Infof("agent.StreamSource() ENTER\n")
+
+	format := src.Format()
+	err := format.Validate()
+	if err == nil && format.Channels > 1 {
+		err = audio.ErrUnsupportedFormat
+	}
+	if err != nil {
+		klog.V(1).Infof("StreamSource: invalid format %+v. Err: %v\n", format, err)
+		klog.V(6).Infof("agent.StreamSource() LEAVE\n")
+		return err
+	}
+
+	switch c.State() {
+	case common.StateIdle, common.StateClosed:
+		c.tOptions.Audio.Input = &interfacesv1.Input{
+			Encoding:   format.Encoding,
+			SampleRate: format.SampleRate,
+		}
+		if err := c.ConnectContext(c.ctx); err != nil {
+			klog.V(1).Infof("StreamSource: connect failed. Err: %v\n", err)
+			klog.V(6).Infof("agent.StreamSource() LEAVE\n")
+			return err
+		}
+	default:
+		var connected audio.Format
+		if input := c.tOptions.Audio.Input; input != nil {
+			connected = audio.Format{Encoding: input.Encoding, SampleRate: input.SampleRate}
+		}
+		if !format.Equal(connected) {
+			klog.V(1).Infof("StreamSource: source %+v, connected with %+v\n", format, connected)
+			klog.V(6).Infof("agent.StreamSource() LEAVE\n")
+			return audio.ErrFormatMismatch
+		}
+	}
+
+	err = c.Stream(audio.ContextReader(ctx, src))
+	if err == io.EOF {
+		err = nil
+	}
+
+	klog.V(6).Infof("agent.StreamSource() LEAVE\n")
+	return err
+}
+
+// streamPaced streams r at real time speed, see ClientOptions.StreamPacing
+func (c *WSChannel) streamPaced(r io.Reader) error {
+	klog.V(6).Infof("agent.streamPaced() ENTER\n")
+
+	opts := pacing.Options{
+		FrameMs: c.cOptions.StreamPacing.FrameMs,
+		Speed:   c.cOptions.StreamPacing.Speed,
+	}
+	if input := c.tOptions.Audio.Input; input != nil {
+		opts.Encoding = input.Encoding
+		opts.SampleRate = input.SampleRate
+	}
+	pacer, err := pacing.New(opts)
+	if err != nil {
+		klog.V(1).Infof("pacing.New failed. Err: %v\n", err)
+		klog.V(6).Infof("agent.streamPaced() LEAVE\n")
+		return err
+	}
+	c.pacer.Store(pacer)
+
+	_, err = pacer.Copy(c.ctx, c, r)
+	switch {
+	case c.ctx.Err() != nil:
+		klog.V(2).Infof("stream object Done()\n")
+		err = nil
+	case err == nil:
+		// the same as Stream reaching the end of r
+		klog.V(3).Infof("stream object EOF\n")
+		err = io.EOF
+	default:
+		klog.V(1).Infof("pacer.Copy failed. Err: %v\n", err)
+	}
+
+	klog.V(6).Infof("agent.streamPaced() LEAVE\n")
+	return err
+}
+
+/*
+AudioPosition is how much audio a paced Stream has sent, for measuring latency.
+It is zero without ClientOptions.StreamPacing.
+*/
+func (c *WSChannel) AudioPosition() time.Duration {
+	if pacer := c.pacer.Load(); pacer != nil {
+		return pacer.Position()
+	}
+	return 0
+}
+
 /*
 Write performs the lower level websocket write operation.
 This is needed to implement the io.Writer interface. (aka the streaming interface)
@@ -249,6 +382,67 @@ func (c *WSChannel) KeepAlive() error {
 	return err
 }
 
+/*
+Subscribe adds a subscriber which receives the events matching filter, for example only ConversationText.
+Subscribers can be added and removed at any time, including while connected. Call cancel when done,
+which closes the channel. The channel is also closed when the client context is done.
+*/
+func (c *WSChannel) Subscribe(filter *msginterfaces.EventFilter) (<-chan msginterfaces.Event, func()) {
+	if c.router != nil {
+		if router, ok := (*c.router).(*websocketv1api.ChanRouter); ok {
+			return router.Subscribe(filter)
+		}
+	}
+
+	klog.V(1).Infof("Subscribe is not supported by this router\n")
+	ch := make(chan msginterfaces.Event)
+	close(ch)
+	return ch, func() {}
+}
+
+// DeliveryStats returns the delivery counters for each channel subscriber, see ClientOptions.ChanDelivery
+func (c *WSChannel) DeliveryStats() map[string]delivery.Stats {
+	if c.router == nil {
+		return nil
+	}
+	if router, ok := (*c.router).(*websocketv1api.ChanRouter); ok {
+		return router.DeliveryStats()
+	}
+	return nil
+}
+
+/*
+FinishContext gracefully finishes the conversation. If the agent is still sending audio, it waits for
+AgentAudioDone so the reply is not cut off, or for ctx to end. The client is stopped either way.
+The response is nil if no audio was pending.
+*/
+func (c *WSChannel) FinishContext(ctx context.Context) (*msginterfaces.AgentAudioDoneResponse, error) {
+	klog.V(6).Infof("agent.FinishContext() ENTER\n")
+
+	byMsg, err := c.Drain(ctx, common.DrainOptions{
+		Match:   isAgentAudioDone,
+		Pending: c.audioPending.Load,
+	})
+	if byMsg == nil {
+		if err != nil {
+			klog.V(1).Infof("FinishContext failed. Err: %v\n", err)
+		}
+		klog.V(6).Infof("agent.FinishContext() LEAVE\n")
+		return nil, err
+	}
+
+	var done msginterfaces.AgentAudioDoneResponse
+	if jsonErr := json.Unmarshal(byMsg, &done); jsonErr != nil {
+		klog.V(1).Infof("json.Unmarshal(AgentAudioDoneResponse) failed. Err: %v\n", jsonErr)
+		klog.V(6).Infof("agent.FinishContext() LEAVE\n")
+		return nil, jsonErr
+	}
+
+	klog.V(3).Infof("FinishContext Succeeded\n")
+	klog.V(6).Infof("agent.FinishContext() LEAVE\n")
+	return &done, err
+}
+
 // GetCloseMsg sends an application level message to Deepgram
 func (c *WSChannel) GetCloseMsg() []byte {
 	close := msginterfaces.Close{
@@ -87,7 +87,7 @@ func NewUsingChanWithCancel(ctx context.Context, ctxCancel context.CancelFunc, a
 
 	// init
 	var router commoninterfaces.Router
-	router = websocketv1api.NewChanRouter(chans)
+	router = websocketv1api.NewChanRouterWithOptions(ctx, chans, cOptions.ChanDelivery)
 
 	conn := WSChannel{
 		cOptions:  cOptions,
@@ -6,8 +6,10 @@ package websocketv1
 
 import (
 	"context"
+	"sync/atomic"
 
 	msginterface "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/agent/v1/websocket/interfaces"
+	pacing "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/pacing"
 	common "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1"
 	commoninterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1/interfaces"
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
@@ -33,4 +35,10 @@ type WSChannel struct {
 
 	chans  []*msginterface.AgentMessageChan
 	router *commoninterfaces.Router
+
+	// agent audio has been received without an AgentAudioDone yet
+	audioPending atomic.Bool
+
+	// audio position of a paced Stream
+	pacer atomic.Pointer[pacing.Pacer]
 }
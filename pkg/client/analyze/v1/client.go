@@ -133,7 +133,13 @@ func (c *Client) DoStream(ctx context.Context, src io.Reader, options *interface
 }
 
 func (c *Client) DoText(ctx context.Context, text string, options *interfaces.AnalyzeOptions, resBody interface{}) error {
-	klog.V(6).Infof("analyze.DoText() LEAVE\n")
+	klog.V(6).Infof("analyze.DoText() ENTER\n")
+
+	if err := options.Check(); err != nil {
+		klog.V(1).Infof("AnalyzeOptions.Check() failed. Err: %v\n", err)
+		klog.V(6).Infof("analyze.DoText() LEAVE\n")
+		return err
+	}
 
 	uri, err := version.GetAnalyzeAPI(ctx, c.Options.Host, c.Options.APIVersion, c.Options.Path, options)
 	if err != nil {
@@ -205,6 +211,12 @@ func (c *Client) DoURL(ctx context.Context, uri string, options *interfaces.Anal
 		return ErrInvalidInput
 	}
 
+	if err := options.Check(); err != nil {
+		klog.V(1).Infof("AnalyzeOptions.Check() failed. Err: %v\n", err)
+		klog.V(6).Infof("analyze.DoURL() LEAVE\n")
+		return err
+	}
+
 	uri, err := version.GetAnalyzeAPI(ctx, c.Options.Host, c.Options.APIVersion, c.Options.Path, options)
 	if err != nil {
 		klog.V(1).Infof("GetAnalyzeAPI failed. Err: %v\n", err)
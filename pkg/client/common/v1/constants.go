@@ -36,9 +36,41 @@ var (
 
 	// ErrFatalPanicRecovered fatal panic recovered
 	ErrFatalPanicRecovered = errors.New("fatal panic - attempt to recover")
+
+	// ErrConnectionTerminated the connection was stopped and will not reconnect on its own
+	ErrConnectionTerminated = errors.New("connection has been terminated")
+
+	// ErrDrainIncomplete the connection closed before the final message arrived
+	ErrDrainIncomplete = errors.New("connection closed before the final message was received")
+
+	// ErrWriteQueueFull TryWrite found the write queue full
+	ErrWriteQueueFull = errors.New("write queue is full")
+
+	// ErrHandshakeFailed the server rejected the websocket upgrade, see HandshakeError
+	ErrHandshakeFailed = errors.New("websocket handshake failed")
+)
+
+// connection states
+const (
+	StateIdle ConnectionState = iota
+	StateConnecting
+	StateOpen
+	StateReconnecting
+	StateClosing
+	StateClosed
 )
 
 // internal constants for retry, waits, back-off, etc.
 const (
 	defaultDelayBetweenRetry int64 = 2
+
+	// how much of a failed handshake response body to keep
+	maxHandshakeBody int64 = 4096
+
+	// async writer queue sizes
+	defaultWriteQueueSize   int = 100
+	defaultControlQueueSize int = 16
+
+	// how long closing waits for the async writer to send the queued audio
+	defaultFlushTimeout = 5 * time.Second
 )
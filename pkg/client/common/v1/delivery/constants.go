@@ -0,0 +1,27 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package delivery
+
+import (
+	"time"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+const (
+	defaultSlowConsumerThreshold = time.Second
+	defaultQueueWarning          = 1000
+)
+
+// message types for the channels which do not carry a JSON message
+const (
+	MessageTypeBinary    string = "Binary"
+	MessageTypeUnhandled string = "Unhandled"
+
+	// MessageTypeSubscriber is used to look up the policy for Hub subscribers
+	MessageTypeSubscriber string = "Subscriber"
+)
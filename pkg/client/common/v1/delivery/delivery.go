@@ -0,0 +1,334 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package implements the backpressure policies used by the websocket ChanRouters.
+
+The routers deliver messages from the websocket read goroutine, so a subscriber which stops reading
+its channel stalls the whole connection unless a non-blocking DeliveryPolicy is used.
+
+Hub builds on the same policies for subscribers which attach and detach while the connection is open.
+*/
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces/v1"
+)
+
+// New creates a Dispatcher. Sends give up when ctx is done. A nil opts blocks like a plain channel send.
+func New(ctx context.Context, opts *interfaces.ChanDeliveryOptions) *Dispatcher {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	d := &Dispatcher{
+		ctx:    ctx,
+		sinks:  make(map[interface{}]subscriber),
+		counts: make(map[string]int),
+	}
+	if opts != nil {
+		d.opts = *opts
+	}
+	if d.opts.Policy == "" {
+		d.opts.Policy = interfaces.DeliveryBlock
+	}
+	if d.opts.SlowConsumerThreshold <= 0 {
+		d.opts.SlowConsumerThreshold = defaultSlowConsumerThreshold
+	}
+	if d.opts.QueueWarning <= 0 {
+		d.opts.QueueWarning = defaultQueueWarning
+	}
+
+	return d
+}
+
+/*
+Send delivers v to the channel according to the policy for msgType.
+
+It returns the context error if the send was abandoned because the Dispatcher context is done.
+A nil Dispatcher performs a plain blocking send.
+*/
+func Send[T any](d *Dispatcher, msgType string, ch *chan T, v T) error {
+	if ch == nil || *ch == nil {
+		return nil
+	}
+	if d == nil {
+		*ch <- v
+		return nil
+	}
+
+	return sinkFor(d, msgType, ch).send(v)
+}
+
+// Stats returns the counters for every subscriber, keyed by subscriber name
+func (d *Dispatcher) Stats() map[string]Stats {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	sinks := make([]subscriber, 0, len(d.sinks))
+	for _, s := range d.sinks {
+		sinks = append(sinks, s)
+	}
+	d.mu.Unlock()
+
+	stats := make(map[string]Stats, len(sinks))
+	for _, s := range sinks {
+		stats[s.name()] = s.stats()
+	}
+	return stats
+}
+
+// Subscribers returns the sorted subscriber names
+func (d *Dispatcher) Subscribers() []string {
+	stats := d.Stats()
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// policyFor returns the policy for a message type
+func (d *Dispatcher) policyFor(msgType string) interfaces.DeliveryPolicy {
+	if p, ok := d.opts.Policies[msgType]; ok && p != "" {
+		return p
+	}
+	return d.opts.Policy
+}
+
+// sinkFor returns the sink for a channel, creating it on first use
+func sinkFor[T any](d *Dispatcher, msgType string, ch *chan T) *sink[T] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if s, ok := d.sinks[ch]; ok {
+		return s.(*sink[T])
+	}
+
+	s := newSink(d.ctx, d, msgType, *ch)
+	d.sinks[ch] = s
+	return s
+}
+
+// newSink creates a sink which gives up when ctx is done. The caller must hold d.mu.
+func newSink[T any](ctx context.Context, d *Dispatcher, msgType string, ch chan T) *sink[T] {
+	s := &sink[T]{
+		d:       d,
+		ctx:     ctx,
+		id:      fmt.Sprintf("%s#%d", msgType, d.counts[msgType]),
+		msgType: msgType,
+		policy:  d.policyFor(msgType),
+		ch:      ch,
+	}
+	s.counters.Policy = s.policy
+	d.counts[msgType]++
+
+	if s.policy == interfaces.DeliveryUnbounded {
+		s.pending = make(chan struct{}, 1)
+		s.stopped = make(chan struct{})
+		go s.pump()
+	}
+
+	klog.V(4).Infof("delivery: %s uses policy %s\n", s.id, s.policy)
+	return s
+}
+
+func (s *sink[T]) name() string {
+	return s.id
+}
+
+func (s *sink[T]) stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counters
+}
+
+func (s *sink[T]) send(v T) error {
+	switch s.policy {
+	case interfaces.DeliveryDropNewest:
+		select {
+		case s.ch <- v:
+			s.delivered()
+		default:
+			s.dropped()
+		}
+		return nil
+	case interfaces.DeliveryDropOldest:
+		s.sendDropOldest(v)
+		return nil
+	case interfaces.DeliveryUnbounded:
+		s.enqueue(v)
+		return nil
+	default:
+		return s.sendBlocking(v)
+	}
+}
+
+// sendBlocking waits for the subscriber, warning if it takes too long
+func (s *sink[T]) sendBlocking(v T) error {
+	select {
+	case s.ch <- v:
+		s.delivered()
+		return nil
+	default:
+	}
+
+	start := time.Now()
+	timer := time.NewTimer(s.d.opts.SlowConsumerThreshold)
+	defer timer.Stop()
+
+	for {
+		select {
+		case s.ch <- v:
+			s.delivered()
+			return nil
+		case <-s.ctx.Done():
+			s.mu.Lock()
+			s.counters.Dropped++
+			s.mu.Unlock()
+			return s.ctx.Err()
+		case <-timer.C:
+			s.warn(&interfaces.SlowConsumerEvent{Blocked: time.Since(start)})
+			timer.Reset(s.d.opts.SlowConsumerThreshold)
+		}
+	}
+}
+
+// sendDropOldest makes room by discarding the oldest message buffered in the channel
+func (s *sink[T]) sendDropOldest(v T) {
+	for i := 0; i <= cap(s.ch); i++ {
+		select {
+		case s.ch <- v:
+			s.delivered()
+			return
+		default:
+		}
+
+		select {
+		case <-s.ch:
+			s.dropped()
+		default:
+		}
+	}
+
+	// unbuffered, or the subscriber is refilling it as fast as we drain
+	s.dropped()
+}
+
+func (s *sink[T]) enqueue(v T) {
+	s.mu.Lock()
+	s.queue = append(s.queue, v)
+	s.counters.Queued = len(s.queue)
+	if s.counters.Queued > s.counters.MaxQueued {
+		s.counters.MaxQueued = s.counters.Queued
+	}
+	warn := !s.warned && s.counters.Queued > s.d.opts.QueueWarning
+	if warn {
+		s.warned = true
+	}
+	queued := s.counters.Queued
+	s.mu.Unlock()
+
+	if warn {
+		s.warn(&interfaces.SlowConsumerEvent{Queued: queued})
+	}
+
+	select {
+	case s.pending <- struct{}{}:
+	default:
+	}
+}
+
+// pump moves messages from the unbounded queue to the channel
+func (s *sink[T]) pump() {
+	defer close(s.stopped)
+
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			select {
+			case <-s.pending:
+				continue
+			case <-s.ctx.Done():
+				return
+			}
+		}
+		v := s.queue[0]
+		s.mu.Unlock()
+
+		select {
+		case s.ch <- v:
+		case <-s.ctx.Done():
+			s.mu.Lock()
+			s.counters.Dropped += uint64(len(s.queue))
+			s.queue = nil
+			s.counters.Queued = 0
+			s.mu.Unlock()
+			return
+		}
+
+		s.mu.Lock()
+		var zero T
+		s.queue[0] = zero
+		s.queue = s.queue[1:]
+		s.counters.Queued = len(s.queue)
+		s.counters.Delivered++
+		// re-arm the warning once the subscriber has caught up
+		if s.warned && s.counters.Queued <= s.d.opts.QueueWarning/2 {
+			s.warned = false
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *sink[T]) delivered() {
+	s.mu.Lock()
+	s.counters.Delivered++
+	s.mu.Unlock()
+}
+
+// dropped counts a dropped message, warning at most once per SlowConsumerThreshold
+func (s *sink[T]) dropped() {
+	s.mu.Lock()
+	s.counters.Dropped++
+	warn := time.Since(s.lastWarn) >= s.d.opts.SlowConsumerThreshold
+	if warn {
+		s.lastWarn = time.Now()
+	}
+	s.mu.Unlock()
+
+	if warn {
+		s.warn(&interfaces.SlowConsumerEvent{})
+	}
+}
+
+// warn fills in the common fields and raises a slow consumer event
+func (s *sink[T]) warn(event *interfaces.SlowConsumerEvent) {
+	s.mu.Lock()
+	s.counters.Warnings++
+	event.Dropped = s.counters.Dropped
+	s.mu.Unlock()
+
+	event.Subscriber = s.id
+	event.MessageType = s.msgType
+	event.Policy = s.policy
+
+	klog.V(2).Infof("Slow consumer %s (policy: %s, blocked: %v, queued: %d, dropped: %d)\n",
+		event.Subscriber, event.Policy, event.Blocked, event.Queued, event.Dropped)
+
+	if s.d.opts.OnSlowConsumer != nil {
+		s.d.opts.OnSlowConsumer(event)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package delivery
+
+import (
+	"context"
+
+	klog "k8s.io/klog/v2"
+)
+
+// NewHub creates a Hub whose subscribers are delivered to according to the Dispatcher's policies.
+// Subscribers use the policy for MessageTypeSubscriber.
+func NewHub[E any](d *Dispatcher) *Hub[E] {
+	if d == nil {
+		d = New(context.Background(), nil)
+	}
+	return &Hub[E]{d: d}
+}
+
+/*
+Subscribe registers a subscriber which receives every published event for which match returns true.
+A nil match receives everything.
+
+The returned cancel func unsubscribes and closes the channel. The channel is also closed when the
+Dispatcher context is done, so ranging over it ends with the connection.
+*/
+func (h *Hub[E]) Subscribe(match func(E) bool, buffer int) (<-chan E, func()) {
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	ctx, cancel := context.WithCancel(h.d.ctx)
+	ch := make(chan E, buffer)
+
+	sub := &subscription[E]{
+		match:  match,
+		cancel: cancel,
+	}
+
+	h.d.mu.Lock()
+	sub.sink = newSink(ctx, h.d, MessageTypeSubscriber, ch)
+	h.d.sinks[sub] = sub.sink
+	h.d.mu.Unlock()
+
+	h.mu.Lock()
+	h.subs = append(h.subs, sub)
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		sub.once.Do(func() {
+			h.remove(sub)
+		})
+	}
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+
+	klog.V(4).Infof("delivery: %s subscribed\n", sub.sink.id)
+	return ch, unsubscribe
+}
+
+// Publish delivers an event to every matching subscriber
+func (h *Hub[E]) Publish(event E) {
+	if h == nil {
+		return
+	}
+
+	h.mu.RLock()
+	subs := make([]*subscription[E], len(h.subs))
+	copy(subs, h.subs)
+	h.mu.RUnlock()
+
+	for _, sub := range subs {
+		if sub.match != nil && !sub.match(event) {
+			continue
+		}
+
+		sub.mu.Lock()
+		if !sub.closed {
+			// an error means this subscriber was canceled mid-send
+			_ = sub.sink.send(event)
+		}
+		sub.mu.Unlock()
+	}
+}
+
+// Len returns the number of subscribers
+func (h *Hub[E]) Len() int {
+	if h == nil {
+		return 0
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs)
+}
+
+// remove unsubscribes and closes the subscriber channel
+func (h *Hub[E]) remove(sub *subscription[E]) {
+	h.mu.Lock()
+	for i, s := range h.subs {
+		if s == sub {
+			h.subs = append(h.subs[:i], h.subs[i+1:]...)
+			break
+		}
+	}
+	h.mu.Unlock()
+
+	// wakes a blocked Publish so the lock below can be taken
+	sub.cancel()
+
+	sub.mu.Lock()
+	sub.closed = true
+	if sub.sink.stopped != nil {
+		<-sub.sink.stopped
+	}
+	close(sub.sink.ch)
+	sub.mu.Unlock()
+
+	h.d.mu.Lock()
+	delete(h.d.sinks, sub)
+	h.d.mu.Unlock()
+
+	klog.V(4).Infof("delivery: %s unsubscribed\n", sub.sink.id)
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package delivery
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces/v1"
+)
+
+// Stats are the delivery counters for a single channel subscriber
+type Stats struct {
+	Policy    interfaces.DeliveryPolicy
+	Delivered uint64 // messages handed to the channel
+	Dropped   uint64 // messages discarded by the policy or a canceled context
+	Queued    int    // messages currently waiting in an unbounded queue
+	MaxQueued int    // high water mark of the unbounded queue
+	Warnings  uint64 // slow consumer events raised
+}
+
+// Dispatcher delivers router messages to channel subscribers according to a DeliveryPolicy
+type Dispatcher struct {
+	ctx  context.Context
+	opts interfaces.ChanDeliveryOptions
+
+	mu     sync.Mutex
+	sinks  map[interface{}]subscriber
+	counts map[string]int
+}
+
+// subscriber is the type independent view of a sink
+type subscriber interface {
+	name() string
+	stats() Stats
+}
+
+// sink delivers to a single channel
+type sink[T any] struct {
+	d       *Dispatcher
+	ctx     context.Context
+	id      string
+	msgType string
+	policy  interfaces.DeliveryPolicy
+	ch      chan T
+
+	mu       sync.Mutex
+	counters Stats
+	queue    []T
+	pending  chan struct{}
+	stopped  chan struct{} // closed when the pump exits
+	warned   bool          // an unbounded queue warning is outstanding
+	lastWarn time.Time     // last drop warning
+}
+
+// Hub fans events out to subscribers which can come and go while the connection is open
+type Hub[E any] struct {
+	d *Dispatcher
+
+	mu   sync.RWMutex
+	subs []*subscription[E]
+}
+
+// subscription is a single Hub subscriber
+type subscription[E any] struct {
+	match  func(E) bool
+	sink   *sink[E]
+	cancel context.CancelFunc
+	once   sync.Once
+
+	mu     sync.Mutex // held while sending so the channel is not closed underneath
+	closed bool
+}
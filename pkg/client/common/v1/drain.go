@@ -0,0 +1,144 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package commonv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	klog "k8s.io/klog/v2"
+)
+
+/*
+Drain gracefully finishes the stream instead of closing after a fixed sleep. It sends opts.Message,
+waits for the message matching opts.Match and, with opts.WaitClose, for the server to close the
+connection. The client is then stopped, even when ctx ends first.
+
+It returns the matched message, or ErrDrainIncomplete if the connection closed before it arrived.
+*/
+func (c *WSClient) Drain(ctx context.Context, opts DrainOptions) ([]byte, error) {
+	klog.V(6).Infof("common.Drain() ENTER\n")
+	defer c.Stop()
+
+	var waiter *drainWaiter
+	if opts.Match != nil {
+		waiter = &drainWaiter{match: opts.Match, ch: make(chan []byte, 1)}
+		c.muDrain.Lock()
+		c.drains = append(c.drains, waiter)
+		c.muDrain.Unlock()
+		defer c.removeDrain(waiter)
+	}
+
+	c.muConn.Lock()
+	done := c.listenDone
+	connected := c.wsconn != nil
+	c.muConn.Unlock()
+	if !connected || done == nil {
+		klog.V(1).Infof("Drain: %v\n", ErrInvalidConnection)
+		klog.V(6).Infof("common.Drain() LEAVE\n")
+		return nil, ErrInvalidConnection
+	}
+
+	// the finish message must follow the audio still queued
+	if err := c.flushWriter(ctx); err != nil {
+		klog.V(1).Infof("Drain: sending queued audio failed. Err: %v\n", err)
+		klog.V(6).Infof("common.Drain() LEAVE\n")
+		return nil, err
+	}
+
+	if len(opts.Message) > 0 {
+		if err := c.WriteJSON(json.RawMessage(opts.Message)); err != nil {
+			klog.V(1).Infof("Drain: sending the finish message failed. Err: %v\n", err)
+			klog.V(6).Infof("common.Drain() LEAVE\n")
+			return nil, err
+		}
+	}
+	c.setState(StateClosing)
+
+	// registered before checking, so a final message which arrives in between is not missed
+	if waiter != nil && opts.Pending != nil && !opts.Pending() {
+		klog.V(4).Infof("Drain: no final message pending\n")
+		waiter = nil
+	}
+
+	var final []byte
+	if waiter != nil {
+		select {
+		case final = <-waiter.ch:
+			klog.V(4).Infof("Drain: final message received\n")
+		case <-done:
+			// the message may have been the last thing read
+			select {
+			case final = <-waiter.ch:
+			default:
+				klog.V(1).Infof("Drain: %v\n", ErrDrainIncomplete)
+				klog.V(6).Infof("common.Drain() LEAVE\n")
+				return nil, ErrDrainIncomplete
+			}
+		case <-ctx.Done():
+			klog.V(1).Infof("Drain: waiting for the final message. Err: %v\n", ctx.Err())
+			klog.V(6).Infof("common.Drain() LEAVE\n")
+			return nil, ctx.Err()
+		}
+	}
+
+	if opts.WaitClose {
+		select {
+		case <-done:
+			klog.V(4).Infof("Drain: server closed the connection\n")
+		case <-ctx.Done():
+			klog.V(1).Infof("Drain: waiting for the server to close. Err: %v\n", ctx.Err())
+			klog.V(6).Infof("common.Drain() LEAVE\n")
+			return final, ctx.Err()
+		}
+	}
+
+	klog.V(3).Infof("Drain Succeeded\n")
+	klog.V(6).Infof("common.Drain() LEAVE\n")
+	return final, nil
+}
+
+// notifyDrain hands a received text message to the Drain waiting for it
+func (c *WSClient) notifyDrain(byMsg []byte) {
+	c.muDrain.Lock()
+	defer c.muDrain.Unlock()
+
+	for _, w := range c.drains {
+		if w.match(byMsg) {
+			select {
+			case w.ch <- byMsg:
+			default:
+				// keep the first match
+			}
+		}
+	}
+}
+
+func (c *WSClient) removeDrain(waiter *drainWaiter) {
+	c.muDrain.Lock()
+	defer c.muDrain.Unlock()
+
+	for i, w := range c.drains {
+		if w == waiter {
+			c.drains = append(c.drains[:i], c.drains[i+1:]...)
+			return
+		}
+	}
+}
+
+// MatchType returns a DrainOptions.Match func which matches messages of the given type
+func MatchType(msgType string) func(byMsg []byte) bool {
+	quoted := []byte(`"` + msgType + `"`)
+	return func(byMsg []byte) bool {
+		if !bytes.Contains(byMsg, quoted) {
+			return false
+		}
+		var mt struct {
+			Type string `json:"type"`
+		}
+		return json.Unmarshal(byMsg, &mt) == nil && mt.Type == msgType
+	}
+}
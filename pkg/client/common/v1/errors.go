@@ -0,0 +1,74 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package commonv1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	clientinterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces/v1"
+)
+
+// Error returns the status and the Deepgram error message, or the start of the body
+func (e *HandshakeError) Error() string {
+	switch {
+	case e.DeepgramError != nil:
+		return fmt.Sprintf("%v: %s: %s %s", ErrHandshakeFailed, e.Status, e.DeepgramError.ErrCode, e.DeepgramError.ErrMsg)
+	case len(e.Body) > 0:
+		return fmt.Sprintf("%v: %s: %s", ErrHandshakeFailed, e.Status, strings.TrimSpace(string(e.Body)))
+	default:
+		return fmt.Sprintf("%v: %s", ErrHandshakeFailed, e.Status)
+	}
+}
+
+// Is matches ErrHandshakeFailed
+func (e *HandshakeError) Is(target error) bool {
+	return target == ErrHandshakeFailed
+}
+
+// Unwrap returns the dialer error
+func (e *HandshakeError) Unwrap() error {
+	return e.Err
+}
+
+// Error returns the host, the number of attempts and the last error
+func (e *ConnectError) Error() string {
+	return fmt.Sprintf("connecting to %s failed after %d attempt(s): %v", e.Host, e.Attempts, e.Err)
+}
+
+// Unwrap returns the error from the last attempt
+func (e *ConnectError) Unwrap() error {
+	return e.Err
+}
+
+/*
+helpers
+*/
+// newHandshakeError reads the response to a rejected upgrade. The caller closes the body.
+func newHandshakeError(res *http.Response, err error) *HandshakeError {
+	e := &HandshakeError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Header:     res.Header,
+		RequestID:  res.Header.Get("dg-request-id"),
+		Err:        err,
+	}
+
+	if res.Body != nil {
+		e.Body, _ = io.ReadAll(io.LimitReader(res.Body, maxHandshakeBody))
+	}
+
+	var dgErr clientinterfaces.DeepgramError
+	if json.Unmarshal(e.Body, &dgErr) == nil && (dgErr.ErrCode != "" || dgErr.ErrMsg != "") {
+		e.DeepgramError = &dgErr
+	} else if msg := res.Header.Get("dg-error"); msg != "" {
+		e.DeepgramError = &clientinterfaces.DeepgramError{Type: "Error", ErrMsg: msg}
+	}
+
+	return e
+}
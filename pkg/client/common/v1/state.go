@@ -0,0 +1,81 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package commonv1
+
+import (
+	"net/http"
+
+	klog "k8s.io/klog/v2"
+)
+
+// String returns the name of the state
+func (s ConnectionState) String() string {
+	switch s {
+	case StateIdle:
+		return "Idle"
+	case StateConnecting:
+		return "Connecting"
+	case StateOpen:
+		return "Open"
+	case StateReconnecting:
+		return "Reconnecting"
+	case StateClosing:
+		return "Closing"
+	case StateClosed:
+		return "Closed"
+	default:
+		return "Unknown"
+	}
+}
+
+// State returns the current connection state
+func (c *WSClient) State() ConnectionState {
+	c.muState.Lock()
+	defer c.muState.Unlock()
+	return c.state
+}
+
+// OnStateChange adds a hook which is called on every state change, see StateHook
+func (c *WSClient) OnStateChange(hook StateHook) {
+	if hook == nil {
+		return
+	}
+
+	c.muState.Lock()
+	defer c.muState.Unlock()
+	c.hooks = append(c.hooks, hook)
+}
+
+// setState moves to a new state and calls the hooks
+func (c *WSClient) setState(to ConnectionState) {
+	c.muState.Lock()
+	from := c.state
+	if from == to {
+		c.muState.Unlock()
+		return
+	}
+	c.state = to
+	hooks := make([]StateHook, len(c.hooks))
+	copy(hooks, c.hooks)
+	c.muState.Unlock()
+
+	klog.V(4).Infof("WebSocket state %s -> %s\n", from, to)
+	for _, hook := range hooks {
+		hook(from, to)
+	}
+}
+
+// retryable reports whether a rejected handshake might succeed on another attempt
+func (c *WSClient) retryable(statusCode int) bool {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		// the token was invalidated, so the next attempt fetches a new one
+		return c.cOptions.TokenSource != nil
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests:
+		return true
+	default:
+		return statusCode >= http.StatusInternalServerError
+	}
+}
@@ -6,7 +6,9 @@ package commonv1
 
 import (
 	"context"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/dvonthenen/websocket"
 
@@ -26,7 +28,6 @@ const (
 type WSClient struct {
 	cOptions *clientinterfaces.ClientOptions
 
-	sendBuf   chan []byte
 	ctx       context.Context
 	ctxCancel context.CancelFunc
 
@@ -37,6 +38,95 @@ type WSClient struct {
 
 	processMessages *commonv1interfaces.WebSocketHandler
 	router          *commonv1interfaces.Router
+
+	writer *asyncWriter // nil writes synchronously
+
+	listenDone chan struct{} // closed when the current connection stops reading
+	muDrain    sync.Mutex
+	drains     []*drainWaiter
+
+	opened  bool // has been open before, so the next connect is a reconnect
+	muState sync.Mutex
+	state   ConnectionState
+	hooks   []StateHook
+}
+
+// DrainOptions describes how a protocol finishes its stream, see WSClient.Drain
+type DrainOptions struct {
+	// Message is sent to ask the server to finish, e.g. CloseStream. Optional.
+	Message []byte
+	// Match reports whether a text message is the final one to wait for. Nil waits for none.
+	Match func(byMsg []byte) bool
+	// Pending reports whether the final message is still expected. Nil means it always is.
+	Pending func() bool
+	// WaitClose also waits for the server to close the connection
+	WaitClose bool
+}
+
+// drainWaiter is a Drain waiting for its final message
+type drainWaiter struct {
+	match func(byMsg []byte) bool
+	ch    chan []byte
+}
+
+// WriteQueueStats reports the state of the async writer, see ClientOptions.AsyncWrite
+type WriteQueueStats struct {
+	Depth        int    // audio chunks waiting in the queue
+	Capacity     int    // size of the audio queue
+	HighWater    int    // deepest the audio queue has been
+	Control      int    // control messages waiting
+	PendingBytes int    // audio held back to coalesce into the next frame
+	Enqueued     uint64 // audio chunks queued
+	Coalesced    uint64 // audio chunks merged into a larger frame
+	Frames       uint64 // audio frames written
+	Controls     uint64 // control messages written
+	Rejected     uint64 // TryWrite calls which found the queue full
+	Errors       uint64 // writes which failed
+}
+
+// asyncWriter sends queued messages from its own goroutine
+type asyncWriter struct {
+	audio      chan writeItem
+	control    chan []byte
+	frameBytes int
+	maxDelay   time.Duration
+
+	mu      sync.Mutex
+	ctx     context.Context // of the running writer
+	running bool
+	lastErr error // returned by the next write, so async failures are not lost
+	stats   WriteQueueStats
+}
+
+// writeItem is a queued audio chunk, or a barrier which is closed once everything before it is sent
+type writeItem struct {
+	data  []byte
+	flush chan struct{}
+}
+
+// ConnectionState is the lifecycle state of a WSClient
+type ConnectionState int32
+
+// StateHook is called on every state change. It runs on the goroutine changing the state, while the
+// connection is locked, so it must not block or call back into the client other than State().
+type StateHook func(from, to ConnectionState)
+
+// HandshakeError is returned when the server rejects the websocket upgrade
+type HandshakeError struct {
+	StatusCode    int
+	Status        string
+	Header        http.Header
+	Body          []byte // truncated to 4KB
+	RequestID     string // dg-request-id header
+	DeepgramError *clientinterfaces.DeepgramError
+	Err           error
+}
+
+// ConnectError is returned when connecting fails. Err is the error from the last attempt.
+type ConnectError struct {
+	Host     string
+	Attempts int
+	Err      error
 }
 
 // ***************************
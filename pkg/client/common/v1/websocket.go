@@ -35,13 +35,15 @@ func NewWS(ctx context.Context, ctxCancel context.CancelFunc, apiKey string, opt
 
 	c := WSClient{
 		cOptions:        options,
-		sendBuf:         make(chan []byte, 1),
 		ctx:             ctx,
 		ctxCancel:       ctxCancel,
 		retry:           true,
 		processMessages: processMessages,
 		router:          router,
 	}
+	if options.AsyncWrite != nil {
+		c.writer = newAsyncWriter(ctx, options.AsyncWrite)
+	}
 
 	return &c
 }
@@ -78,12 +80,41 @@ func (c *WSClient) AttemptReconnectWithCancel(ctx context.Context, ctxCancel con
 	return c.internalConnectWithCancel(ctx, ctxCancel, int(retries), true) != nil
 }
 
+/*
+ConnectContext performs a websocket connection with "DefaultConnectRetry" number of retries, or the
+retry count of the previous connect. Unlike Connect, it returns why the connection failed, for example
+a *HandshakeError carrying the HTTP status and body of a rejected upgrade. The connection lives until
+ctx is done or Stop is called.
+*/
+func (c *WSClient) ConnectContext(ctx context.Context) error {
+	retryCnt := c.retryCnt
+	if retryCnt == 0 {
+		retryCnt = DefaultConnectRetry
+	}
+	ctx, ctxCancel := context.WithCancel(ctx)
+	return c.ConnectContextWithCancel(ctx, ctxCancel, int(retryCnt))
+}
+
+// ConnectContextWithCancel is ConnectContext with a specified number of retries and a cancel function to stop the connection
+func (c *WSClient) ConnectContextWithCancel(ctx context.Context, ctxCancel context.CancelFunc, retryCnt int) error {
+	c.muConn.Lock()
+	c.retry = true
+	c.muConn.Unlock()
+	_, err := c.connect(ctx, ctxCancel, retryCnt, true)
+	return err
+}
+
 func (c *WSClient) internalConnect() *websocket.Conn {
 	return c.internalConnectWithCancel(c.ctx, c.ctxCancel, int(c.retryCnt), false)
 }
 
-//nolint:funlen,gocyclo // this is a complex function. keep as is
 func (c *WSClient) internalConnectWithCancel(ctx context.Context, ctxCancel context.CancelFunc, retryCnt int, lock bool) *websocket.Conn {
+	ws, _ := c.connect(ctx, ctxCancel, retryCnt, lock)
+	return ws
+}
+
+//nolint:funlen,gocyclo // this is a complex function. keep as is
+func (c *WSClient) connect(ctx context.Context, ctxCancel context.CancelFunc, retryCnt int, lock bool) (*websocket.Conn, error) {
 	klog.V(7).Infof("common.internalConnectWithCancel() ENTER\n")
 
 	// set the context
@@ -105,7 +136,7 @@ func (c *WSClient) internalConnectWithCancel(ctx context.Context, ctxCancel cont
 			klog.V(3).Infof("Unlocking connection mutex\n")
 			c.muConn.Unlock()
 		}
-		return nil
+		return nil, ErrConnectionTerminated
 	}
 
 	// if the connection is good, return it otherwise, attempt reconnect
@@ -118,7 +149,7 @@ func (c *WSClient) internalConnectWithCancel(ctx context.Context, ctxCancel cont
 				klog.V(3).Infof("Unlocking connection mutex\n")
 				c.muConn.Unlock()
 			}
-			return nil
+			return nil, c.ctx.Err()
 		default:
 			klog.V(7).Infof("Connection is good. Return object.")
 			klog.V(7).Infof("common.internalConnectWithCancel() LEAVE\n")
@@ -126,7 +157,7 @@ func (c *WSClient) internalConnectWithCancel(ctx context.Context, ctxCancel cont
 				klog.V(3).Infof("Unlocking connection mutex\n")
 				c.muConn.Unlock()
 			}
-			return c.wsconn
+			return c.wsconn, nil
 		}
 	} else {
 		select {
@@ -137,7 +168,7 @@ func (c *WSClient) internalConnectWithCancel(ctx context.Context, ctxCancel cont
 				klog.V(3).Infof("Unlocking connection mutex\n")
 				c.muConn.Unlock()
 			}
-			return nil
+			return nil, c.ctx.Err()
 		default:
 			klog.V(3).Infof("Context is still valid. Retry...\n")
 		}
@@ -156,22 +187,17 @@ func (c *WSClient) internalConnectWithCancel(ctx context.Context, ctxCancel cont
 		}
 	}
 
-	// Set Authorization header based on priority: AccessToken (Bearer) > APIKey (Token)
 	myHeader.Set("Host", c.cOptions.Host)
-	token, isBearer := c.cOptions.GetAuthToken()
-	if isBearer {
-		myHeader.Set("Authorization", "Bearer "+token)
-		klog.V(4).Infof("WebSocket using Bearer authentication")
-	} else {
-		myHeader.Set("Authorization", "token "+token)
-		klog.V(4).Infof("WebSocket using Token authentication")
-	}
 	myHeader.Set("User-Agent", clientinterfaces.DgAgent)
-	if c.cOptions.WSHeaderProcessor != nil {
-		c.cOptions.WSHeaderProcessor(myHeader)
+
+	if c.opened {
+		c.setState(StateReconnecting)
+	} else {
+		c.setState(StateConnecting)
 	}
 
 	// attempt to establish connection
+	var lastErr error
 	i := int64(0)
 	for {
 		if i >= c.retryCnt {
@@ -183,24 +209,70 @@ func (c *WSClient) internalConnectWithCancel(ctx context.Context, ctxCancel cont
 		// delay on subsequent calls
 		if i > 0 {
 			klog.V(2).Infof("Sleep for retry #%d...\n", i)
-			time.Sleep(time.Second * time.Duration(defaultDelayBetweenRetry))
+			if err := c.sleep(time.Second * time.Duration(defaultDelayBetweenRetry)); err != nil {
+				klog.V(1).Infof("Context done while waiting to retry. Err: %v\n", err)
+				lastErr = err
+				break
+			}
 		}
 
 		i++
 
+		// a reconnect may happen long after the last dial, so get a current token every attempt
+		if err := c.cOptions.RefreshAuthToken(c.ctx); err != nil {
+			klog.V(1).Infof("RefreshAuthToken failed. Err: %v\n", err)
+			lastErr = err
+			continue
+		}
+
+		// Set Authorization header based on priority: AccessToken (Bearer) > APIKey (Token)
+		dialHeader := myHeader.Clone()
+		token, isBearer := c.cOptions.GetAuthToken()
+		if isBearer {
+			dialHeader.Set("Authorization", "Bearer "+token)
+			klog.V(4).Infof("WebSocket using Bearer authentication")
+		} else {
+			dialHeader.Set("Authorization", "token "+token)
+			klog.V(4).Infof("WebSocket using Token authentication")
+		}
+		if c.cOptions.WSHeaderProcessor != nil {
+			c.cOptions.WSHeaderProcessor(dialHeader)
+		}
+
 		// create new connection
 		url, err := (*c.processMessages).GetURL(c.cOptions.Host)
 		if err != nil {
 			klog.V(1).Infof("GetURL failed. Err: %v\n", err)
 			klog.V(7).Infof("internalConnectWithCancel() LEAVE\n")
+			c.setState(StateClosed)
 			if lock {
 				klog.V(3).Infof("Unlocking connection mutex\n")
 				c.muConn.Unlock()
 			}
-			return nil // no point in retrying because this is going to fail on every retry
+			// no point in retrying because this is going to fail on every retry
+			return nil, &ConnectError{Host: c.cOptions.Host, Attempts: int(i), Err: err}
 		}
 		klog.V(5).Infof("Connecting to %s\n", url)
 
+		// sign the handshake request
+		if signer, ok := c.ctx.Value(clientinterfaces.SignerContext{}).(clientinterfaces.Signer); ok && signer != nil {
+			handshake, err := http.NewRequestWithContext(c.ctx, http.MethodGet, url, nil)
+			if err == nil {
+				handshake.Header = dialHeader
+				err = signer.SignRequest(handshake)
+			}
+			if err != nil {
+				klog.V(1).Infof("SignRequest failed. Err: %v\n", err)
+				klog.V(7).Infof("internalConnectWithCancel() LEAVE\n")
+				c.setState(StateClosed)
+				if lock {
+					klog.V(3).Infof("Unlocking connection mutex\n")
+					c.muConn.Unlock()
+				}
+				return nil, &ConnectError{Host: c.cOptions.Host, Attempts: int(i), Err: err}
+			}
+		}
+
 		// if host starts with "ws://", then disable TLS
 		var dialer websocket.Dialer
 		if url[:5] == "ws://" {
@@ -221,23 +293,39 @@ func (c *WSClient) internalConnectWithCancel(ctx context.Context, ctxCancel cont
 		}
 
 		// perform the websocket connection
-		ws, res, err := dialer.DialContext(c.ctx, url, myHeader)
+		ws, res, err := dialer.DialContext(c.ctx, url, dialHeader)
 		if res != nil {
 			klog.V(3).Infof("HTTP Response: %s\n", res.Status)
+			if err != nil {
+				err = newHandshakeError(res, err)
+			}
 			res.Body.Close()
+			if res.StatusCode == http.StatusUnauthorized {
+				c.cOptions.InvalidateAuthToken()
+			}
 		}
 		if err != nil {
 			klog.V(1).Infof("Cannot connect to websocket: %s\n", c.cOptions.Host)
 			klog.V(1).Infof("Dialer failed. Err: %v\n", err)
+			lastErr = err
+
+			// bad credentials or options fail the same way on every retry
+			if res != nil && !c.retryable(res.StatusCode) {
+				break
+			}
 			continue
 		}
 
 		// set the object to allow threads to function
 		c.wsconn = ws
 		c.retry = true
+		c.opened = true
+		c.startWriter(c.ctx) // before Open, which lets writes be queued
+		c.setState(StateOpen)
 
 		// kick off threads to listen for messages and ping/keepalive
-		go c.listen()
+		c.listenDone = make(chan struct{})
+		go c.listen(c.listenDone)
 		if lock {
 			klog.V(3).Infof("Unlocking connection mutex\n")
 			c.muConn.Unlock()
@@ -257,25 +345,29 @@ func (c *WSClient) internalConnectWithCancel(ctx context.Context, ctxCancel cont
 		klog.V(3).Infof("WebSocket Connection Successful!")
 		klog.V(7).Infof("common.internalConnectWithCancel() LEAVE\n")
 
-		return c.wsconn
+		return c.wsconn, nil
 	}
 
 	// if we get here, we failed to connect
 	klog.V(1).Infof("Failed to connect to websocket: %s\n", c.cOptions.Host)
 	klog.V(7).Infof("common.internalConnectWithCancel() LEAVE\n")
 
+	c.setState(StateClosed)
 	if lock {
 		klog.V(3).Infof("Unlocking connection mutex\n")
 		c.muConn.Unlock()
 	}
 
-	return nil
+	return nil, &ConnectError{Host: c.cOptions.Host, Attempts: int(i), Err: lastErr}
 }
 
 //nolint:funlen // this is a complex function. keep as is
-func (c *WSClient) listen() {
+func (c *WSClient) listen(done chan struct{}) {
 	klog.V(6).Infof("common.listen() ENTER\n")
 
+	// lets Drain know the server closed the connection
+	defer close(done)
+
 	defer func() {
 		if r := recover(); r != nil {
 			klog.V(1).Infof("Panic triggered\n")
@@ -406,6 +498,11 @@ func (c *WSClient) listen() {
 		if err != nil {
 			klog.V(1).Infof("ProcessMessage failed. Err: %v\n", err)
 		}
+
+		// after processing, so subscribers have the final message before Drain returns
+		if msgType == websocket.TextMessage {
+			c.notifyDrain(byMsg)
+		}
 	}
 }
 
@@ -413,6 +510,11 @@ func (c *WSClient) listen() {
 func (c *WSClient) WriteBinary(byData []byte) error {
 	klog.V(7).Infof("common.WriteBinary() ENTER\n")
 
+	if c.writer != nil {
+		klog.V(7).Infof("common.WriteBinary() LEAVE\n")
+		return c.writeAsync(byData)
+	}
+
 	// doing a write, need to lock
 	c.muConn.Lock()
 	defer c.muConn.Unlock()
@@ -457,6 +559,11 @@ func (c *WSClient) WriteJSON(payload interface{}) error {
 		return err
 	}
 
+	if c.writer != nil {
+		klog.V(6).Infof("common.WriteJSON() LEAVE\n")
+		return c.writeControlAsync(byData)
+	}
+
 	// doing a write, need to lock
 	c.muConn.Lock()
 	defer c.muConn.Unlock()
@@ -568,10 +675,19 @@ func (c *WSClient) Stop() {
 func (c *WSClient) closeWs(fatal bool, perm bool) {
 	klog.V(6).Infof("common.closeWs() closing channels...\n")
 
+	// send the queued audio ahead of the close message, before taking the lock the writer needs
+	if !fatal {
+		c.drainWriter()
+	}
+
 	// doing a write, need to lock
 	c.muConn.Lock()
 	defer c.muConn.Unlock()
 
+	if c.wsconn != nil {
+		c.setState(StateClosing)
+	}
+
 	if c.wsconn != nil && !fatal {
 		// deepgram requires a close message to be sent
 		_ = c.closeStream(false)
@@ -606,6 +722,7 @@ func (c *WSClient) closeWs(fatal bool, perm bool) {
 		c.wsconn.Close()
 		c.wsconn = nil
 	}
+	c.setState(StateClosed)
 
 	klog.V(4).Infof("common.closeWs() Succeeded\n")
 	klog.V(6).Infof("common.closeWs() LEAVE\n")
@@ -620,3 +737,16 @@ func (c *WSClient) sendError(err error) error {
 
 	return err
 }
+
+// sleep waits for d or until the context is done
+func (c *WSClient) sleep(d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
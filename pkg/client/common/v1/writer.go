@@ -0,0 +1,337 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package commonv1
+
+import (
+	"context"
+	"time"
+
+	"github.com/dvonthenen/websocket"
+	klog "k8s.io/klog/v2"
+
+	clientinterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces/v1"
+)
+
+// newAsyncWriter creates the writer, done with the client's ctx until startWriter hands it a connection's
+func newAsyncWriter(ctx context.Context, opts *clientinterfaces.AsyncWriteOptions) *asyncWriter {
+	queueSize := opts.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultWriteQueueSize
+	}
+
+	w := &asyncWriter{
+		ctx:     ctx,
+		audio:   make(chan writeItem, queueSize),
+		control: make(chan []byte, defaultControlQueueSize),
+	}
+	w.stats.Capacity = queueSize
+
+	if opts.FrameDuration > 0 && opts.BytesPerSecond > 0 {
+		w.frameBytes = int(int64(opts.BytesPerSecond) * int64(opts.FrameDuration) / int64(time.Second))
+		w.maxDelay = opts.FrameDuration
+	}
+
+	return w
+}
+
+/*
+TryWrite queues binary data without waiting. It returns ErrWriteQueueFull when the queue is full,
+so the caller can drop or buffer the audio itself. Without ClientOptions.AsyncWrite, it is WriteBinary.
+*/
+func (c *WSClient) TryWrite(byData []byte) error {
+	if c.writer == nil {
+		return c.WriteBinary(byData)
+	}
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	select {
+	case c.writer.audio <- writeItem{data: copyBytes(byData)}:
+		c.writer.queued()
+		return nil
+	default:
+		c.writer.mu.Lock()
+		c.writer.stats.Rejected++
+		c.writer.mu.Unlock()
+
+		klog.V(4).Infof("TryWrite: %v\n", ErrWriteQueueFull)
+		return ErrWriteQueueFull
+	}
+}
+
+// WriteQueueStats returns the async writer's queue depth and counters, zero without ClientOptions.AsyncWrite
+func (c *WSClient) WriteQueueStats() WriteQueueStats {
+	if c.writer == nil {
+		return WriteQueueStats{}
+	}
+
+	c.writer.mu.Lock()
+	defer c.writer.mu.Unlock()
+
+	stats := c.writer.stats
+	stats.Depth = len(c.writer.audio)
+	stats.Control = len(c.writer.control)
+	return stats
+}
+
+// writeAsync queues an audio chunk, waiting for room in the queue
+func (c *WSClient) writeAsync(byData []byte) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	select {
+	case c.writer.audio <- writeItem{data: copyBytes(byData)}:
+		c.writer.queued()
+		return nil
+	case <-c.writer.done():
+		return ErrInvalidConnection
+	}
+}
+
+// writeControlAsync queues a control message, which is sent ahead of queued audio
+func (c *WSClient) writeControlAsync(byData []byte) error {
+	if err := c.writable(); err != nil {
+		return err
+	}
+
+	select {
+	case c.writer.control <- byData:
+		return nil
+	case <-c.writer.done():
+		return ErrInvalidConnection
+	}
+}
+
+// flushWriter waits until the audio queued so far has been sent
+func (c *WSClient) flushWriter(ctx context.Context) error {
+	if c.writer == nil {
+		return nil
+	}
+
+	barrier := writeItem{flush: make(chan struct{})}
+	select {
+	case c.writer.audio <- barrier:
+	case <-c.writer.done():
+		return ErrInvalidConnection
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-barrier.flush:
+		return nil
+	case <-c.writer.done():
+		return ErrInvalidConnection
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drainWriter sends the audio queued so far before the stream is closed, waiting at most defaultFlushTimeout
+func (c *WSClient) drainWriter() {
+	if c.writer == nil {
+		return
+	}
+
+	c.writer.mu.Lock()
+	running := c.writer.running
+	c.writer.mu.Unlock()
+	if !running {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultFlushTimeout)
+	defer cancel()
+
+	if err := c.flushWriter(ctx); err != nil {
+		klog.V(1).Infof("flushWriter failed. Err: %v\n", err)
+	}
+}
+
+// writable fails the write when the connection is not usable, or with the error of an earlier async write
+func (c *WSClient) writable() error {
+	switch c.State() {
+	case StateIdle, StateClosing, StateClosed:
+		return ErrInvalidConnection
+	}
+
+	c.writer.mu.Lock()
+	defer c.writer.mu.Unlock()
+
+	err := c.writer.lastErr
+	c.writer.lastErr = nil
+	return err
+}
+
+// startWriter runs the writer for the life of ctx, if it isn't running already
+func (c *WSClient) startWriter(ctx context.Context) {
+	if c.writer == nil {
+		return
+	}
+
+	c.writer.mu.Lock()
+	defer c.writer.mu.Unlock()
+
+	if c.writer.running {
+		return
+	}
+	c.writer.running = true
+	c.writer.ctx = ctx
+	go c.runWriter(ctx)
+}
+
+// runWriter sends control messages first, then audio coalesced into frames
+//
+//nolint:gocyclo // this is a complex function. keep as is
+func (c *WSClient) runWriter(ctx context.Context) {
+	klog.V(6).Infof("common.runWriter() ENTER\n")
+
+	w := c.writer
+	defer func() {
+		w.mu.Lock()
+		w.running = false
+		w.mu.Unlock()
+
+		klog.V(6).Infof("common.runWriter() LEAVE\n")
+	}()
+
+	var pending []byte
+	var coalesced uint64
+	var timer *time.Timer
+	var timeout <-chan time.Time
+
+	flushPending := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		if len(pending) == 0 {
+			return
+		}
+		err := c.writeMessage(websocket.BinaryMessage, pending)
+		w.written(err, false, coalesced)
+		pending = pending[:0]
+		coalesced = 0
+	}
+
+	for {
+		// control messages go ahead of any audio
+		select {
+		case byData := <-w.control:
+			w.written(c.writeMessage(websocket.TextMessage, byData), true, 0)
+			continue
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			klog.V(3).Infof("common.runWriter() Exiting\n")
+			flushPending()
+			return
+		case byData := <-w.control:
+			w.written(c.writeMessage(websocket.TextMessage, byData), true, 0)
+		case <-timeout:
+			klog.V(7).Infof("runWriter: sending a partial frame of %d bytes\n", len(pending))
+			flushPending()
+		case item := <-w.audio:
+			if item.flush != nil {
+				flushPending()
+				close(item.flush)
+				break
+			}
+
+			// nothing to coalesce with
+			if w.frameBytes == 0 || (len(pending) == 0 && len(item.data) >= w.frameBytes) {
+				w.written(c.writeMessage(websocket.BinaryMessage, item.data), false, 0)
+				break
+			}
+
+			if len(pending) > 0 {
+				coalesced++
+			}
+			pending = append(pending, item.data...)
+			if len(pending) >= w.frameBytes {
+				flushPending()
+			} else if timer == nil {
+				timer = time.NewTimer(w.maxDelay)
+				timeout = timer.C
+			}
+		}
+
+		w.mu.Lock()
+		w.stats.PendingBytes = len(pending)
+		w.mu.Unlock()
+	}
+}
+
+// writeMessage performs a synchronous write on the current connection
+func (c *WSClient) writeMessage(msgType int, byData []byte) error {
+	c.muConn.Lock()
+	defer c.muConn.Unlock()
+
+	// use the open connection, which closeWs still writes to after Stop turns off retries
+	ws := c.wsconn
+	if ws == nil {
+		ws = c.internalConnect()
+	}
+	if ws == nil {
+		return ErrInvalidConnection
+	}
+
+	return ws.WriteMessage(msgType, byData)
+}
+
+// done is closed when the writer stops
+func (w *asyncWriter) done() <-chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// never ready without a context
+	if w.ctx == nil {
+		return nil
+	}
+	return w.ctx.Done()
+}
+
+func (w *asyncWriter) queued() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.stats.Enqueued++
+	if depth := len(w.audio); depth > w.stats.HighWater {
+		w.stats.HighWater = depth
+	}
+}
+
+func (w *asyncWriter) written(err error, control bool, coalesced uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err != nil {
+		klog.V(1).Infof("async write failed. Err: %v\n", err)
+		w.stats.Errors++
+		w.lastErr = err
+		return
+	}
+
+	if control {
+		w.stats.Controls++
+		return
+	}
+	w.stats.Frames++
+	w.stats.Coalesced += coalesced
+}
+
+/*
+helpers
+*/
+// copyBytes copies a chunk before queueing it, since callers like Stream reuse their buffer
+func copyBytes(byData []byte) []byte {
+	dup := make([]byte, len(byData))
+	copy(dup, byData)
+	return dup
+}
@@ -19,9 +19,23 @@ func NewSettingsConfigurationOptions() *interfacesv1.SettingsOptions {
 
 // options
 type ClientOptions = interfacesv1.ClientOptions
+type TokenSource = interfacesv1.TokenSource
+type DeliveryPolicy = interfacesv1.DeliveryPolicy
+type ChanDeliveryOptions = interfacesv1.ChanDeliveryOptions
+type SlowConsumerEvent = interfacesv1.SlowConsumerEvent
+type AsyncWriteOptions = interfacesv1.AsyncWriteOptions
+type StreamPacingOptions = interfacesv1.StreamPacingOptions
 type SettingsOptions = interfacesv1.SettingsOptions
 type PreRecordedTranscriptionOptions = interfacesv1.PreRecordedTranscriptionOptions
 type LiveTranscriptionOptions = interfacesv1.LiveTranscriptionOptions
 type AnalyzeOptions = interfacesv1.AnalyzeOptions
 type SpeakOptions = interfacesv1.SpeakOptions
 type WSSpeakOptions = interfacesv1.WSSpeakOptions
+
+// channel delivery policies
+const (
+	DeliveryBlock      = interfacesv1.DeliveryBlock
+	DeliveryDropOldest = interfacesv1.DeliveryDropOldest
+	DeliveryDropNewest = interfacesv1.DeliveryDropNewest
+	DeliveryUnbounded  = interfacesv1.DeliveryUnbounded
+)
@@ -29,6 +29,13 @@ func WithCustomHeaders(ctx context.Context, headers http.Header) context.Context
 	return interfacesv1.WithCustomHeaders(ctx, headers)
 }
 
+// api key
+type APIKeyContext = interfacesv1.APIKeyContext
+
+func WithAPIKey(ctx context.Context) context.Context {
+	return interfacesv1.WithAPIKey(ctx)
+}
+
 // parameters
 type ParametersContext = interfacesv1.ParametersContext
 
@@ -41,3 +48,9 @@ type RawResponse = interfacesv1.RawResponse
 type DeepgramWarning = interfacesv1.DeepgramWarning
 type DeepgramError = interfacesv1.DeepgramError
 type StatusError = interfacesv1.StatusError
+
+// option validation
+type OptionsError = interfacesv1.OptionsError
+type OptionsProblem = interfacesv1.OptionsProblem
+
+var ErrInvalidOptions = interfacesv1.ErrInvalidOptions
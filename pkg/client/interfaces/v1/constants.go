@@ -16,8 +16,40 @@ const (
 	TypeSettings = "Settings"
 )
 
+const (
+	// minUtteranceEndMs is the smallest utterance_end_ms the platform accepts
+	minUtteranceEndMs int = 1000
+)
+
+// option values accepted by Check()
+var (
+	transcriptionEncodings = []string{"linear16", "linear32", "flac", "alaw", "mulaw", "amr-nb", "amr-wb", "opus", "ogg-opus", "speex", "g729"}
+	rawEncodings           = []string{"linear16", "linear32", "alaw", "mulaw", "amr-nb", "amr-wb", "opus", "speex", "g729"}
+	callbackMethods        = []string{"POST", "PUT"}
+	customModes            = []string{"extended", "strict"}
+	summarizeValues        = []string{"true", "false", "v2"}
+
+	speakEncodings      = []string{"linear16", "mulaw", "alaw", "mp3", "opus", "flac", "aac"}
+	speakContainers     = []string{"none", "wav", "ogg"}
+	wsSpeakEncodings    = []string{"linear16", "mulaw", "alaw"}
+	agentInputEncodings = []string{"linear16", "linear32", "flac", "alaw", "mulaw", "amr-nb", "amr-wb", "opus", "ogg-opus", "speex", "g729"}
+	agentOutputEncoding = []string{"linear16", "mulaw", "alaw", "mp3", "opus", "flac", "aac"}
+	agentContainers     = []string{"none", "wav"}
+
+	// sample rates for the speak encodings which allow setting one
+	speakSampleRates = map[string][]int{
+		"linear16": {8000, 16000, 24000, 32000, 48000},
+		"mulaw":    {8000, 16000},
+		"alaw":     {8000, 16000},
+		"flac":     {8000, 16000, 22050, 32000, 48000},
+	}
+)
+
 // errors
 var (
 	// ErrNoAPIKey no api key found
 	ErrNoAPIKey = errors.New("no api key found")
+
+	// ErrInvalidOptions the request options failed validation
+	ErrInvalidOptions = errors.New("invalid options")
 )
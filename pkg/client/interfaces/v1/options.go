@@ -5,6 +5,7 @@
 package interfacesv1
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -56,7 +57,7 @@ func (o *ClientOptions) Parse() error {
 	// checks - ensure we have some form of authentication unless self-hosted
 	// Use thread-safe access to check credentials
 	o.credentialsMutex.RLock()
-	hasCredentials := o.AccessToken != "" || o.APIKey != ""
+	hasCredentials := o.AccessToken != "" || o.APIKey != "" || o.TokenSource != nil
 	o.credentialsMutex.RUnlock()
 
 	if !o.SelfHosted && !hasCredentials {
@@ -117,39 +118,120 @@ func (o *ClientOptions) InspectSpeakMessage() bool {
 	return o.AutoFlushSpeakDelta != 0
 }
 
+// Check validates the options and returns an *OptionsError listing every problem found
 func (o *PreRecordedTranscriptionOptions) Check() error {
-	// checks
-	// currently no op
+	if o == nil {
+		return nil
+	}
 
-	return nil
+	v := newValidator("PreRecordedTranscriptionOptions")
+	v.transcription(o.Model, o.Encoding, o.SampleRate, o.Channels, o.Alternatives, o.Keyterm, o.Keywords, o.Dictation, o.Punctuate)
+	v.callback(o.Callback, o.CallbackMethod)
+	v.oneOf("summarize", o.Summarize, summarizeValues)
+	v.customMode("custom_intent_mode", o.CustomIntentMode, o.CustomIntent)
+	v.customMode("custom_topic_mode", o.CustomTopicMode, o.CustomTopic)
+
+	if o.UttSplit < 0 {
+		v.add("utt_split", "must not be negative, got %v", o.UttSplit)
+	}
+	if o.UttSplit > 0 && !o.Utterances {
+		v.add("utt_split", "requires utterances")
+	}
+	if o.DetectLanguage && o.Language != "" {
+		v.add("detect_language", "cannot be combined with language %q", o.Language)
+	}
+
+	return v.err()
 }
 
+// Check validates the options and returns an *OptionsError listing every problem found
 func (o *LiveTranscriptionOptions) Check() error {
-	// checks
-	// currently no op
+	if o == nil {
+		return nil
+	}
 
-	return nil
+	v := newValidator("LiveTranscriptionOptions")
+	v.transcription(o.Model, o.Encoding, o.SampleRate, o.Channels, o.Alternatives, o.Keyterm, o.Keywords, o.Dictation, o.Punctuate)
+	v.callback(o.Callback, o.CallbackMethod)
+
+	if o.Endpointing != "" && o.Endpointing != "false" && o.Endpointing != "true" {
+		if _, ok := parseMs(o.Endpointing); !ok {
+			v.add("endpointing", "%q must be false or a number of milliseconds", o.Endpointing)
+		}
+	}
+	if o.UtteranceEndMs != "" {
+		ms, ok := parseMs(o.UtteranceEndMs)
+		if !ok || ms < minUtteranceEndMs {
+			v.add("utterance_end_ms", "%q must be a number of milliseconds of at least %d", o.UtteranceEndMs, minUtteranceEndMs)
+		}
+		if !o.InterimResults {
+			v.add("utterance_end_ms", "requires interim_results")
+		}
+	}
+
+	return v.err()
 }
 
+// Check validates the options and returns an *OptionsError listing every problem found
 func (o *AnalyzeOptions) Check() error {
-	// checks
-	// currently no op
+	if o == nil {
+		return nil
+	}
 
-	return nil
+	v := newValidator("AnalyzeOptions")
+	v.callback(o.Callback, o.CallbackMethod)
+	v.customMode("custom_intent_mode", o.CustomIntentMode, o.CustomIntent)
+	v.customMode("custom_topic_mode", o.CustomTopicMode, o.CustomTopic)
+
+	// text intelligence is English only
+	if o.Language != "" && o.Language != "en" && !strings.HasPrefix(o.Language, "en-") {
+		v.add("language", "%q is not supported, text intelligence is English only", o.Language)
+	}
+
+	return v.err()
 }
 
+// Check validates the options and returns an *OptionsError listing every problem found
 func (o *SpeakOptions) Check() error {
-	// checks
-	// currently no op
+	if o == nil {
+		return nil
+	}
 
-	return nil
+	v := newValidator("SpeakOptions")
+	v.oneOf("encoding", o.Encoding, speakEncodings)
+	v.oneOf("container", o.Container, speakContainers)
+	v.nonNegative("sample_rate", o.SampleRate)
+	v.nonNegative("bit_rate", o.BitRate)
+	v.callback(o.Callback, o.CallbackMethod)
+	v.speakAudio(o.Encoding, o.SampleRate, o.BitRate)
+
+	switch o.Container {
+	case "wav":
+		if o.Encoding != "" && o.Encoding != "linear16" && o.Encoding != "mulaw" && o.Encoding != "alaw" {
+			v.add("container", "wav is not supported with encoding %q", o.Encoding)
+		}
+	case "ogg":
+		if o.Encoding != "opus" {
+			v.add("container", "ogg requires encoding opus")
+		}
+	}
+
+	return v.err()
 }
 
+// Check validates the options and returns an *OptionsError listing every problem found
 func (o *WSSpeakOptions) Check() error {
-	// checks
-	// currently no op
+	if o == nil {
+		return nil
+	}
 
-	return nil
+	v := newValidator("WSSpeakOptions")
+	v.oneOf("encoding", o.Encoding, wsSpeakEncodings)
+	v.nonNegative("sample_rate", o.SampleRate)
+	v.nonNegative("bit_rate", o.BitRate)
+	v.speakAudio(o.Encoding, o.SampleRate, o.BitRate)
+
+	return v.err()
 }
 
 func NewSettingsOptions() *SettingsOptions {
@@ -193,9 +275,74 @@ func NewSettingsOptions() *SettingsOptions {
 
 	return options
 }
+
+// Check validates the options and returns an *OptionsError listing every problem found
 func (o *SettingsOptions) Check() error {
-	// checks
-	// currently no op
+	if o == nil {
+		return nil
+	}
 
-	return nil
+	v := newValidator("SettingsOptions")
+	if o.Type != TypeSettings {
+		v.add("type", "must be %q, got %q", TypeSettings, o.Type)
+	}
+
+	if in := o.Audio.Input; in != nil {
+		v.oneOf("audio.input.encoding", in.Encoding, agentInputEncodings)
+		v.nonNegative("audio.input.sample_rate", in.SampleRate)
+		if isRawEncoding(in.Encoding) && in.SampleRate == 0 {
+			v.add("audio.input.sample_rate", "is required for encoding %q", in.Encoding)
+		}
+	}
+	if out := o.Audio.Output; out != nil {
+		v.oneOf("audio.output.encoding", out.Encoding, agentOutputEncoding)
+		v.oneOf("audio.output.container", out.Container, agentContainers)
+		v.nonNegative("audio.output.sample_rate", out.SampleRate)
+		v.nonNegative("audio.output.bitrate", out.Bitrate)
+	}
+
+	if providerType(o.Agent.Listen.Provider) == "" && o.Agent.Listen.Provider != nil {
+		v.add("agent.listen.provider.type", "is required")
+	}
+	if providerType(o.Agent.Think.Provider) == "" {
+		v.add("agent.think.provider.type", "is required")
+	}
+	if providerType(o.Agent.Speak.Provider) == "" && o.Agent.Speak.Endpoint == nil {
+		v.add("agent.speak.provider.type", "is required")
+	}
+	if o.Agent.SpeakFallback != nil {
+		for i, speak := range *o.Agent.SpeakFallback {
+			if providerType(speak.Provider) == "" {
+				v.add(fmt.Sprintf("agent.speak_fallback[%d].provider.type", i), "is required")
+			}
+		}
+	}
+
+	switch cl := o.Agent.Think.ContextLength.(type) {
+	case nil:
+	case int:
+		if cl <= 0 {
+			v.add("agent.think.context_length", "must be positive, got %d", cl)
+		}
+	case float64:
+		if cl <= 0 || cl != float64(int(cl)) {
+			v.add("agent.think.context_length", "must be a positive integer, got %v", cl)
+		}
+	case string:
+		if cl != "max" {
+			v.add("agent.think.context_length", "must be an integer or \"max\", got %q", cl)
+		}
+	default:
+		v.add("agent.think.context_length", "must be an integer or \"max\", got %T", cl)
+	}
+
+	if o.Agent.Think.Functions != nil {
+		for i, fn := range *o.Agent.Think.Functions {
+			if fn.Name == "" {
+				v.add(fmt.Sprintf("agent.think.functions[%d].name", i), "is required")
+			}
+		}
+	}
+
+	return v.err()
 }
@@ -5,15 +5,18 @@
 package interfacesv1
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 )
 
 // ClientOptions defines any options for the client
 type ClientOptions struct {
 	APIKey            string
 	AccessToken       string                                // JWT access token for Bearer authentication
+	TokenSource       TokenSource                           // supplies (and refreshes) the AccessToken before each request
 	Host              string                                // override for the host endpoint
 	APIVersion        string                                // override for the version used
 	Path              string                                // override for the endpoint path usually <version/listen> or <version/projects>
@@ -34,10 +37,30 @@ type ClientOptions struct {
 	// text-to-speech client options
 	AutoFlushSpeakDelta int64 // enables the auto flush feature based on the delta in milliseconds
 
+	// websocket channel client options
+	ChanDelivery *ChanDeliveryOptions // backpressure policy for channel subscribers, nil blocks
+
+	// websocket client options
+	AsyncWrite   *AsyncWriteOptions   // queue writes for a background writer, nil writes synchronously
+	StreamPacing *StreamPacingOptions // send Stream audio at real time speed, nil sends it as fast as it is read
+
 	// Thread safety for credential management
 	credentialsMutex sync.RWMutex // protects AccessToken and APIKey fields
 }
 
+/*
+TokenSource supplies short-lived access tokens for Bearer authentication.
+
+When set on ClientOptions, a token is obtained before every REST request and websocket dial.
+A REST request which fails with 401 Unauthorized is retried once after calling Invalidate.
+*/
+type TokenSource interface {
+	// Token returns a valid access token, fetching a new one if the current one is about to expire
+	Token(ctx context.Context) (string, error)
+	// Invalidate discards the current token so the next call to Token fetches a new one
+	Invalidate()
+}
+
 // SetAccessToken dynamically sets the access token for Bearer authentication (thread-safe)
 func (o *ClientOptions) SetAccessToken(accessToken string) {
 	o.credentialsMutex.Lock()
@@ -63,3 +86,95 @@ func (o *ClientOptions) GetAuthToken() (token string, isBearer bool) {
 	}
 	return o.APIKey, false
 }
+
+// GetAuthTokenContext is GetAuthToken, except that it returns the APIKey for a context created with WithAPIKey (thread-safe)
+func (o *ClientOptions) GetAuthTokenContext(ctx context.Context) (token string, isBearer bool) {
+	if useAPIKey, _ := ctx.Value(APIKeyContext{}).(bool); useAPIKey {
+		o.credentialsMutex.RLock()
+		defer o.credentialsMutex.RUnlock()
+		return o.APIKey, false
+	}
+	return o.GetAuthToken()
+}
+
+// RefreshAuthToken obtains a token from the TokenSource, if there is one, and stores it as the AccessToken (thread-safe)
+func (o *ClientOptions) RefreshAuthToken(ctx context.Context) error {
+	if o.TokenSource == nil {
+		return nil
+	}
+
+	token, err := o.TokenSource.Token(ctx)
+	if err != nil {
+		return err
+	}
+	o.SetAccessToken(token)
+
+	return nil
+}
+
+// InvalidateAuthToken discards the token held by the TokenSource, if there is one
+func (o *ClientOptions) InvalidateAuthToken() {
+	if o.TokenSource != nil {
+		o.TokenSource.Invalidate()
+	}
+}
+
+// DeliveryPolicy controls what happens when a channel subscriber is not keeping up with the websocket
+type DeliveryPolicy string
+
+const (
+	// DeliveryBlock waits for the subscriber, which stalls reading from the websocket (default)
+	DeliveryBlock DeliveryPolicy = "block"
+	// DeliveryDropOldest discards the oldest buffered message to make room
+	DeliveryDropOldest DeliveryPolicy = "drop_oldest"
+	// DeliveryDropNewest discards the new message when the channel is full
+	DeliveryDropNewest DeliveryPolicy = "drop_newest"
+	// DeliveryUnbounded queues messages in memory without limit
+	DeliveryUnbounded DeliveryPolicy = "unbounded"
+)
+
+// ChanDeliveryOptions configures how the websocket channel routers deliver to slow subscribers
+type ChanDeliveryOptions struct {
+	Policy   DeliveryPolicy            // default policy for every channel, DeliveryBlock if empty
+	Policies map[string]DeliveryPolicy // overrides by message type, e.g. "Results" or "Binary"
+
+	SlowConsumerThreshold time.Duration // warn when a send blocks this long, or about drops at most this often. Defaults to 1s.
+	QueueWarning          int           // warn when an unbounded queue grows past this. Defaults to 1000.
+
+	// OnSlowConsumer is called from the websocket read goroutine, so it must not block
+	OnSlowConsumer func(event *SlowConsumerEvent)
+}
+
+// SlowConsumerEvent reports a channel subscriber which is not keeping up
+type SlowConsumerEvent struct {
+	Subscriber  string         // message type and index of the channel, e.g. "Results#0"
+	MessageType string         // type of message being delivered
+	Policy      DeliveryPolicy // policy in effect for the subscriber
+	Blocked     time.Duration  // how long the send has been blocked (DeliveryBlock)
+	Queued      int            // messages waiting (DeliveryUnbounded)
+	Dropped     uint64         // messages dropped so far
+}
+
+/*
+AsyncWriteOptions enables a background writer for websocket clients. Writes are queued instead of
+waiting for the connection, and control messages (e.g. KeepAlive, Finalize) are sent ahead of any
+audio still waiting in the queue.
+*/
+type AsyncWriteOptions struct {
+	QueueSize int // audio chunks which can wait to be sent. Defaults to 100.
+
+	// FrameDuration coalesces small audio chunks into frames of up to this much audio. A partial
+	// frame is sent once it has waited this long. Requires BytesPerSecond, 0 disables coalescing.
+	FrameDuration  time.Duration
+	BytesPerSecond int // e.g. 32000 for 16kHz mono linear16
+}
+
+/*
+StreamPacingOptions makes Stream send audio like a live source would, computing each frame's duration
+from the encoding, sample rate and channels in the transcription or agent options. Only raw audio
+(linear16, linear32, mulaw or alaw) can be paced.
+*/
+type StreamPacingOptions struct {
+	FrameMs int     // audio sent per frame, defaults to 20ms
+	Speed   float64 // multiple of real time, e.g. 2 for twice as fast. Defaults to 1.
+}
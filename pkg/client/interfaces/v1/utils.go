@@ -53,6 +53,14 @@ func WithCustomHeaders(ctx context.Context, headers http.Header) context.Context
 	return context.WithValue(ctx, HeadersContext{}, headers)
 }
 
+// APIKeyContext blackbox of data
+type APIKeyContext struct{}
+
+// WithAPIKey authenticates requests made with the context using the API key, ignoring any TokenSource or AccessToken
+func WithAPIKey(ctx context.Context) context.Context {
+	return context.WithValue(ctx, APIKeyContext{}, true)
+}
+
 // ParametersContext blackbox of data
 type ParametersContext struct{}
 
@@ -0,0 +1,208 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package interfacesv1
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OptionsProblem is a single problem found in a set of options
+type OptionsProblem struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+/*
+OptionsError is returned by the Check() methods and lists every problem found in a set of options.
+
+errors.Is(err, ErrInvalidOptions) is true for an OptionsError.
+*/
+type OptionsError struct {
+	Options  string
+	Problems []OptionsProblem
+}
+
+// Error string representation for a given error
+func (e *OptionsError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		messages = append(messages, fmt.Sprintf("%s: %s", p.Field, p.Message))
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Options, strings.Join(messages, "; "))
+}
+
+// Is allows errors.Is(err, ErrInvalidOptions)
+func (e *OptionsError) Is(target error) bool {
+	return target == ErrInvalidOptions
+}
+
+// validator collects problems for a set of options
+type validator struct {
+	options  string
+	problems []OptionsProblem
+}
+
+func newValidator(options string) *validator {
+	return &validator{options: options}
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.problems = append(v.problems, OptionsProblem{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &OptionsError{Options: v.options, Problems: v.problems}
+}
+
+func (v *validator) oneOf(field, value string, allowed []string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(field, "%q is not one of %s", value, strings.Join(allowed, ", "))
+}
+
+func (v *validator) nonNegative(field string, value int) {
+	if value < 0 {
+		v.add(field, "must not be negative, got %d", value)
+	}
+}
+
+func (v *validator) callback(callback, method string) {
+	if callback != "" {
+		u, err := url.Parse(callback)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			v.add("callback", "%q is not an http(s) URL", callback)
+		}
+	}
+	if method == "" {
+		return
+	}
+	v.oneOf("callback_method", method, callbackMethods)
+	if callback == "" {
+		v.add("callback_method", "requires callback")
+	}
+}
+
+// customMode checks custom_intent_mode/custom_topic_mode
+func (v *validator) customMode(field, mode string, values []string) {
+	v.oneOf(field, mode, customModes)
+	if mode != "" && len(values) == 0 {
+		v.add(field, "requires %s", strings.TrimSuffix(field, "_mode"))
+	}
+}
+
+// transcription checks the fields shared by prerecorded and live options
+func (v *validator) transcription(model, encoding string, sampleRate, channels, alternatives int, keyterm, keywords []string, dictation, punctuate bool) {
+	v.oneOf("encoding", encoding, transcriptionEncodings)
+	v.nonNegative("sample_rate", sampleRate)
+	v.nonNegative("channels", channels)
+	v.nonNegative("alternatives", alternatives)
+
+	if isRawEncoding(encoding) && sampleRate == 0 {
+		v.add("sample_rate", "is required for encoding %q", encoding)
+	}
+	if sampleRate > 0 && encoding == "" {
+		v.add("encoding", "is required when sample_rate is set")
+	}
+
+	if len(keyterm) > 0 && !isNova3(model) {
+		v.add("keyterm", "is only supported with nova-3 models, got model %q", model)
+	}
+	if len(keywords) > 0 && isNova3(model) {
+		v.add("keywords", "is not supported with nova-3 models, use keyterm instead")
+	}
+	if dictation && !punctuate {
+		v.add("dictation", "requires punctuate")
+	}
+}
+
+// speakAudio checks the sample_rate and bit_rate combinations for a speak encoding
+func (v *validator) speakAudio(encoding string, sampleRate, bitRate int) {
+	if encoding == "" {
+		return
+	}
+
+	if sampleRate > 0 {
+		rates, ok := speakSampleRates[encoding]
+		switch {
+		case !ok:
+			v.add("sample_rate", "is not configurable for encoding %q", encoding)
+		case !containsInt(rates, sampleRate):
+			v.add("sample_rate", "%d is not supported for encoding %q", sampleRate, encoding)
+		}
+	}
+
+	if bitRate > 0 {
+		switch encoding {
+		case "mp3":
+			if bitRate != 32000 && bitRate != 48000 {
+				v.add("bit_rate", "%d is not supported for encoding mp3, use 32000 or 48000", bitRate)
+			}
+		case "opus":
+			if bitRate < 4000 || bitRate > 650000 {
+				v.add("bit_rate", "%d is out of range 4000-650000 for encoding opus", bitRate)
+			}
+		case "aac":
+			if bitRate < 4000 || bitRate > 192000 {
+				v.add("bit_rate", "%d is out of range 4000-192000 for encoding aac", bitRate)
+			}
+		default:
+			v.add("bit_rate", "is not configurable for encoding %q", encoding)
+		}
+	}
+}
+
+/*
+helpers
+*/
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isRawEncoding(encoding string) bool {
+	for _, e := range rawEncodings {
+		if encoding == e {
+			return true
+		}
+	}
+	return false
+}
+
+func isNova3(model string) bool {
+	return strings.HasPrefix(model, "nova-3")
+}
+
+// parseMs parses a millisecond value sent as a string
+func parseMs(value string) (int, bool) {
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 0 {
+		return 0, false
+	}
+	return i, true
+}
+
+func providerType(provider map[string]interface{}) string {
+	if provider == nil {
+		return ""
+	}
+	t, _ := provider["type"].(string)
+	return t
+}
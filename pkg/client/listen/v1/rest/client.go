@@ -8,10 +8,12 @@ This package provides the prerecorded client implementation for the Deepgram API
 package restv1
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -21,6 +23,7 @@ import (
 	klog "k8s.io/klog/v2"
 
 	version "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/version"
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
 	common "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1"
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces/v1"
 )
@@ -76,9 +79,10 @@ Output parameters:
 func (c *Client) DoFile(ctx context.Context, filePath string, req *interfaces.PreRecordedTranscriptionOptions, resBody interface{}) error {
 	klog.V(6).Infof("prerecorded.DoFile() ENTER\n")
 
-	if len(req.Keyterm) > 0 && !strings.HasPrefix(req.Model, "nova-3") {
-		klog.V(1).Info("Keyterms are only supported with nova-3 models.")
-		return nil
+	if err := req.Check(); err != nil {
+		klog.V(1).Infof("PreRecordedTranscriptionOptions.Check() failed. Err: %v\n", err)
+		klog.V(6).Infof("prerecorded.DoFile() LEAVE\n")
+		return err
 	}
 
 	// file?
@@ -111,6 +115,11 @@ func (c *Client) DoFile(ctx context.Context, filePath string, req *interfaces.Pr
 /*
 DoStream posts a stream capturing a conversation to a given REST endpoint
 
+The leading bytes of src are sniffed, without consuming them, to set the Content-Type of WAV, FLAC,
+MP3, Ogg, WebM, MP4/M4A and AAC. Headerless audio needs its format, either from options.Encoding or
+from src being an audio.Source, whose format fills in any unset encoding, sample rate and channels.
+Text, images and other payloads which are obviously not audio fail with ErrNotAudio before uploading.
+
 Input parameters:
 - src: io.Reader containing the stream to be posted
 - req: PreRecordedTranscriptionOptions which allows overriding things like language, etc.
@@ -122,11 +131,27 @@ Output parameters:
 func (c *Client) DoStream(ctx context.Context, src io.Reader, options *interfaces.PreRecordedTranscriptionOptions, resBody interface{}) error {
 	klog.V(6).Infof("prerecorded.DoStream() ENTER\n")
 
-	if len(options.Keyterm) > 0 && !strings.HasPrefix(options.Model, "nova-3") {
-		klog.V(1).Info("Keyterms are only supported with nova-3 models.")
-		return nil
+	if err := options.Check(); err != nil {
+		klog.V(1).Infof("PreRecordedTranscriptionOptions.Check() failed. Err: %v\n", err)
+		klog.V(6).Infof("prerecorded.DoStream() LEAVE\n")
+		return err
 	}
 
+	// sniff the container without consuming it
+	header, body, err := peek(src)
+	if err != nil {
+		klog.V(1).Infof("peek failed. Err: %v\n", err)
+		klog.V(6).Infof("prerecorded.DoStream() LEAVE\n")
+		return err
+	}
+	contentType, options, err := detectAudio(header, src, options)
+	if err != nil {
+		klog.V(1).Infof("detectAudio failed. Err: %v\n", err)
+		klog.V(6).Infof("prerecorded.DoStream() LEAVE\n")
+		return err
+	}
+	klog.V(4).Infof("DoStream Content-Type: %q\n", contentType)
+
 	uri, err := version.GetPrerecordedAPI(ctx, c.Options.Host, c.Options.APIVersion, c.Options.Path, options)
 	if err != nil {
 		klog.V(1).Infof("GetPrerecordedAPI failed. Err: %v\n", err)
@@ -137,12 +162,15 @@ func (c *Client) DoStream(ctx context.Context, src io.Reader, options *interface
 	// the Common.SetupRequest (c.SetupRequest vs c.RESTClient.SetupRequest) method, sets
 	// additional "typical" headers like content-type, etc.
 	// but we want RESTClient.SetupRequest only provides the basic headers in this caser
-	req, err := c.RESTClient.Client.SetupRequest(ctx, "POST", uri, src)
+	req, err := c.RESTClient.Client.SetupRequest(ctx, "POST", uri, body)
 	if err != nil {
 		klog.V(1).Infof("SetupRequest failed. Err: %v\n", err)
 		klog.V(6).Infof("prerecorded.DoStream() LEAVE\n")
 		return err
 	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
 	// altertatively, we could have used the Common Client Do method, like this
 	// but the default one also sets additional "typical" headers like
@@ -189,6 +217,12 @@ func (c *Client) DoURL(ctx context.Context, audioURL string, options *interfaces
 		return ErrInvalidInput
 	}
 
+	if err := options.Check(); err != nil {
+		klog.V(1).Infof("PreRecordedTranscriptionOptions.Check() failed. Err: %v\n", err)
+		klog.V(6).Infof("prerecorded.DoURL() LEAVE\n")
+		return err
+	}
+
 	uri, err := version.GetPrerecordedAPI(ctx, c.Options.Host, c.Options.APIVersion, c.Options.Path, options)
 	if err != nil {
 		klog.V(1).Infof("GetPrerecordedAPI failed. Err: %v\n", err)
@@ -230,3 +264,87 @@ func (c *Client) DoURL(ctx context.Context, audioURL string, options *interfaces
 
 	return err
 }
+
+/*
+helpers
+*/
+// peek returns the leading bytes of src and a reader which still starts with them
+func peek(src io.Reader) ([]byte, io.Reader, error) {
+	switch r := src.(type) {
+	case *bytes.Buffer:
+		header := r.Bytes()
+		if len(header) > sniffLen {
+			header = header[:sniffLen]
+		}
+		return header, r, nil
+	case io.ReadSeeker:
+		// keeps the request's ContentLength for the in memory readers. An *os.File for a pipe,
+		// like os.Stdin, fails to seek and is buffered below instead.
+		pos, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			klog.V(4).Infof("peek: reader is not seekable, buffering instead. Err: %v\n", err)
+			break
+		}
+		header := make([]byte, sniffLen)
+		n, err := io.ReadFull(r, header)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, nil, err
+		}
+		if _, err := r.Seek(pos, io.SeekStart); err != nil {
+			return nil, nil, err
+		}
+		return header[:n], r, nil
+	}
+
+	br := bufio.NewReaderSize(src, sniffLen)
+	header, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	return header, br, nil
+}
+
+// detectAudio picks the Content-Type for the stream, filling in the options for headerless audio
+func detectAudio(header []byte, src io.Reader, options *interfaces.PreRecordedTranscriptionOptions) (string, *interfaces.PreRecordedTranscriptionOptions, error) {
+	if len(header) == 0 {
+		return "", nil, ErrNotAudio
+	}
+	if contentType := audio.DetectContentType(header); contentType != "" {
+		return contentType, options, nil
+	}
+
+	// headerless audio in a declared format
+	if source, ok := src.(audio.Source); ok {
+		format := source.Format()
+
+		filled := interfaces.PreRecordedTranscriptionOptions{}
+		if options != nil {
+			filled = *options
+		}
+		if filled.Encoding == "" {
+			filled.Encoding = format.Encoding
+		}
+		if filled.SampleRate == 0 {
+			filled.SampleRate = format.SampleRate
+		}
+		if filled.Channels == 0 && format.Channels > 1 {
+			filled.Channels = format.Channels
+		}
+		return rawContentType, &filled, nil
+	}
+	if options != nil && options.Encoding != "" {
+		return rawContentType, options, nil
+	}
+
+	// a container Deepgram may still decode is sent as before, without a Content-Type
+	sniffed := http.DetectContentType(header)
+	switch {
+	case sniffed == rawContentType:
+		return "", options, nil
+	case strings.HasPrefix(sniffed, "audio/"), strings.HasPrefix(sniffed, "video/"), sniffed == "application/ogg":
+		return sniffed, options, nil
+	}
+
+	klog.V(1).Infof("the payload looks like %s\n", sniffed)
+	return "", nil, fmt.Errorf("%w: looks like %s", ErrNotAudio, sniffed)
+}
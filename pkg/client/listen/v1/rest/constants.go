@@ -12,8 +12,19 @@ const (
 	PackageVersion string = "v1.0"
 )
 
+const (
+	// leading bytes sniffed by DoStream, as many as http.DetectContentType considers
+	sniffLen int = 512
+
+	// Content-Type of headerless audio
+	rawContentType string = "application/octet-stream"
+)
+
 // errors
 var (
 	// ErrInvalidInput required input was not found
 	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrNotAudio the DoStream payload is empty or obviously not audio
+	ErrNotAudio = errors.New("the payload is not audio")
 )
@@ -20,6 +20,8 @@ import (
 
 	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
 	version "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/version"
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
+	pacing "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/pacing"
 	common "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1"
 )
 
@@ -37,6 +39,21 @@ func (c *WSCallback) ConnectWithCancel(ctx context.Context, ctxCancel context.Ca
 	return c.WSClient.ConnectWithCancel(ctx, ctxCancel, retryCnt)
 }
 
+// ConnectContext performs a websocket connection with "DefaultConnectRetry" number of retries and
+// returns why the connection failed, see common.HandshakeError and common.ConnectError
+func (c *WSCallback) ConnectContext(ctx context.Context) error {
+	c.ctx, c.ctxCancel = context.WithCancel(ctx)
+	return c.ConnectContextWithCancel(c.ctx, c.ctxCancel, int(DefaultConnectRetry))
+}
+
+// ConnectContextWithCancel performs a websocket connection with specified number of retries and providing a
+// cancel function to stop the connection. It returns why the connection failed.
+func (c *WSCallback) ConnectContextWithCancel(ctx context.Context, ctxCancel context.CancelFunc, retryCnt int) error {
+	c.ctx = ctx
+	c.ctxCancel = ctxCancel
+	return c.WSClient.ConnectContextWithCancel(ctx, ctxCancel, retryCnt)
+}
+
 // AttemptReconnect performs a reconnect after failing retries
 func (c *WSCallback) AttemptReconnect(ctx context.Context, retries int64) bool {
 	c.ctx, c.ctxCancel = context.WithCancel(ctx)
@@ -107,6 +124,11 @@ func (c *WSCallback) ProcessMessage(wsType int, byMsg []byte) error {
 func (c *WSCallback) Stream(r io.Reader) error {
 	klog.V(6).Infof("live.Stream() ENTER\n")
 
+	if c.cOptions.StreamPacing != nil {
+		klog.V(6).Infof("live.Stream() LEAVE\n")
+		return c.streamPaced(r)
+	}
+
 	chunk := make([]byte, ChunkSize)
 
 	for {
@@ -159,6 +181,95 @@ func (c *WSCallback) Stream(r io.Reader) error {
 	}
 }
 
+/*
+StreamSource streams src until it ends or ctx is done. When the client isn't connected yet, the
+encoding, sample rate and channels are set from src.Format() before connecting. Once connected the
+options can't change, so src must match them. src is not closed.
+*/
+func (c *WSCallback) StreamSource(ctx context.Context, src audio.Source) error {
+	klog.V(6).Infof("live.StreamSource() ENTER\n")
+
+	format := src.Format()
+	if err := format.Validate(); err != nil {
+		klog.V(1).Infof("StreamSource: invalid format %+v. Err: %v\n", format, err)
+		klog.V(6).Infof("live.StreamSource() LEAVE\n")
+		return err
+	}
+
+	switch c.State() {
+	case common.StateIdle, common.StateClosed:
+		c.tOptions.Encoding = format.Encoding
+		c.tOptions.SampleRate = format.SampleRate
+		c.tOptions.Channels = format.Channels
+		if err := c.ConnectContext(c.ctx); err != nil {
+			klog.V(1).Infof("StreamSource: connect failed. Err: %v\n", err)
+			klog.V(6).Infof("live.StreamSource() LEAVE\n")
+			return err
+		}
+	default:
+		connected := audio.Format{Encoding: c.tOptions.Encoding, SampleRate: c.tOptions.SampleRate, Channels: c.tOptions.Channels}
+		if !format.Equal(connected) {
+			klog.V(1).Infof("StreamSource: source %+v, connected with %+v\n", format, connected)
+			klog.V(6).Infof("live.StreamSource() LEAVE\n")
+			return audio.ErrFormatMismatch
+		}
+	}
+
+	err := c.Stream(audio.ContextReader(ctx, src))
+	if err == io.EOF {
+		err = nil
+	}
+
+	klog.V(6).Infof("live.StreamSource() LEAVE\n")
+	return err
+}
+
+// streamPaced streams r at real time speed, see ClientOptions.StreamPacing
+func (c *WSCallback) streamPaced(r io.Reader) error {
+	klog.V(6).Infof("live.streamPaced() ENTER\n")
+
+	pacer, err := pacing.New(pacing.Options{
+		Encoding:   c.tOptions.Encoding,
+		SampleRate: c.tOptions.SampleRate,
+		Channels:   c.tOptions.Channels,
+		FrameMs:    c.cOptions.StreamPacing.FrameMs,
+		Speed:      c.cOptions.StreamPacing.Speed,
+	})
+	if err != nil {
+		klog.V(1).Infof("pacing.New failed. Err: %v\n", err)
+		klog.V(6).Infof("live.streamPaced() LEAVE\n")
+		return err
+	}
+	c.pacer.Store(pacer)
+
+	_, err = pacer.Copy(c.ctx, c, r)
+	switch {
+	case c.ctx.Err() != nil:
+		klog.V(2).Infof("stream object Done()\n")
+		err = nil
+	case err == nil:
+		// the same as Stream reaching the end of r
+		klog.V(3).Infof("stream object EOF\n")
+		err = io.EOF
+	default:
+		klog.V(1).Infof("pacer.Copy failed. Err: %v\n", err)
+	}
+
+	klog.V(6).Infof("live.streamPaced() LEAVE\n")
+	return err
+}
+
+/*
+AudioPosition is how much audio a paced Stream has sent, for measuring transcription latency.
+It is zero without ClientOptions.StreamPacing.
+*/
+func (c *WSCallback) AudioPosition() time.Duration {
+	if pacer := c.pacer.Load(); pacer != nil {
+		return pacer.Position()
+	}
+	return 0
+}
+
 /*
 Write performs the lower level websocket write operation.
 This is needed to implement the io.Writer interface. (aka the streaming interface)
@@ -219,6 +330,37 @@ func (c *WSCallback) Finalize() error {
 	return err
 }
 
+/*
+FinishContext gracefully finishes the stream. It sends CloseStream and waits for the final results, the
+closing Metadata and for the server to close the connection, or for ctx to end. The client is stopped
+either way. Use this instead of Stop so the last transcripts are not lost.
+*/
+func (c *WSCallback) FinishContext(ctx context.Context) (*msginterfaces.MetadataResponse, error) {
+	klog.V(6).Infof("live.FinishContext() ENTER\n")
+
+	byMsg, err := c.Drain(ctx, common.DrainOptions{
+		Message:   c.GetCloseMsg(),
+		Match:     common.MatchType(string(msginterfaces.TypeMetadataResponse)),
+		WaitClose: true,
+	})
+	if byMsg == nil {
+		klog.V(1).Infof("FinishContext failed. Err: %v\n", err)
+		klog.V(6).Infof("live.FinishContext() LEAVE\n")
+		return nil, err
+	}
+
+	var metadata msginterfaces.MetadataResponse
+	if jsonErr := json.Unmarshal(byMsg, &metadata); jsonErr != nil {
+		klog.V(1).Infof("json.Unmarshal(MetadataResponse) failed. Err: %v\n", jsonErr)
+		klog.V(6).Infof("live.FinishContext() LEAVE\n")
+		return nil, jsonErr
+	}
+
+	klog.V(3).Infof("FinishContext Succeeded\n")
+	klog.V(6).Infof("live.FinishContext() LEAVE\n")
+	return &metadata, err
+}
+
 // GetCloseMsg sends an application level message to Deepgram
 func (c *WSCallback) GetCloseMsg() []byte {
 	return []byte("{ \"type\": \"CloseStream\" }")
@@ -7,9 +7,11 @@ package websocketv1
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	msginterface "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+	pacing "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/pacing"
 	common "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1"
 	commoninterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1/interfaces"
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
@@ -39,6 +41,9 @@ type WSCallback struct {
 	// internal constants for retry, waits, back-off, etc.
 	lastDatagram *time.Time
 	muFinal      sync.RWMutex
+
+	// audio position of a paced Stream
+	pacer atomic.Pointer[pacing.Pacer]
 }
 
 // WSChannel is a struct representing the websocket client connection using channels
@@ -56,4 +61,7 @@ type WSChannel struct {
 	// internal constants for retry, waits, back-off, etc.
 	lastDatagram *time.Time
 	muFinal      sync.RWMutex
+
+	// audio position of a paced Stream
+	pacer atomic.Pointer[pacing.Pacer]
 }
@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"time"
 
+	klog "k8s.io/klog/v2"
+
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
 )
 
@@ -32,15 +34,57 @@ func NewHTTPClient(options *interfaces.ClientOptions) *HTTPClient {
 	return &c
 }
 
-// Do performs a simple HTTP-style call
+/*
+Do performs a simple HTTP-style call
+
+If the ClientOptions have a TokenSource, a current access token is set on the request and
+a 401 Unauthorized response is retried once with a freshly obtained token.
+A Signer added to the context with WithSigner signs every attempt just before it is sent.
+*/
 func (c *HTTPClient) Do(ctx context.Context, req *http.Request, f func(*http.Response) error) error {
-	// Create debugging context for this round trip
-	d := c.d.newRoundTrip()
-	if d.enabled() {
-		defer d.done()
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	for attempt := 0; ; attempt++ {
+		if err := c.authorize(ctx, req); err != nil {
+			klog.V(1).Infof("TokenSource.Token failed. Err: %v\n", err)
+			return err
+		}
+
+		// signing is last so the signature covers every header, including a refreshed token
+		if signer, ok := ctx.Value(interfaces.SignerContext{}).(interfaces.Signer); ok && signer != nil {
+			if err := signer.SignRequest(req); err != nil {
+				klog.V(1).Infof("SignRequest failed. Err: %v\n", err)
+				return err
+			}
+		}
+
+		res, d, err := c.roundTrip(ctx, req)
+		if d.enabled() {
+			defer d.done()
+		}
+		if err != nil {
+			return err
+		}
+
+		if attempt == 0 && res.StatusCode == http.StatusUnauthorized && c.hasTokenSource(ctx) {
+			if retry, ok := rewind(ctx, req); ok {
+				klog.V(3).Infof("Request unauthorized. Refreshing access token and retrying.\n")
+				res.Body.Close()
+				c.options.InvalidateAuthToken()
+				req = retry
+				continue
+			}
+		}
+
+		defer res.Body.Close()
+		return f(res)
 	}
+}
 
-	req.Header.Set("User-Agent", c.UserAgent)
+// roundTrip sends the request, capturing it when debugging is enabled. The caller marks the debugRoundTrip done.
+func (c *HTTPClient) roundTrip(ctx context.Context, req *http.Request) (*http.Response, *debugRoundTrip, error) {
+	// Create debugging context for this round trip
+	d := c.d.newRoundTrip()
 
 	ext := ""
 	if d.enabled() {
@@ -57,13 +101,54 @@ func (c *HTTPClient) Do(ctx context.Context, req *http.Request, f func(*http.Res
 	}
 
 	if err != nil {
-		return err
+		return nil, d, err
 	}
 
 	if d.enabled() {
 		d.debugResponse(res, ext)
 	}
 
-	defer res.Body.Close()
-	return f(res)
+	return res, d, nil
+}
+
+// hasTokenSource is false for a context created with WithAPIKey, such as the grant made by a TokenRefresher
+func (c *HTTPClient) hasTokenSource(ctx context.Context) bool {
+	if useAPIKey, _ := ctx.Value(interfaces.APIKeyContext{}).(bool); useAPIKey {
+		return false
+	}
+	return c.options != nil && c.options.TokenSource != nil
+}
+
+// authorize sets the Bearer token from the TokenSource, if there is one
+func (c *HTTPClient) authorize(ctx context.Context, req *http.Request) error {
+	if !c.hasTokenSource(ctx) {
+		return nil
+	}
+
+	if err := c.options.RefreshAuthToken(ctx); err != nil {
+		return err
+	}
+	token, _ := c.options.GetAuthToken()
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return nil
+}
+
+// rewind returns a copy of the request which can be sent again, if the body allows it
+func rewind(ctx context.Context, req *http.Request) (*http.Request, bool) {
+	retry := req.Clone(ctx)
+	if req.Body == nil || req.Body == http.NoBody {
+		return retry, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	retry.Body = body
+
+	return retry, true
 }
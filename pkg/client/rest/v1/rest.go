@@ -68,8 +68,8 @@ func (c *Client) SetupRequest(ctx context.Context, method, uri string, body io.R
 	req.Header.Set("Host", c.Options.Host)
 	req.Header.Set("Accept", "application/json")
 
-	// Set Authorization header based on priority: AccessToken (Bearer) > APIKey (Token)
-	token, isBearer := c.Options.GetAuthToken()
+	// Set Authorization header based on priority: AccessToken (Bearer) > APIKey (Token), unless the context asks for the APIKey
+	token, isBearer := c.Options.GetAuthTokenContext(ctx)
 	if isBearer {
 		req.Header.Set("Authorization", "Bearer "+token)
 		klog.V(4).Infof("Using Bearer authentication")
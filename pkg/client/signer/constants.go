@@ -0,0 +1,39 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package signer
+
+import (
+	"errors"
+)
+
+const (
+	PackageVersion string = "v1.0"
+)
+
+// default HMAC headers
+const (
+	DefaultSignatureHeader   string = "X-Signature"
+	DefaultTimestampHeader   string = "X-Signature-Timestamp"
+	DefaultKeyIDHeader       string = "X-Signature-Key-Id"
+	DefaultContentHashHeader string = "X-Content-Sha256"
+)
+
+// UnsignedPayload replaces the body hash when the body cannot be read without consuming it
+const UnsignedPayload string = "UNSIGNED-PAYLOAD"
+
+// errors
+var (
+	// ErrInvalidInput required input was not found
+	ErrInvalidInput = errors.New("required input was not found")
+
+	// ErrMissingSignature the request has no signature
+	ErrMissingSignature = errors.New("request is not signed")
+
+	// ErrInvalidSignature the signature does not match the request
+	ErrInvalidSignature = errors.New("request signature is invalid")
+
+	// ErrExpiredSignature the signature timestamp is outside the allowed skew
+	ErrExpiredSignature = errors.New("request signature has expired")
+)
@@ -0,0 +1,39 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package signer
+
+import (
+	"net/http"
+
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+)
+
+// NewHeader creates a HeaderSigner which sets the given headers, replacing any existing values
+func NewHeader(headers http.Header) *HeaderSigner {
+	return &HeaderSigner{headers: headers.Clone()}
+}
+
+// SignRequest implements interfaces.Signer
+func (s *HeaderSigner) SignRequest(req *http.Request) error {
+	for k, v := range s.headers {
+		req.Header.Del(k)
+		for _, v := range v {
+			req.Header.Add(k, v)
+		}
+	}
+	return nil
+}
+
+// Chain applies each signer in order, stopping at the first error
+func Chain(signers ...interfaces.Signer) interfaces.Signer {
+	return Func(func(req *http.Request) error {
+		for _, s := range signers {
+			if err := s.SignRequest(req); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
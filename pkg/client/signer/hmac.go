@@ -0,0 +1,198 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+/*
+This package provides implementations of interfaces.Signer for requests which pass through an API
+gateway or proxy that requires its own signature in addition to the Deepgram credentials.
+
+Add a signer to the context passed to any client and it is applied to every REST request and to the
+websocket handshake:
+
+	s, err := signer.NewHMAC(signer.HMACOptions{KeyID: "app-1", Secret: secret})
+	ctx = interfaces.WithSigner(ctx, s)
+*/
+package signer
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	klog "k8s.io/klog/v2"
+)
+
+// NewHMAC creates an HMACSigner
+func NewHMAC(opts HMACOptions) (*HMACSigner, error) {
+	if len(opts.Secret) == 0 {
+		klog.V(1).Infof("signer.NewHMAC: Secret is required\n")
+		return nil, ErrInvalidInput
+	}
+
+	if opts.Hash == nil {
+		opts.Hash = sha256.New
+	}
+	if opts.SignatureHeader == "" {
+		opts.SignatureHeader = DefaultSignatureHeader
+	}
+	if opts.TimestampHeader == "" {
+		opts.TimestampHeader = DefaultTimestampHeader
+	}
+	if opts.KeyIDHeader == "" {
+		opts.KeyIDHeader = DefaultKeyIDHeader
+	}
+	if opts.ContentHashHeader == "" {
+		opts.ContentHashHeader = DefaultContentHashHeader
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	return &HMACSigner{opts: opts}, nil
+}
+
+/*
+SignRequest implements interfaces.Signer. It sets the timestamp, key ID, content hash and signature headers.
+
+The body is hashed through req.GetBody so it is not consumed. Streaming bodies which cannot be
+replayed are signed as UnsignedPayload.
+*/
+func (s *HMACSigner) SignRequest(req *http.Request) error {
+	contentHash, err := bodyHash(req)
+	if err != nil {
+		klog.V(1).Infof("signer: hashing the body failed. Err: %v\n", err)
+		return err
+	}
+
+	req.Header.Set(s.opts.TimestampHeader, strconv.FormatInt(s.opts.Now().Unix(), 10))
+	req.Header.Set(s.opts.ContentHashHeader, contentHash)
+	if s.opts.KeyID != "" {
+		req.Header.Set(s.opts.KeyIDHeader, s.opts.KeyID)
+	}
+
+	req.Header.Set(s.opts.SignatureHeader, s.sign(req))
+	klog.V(4).Infof("signer: signed %s %s\n", req.Method, req.URL.Path)
+
+	return nil
+}
+
+/*
+Verify checks the signature of a request signed by SignRequest, for use on the gateway side.
+A maxSkew of 0 skips the timestamp check. The body is read and replaced so the handler can still read it.
+*/
+func (s *HMACSigner) Verify(req *http.Request, maxSkew time.Duration) error {
+	signature := req.Header.Get(s.opts.SignatureHeader)
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	if maxSkew > 0 {
+		ts, err := strconv.ParseInt(req.Header.Get(s.opts.TimestampHeader), 10, 64)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		skew := s.opts.Now().Sub(time.Unix(ts, 0))
+		if skew < -maxSkew || skew > maxSkew {
+			return ErrExpiredSignature
+		}
+	}
+
+	// the body must match its declared hash unless it was sent unsigned
+	if declared := req.Header.Get(s.opts.ContentHashHeader); declared != UnsignedPayload {
+		actual, err := readBodyHash(req)
+		if err != nil {
+			return err
+		}
+		if actual != declared {
+			return ErrInvalidSignature
+		}
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(req))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// StringToSign returns the canonical form of the request which is signed
+func (s *HMACSigner) StringToSign(req *http.Request) string {
+	var sb strings.Builder
+
+	sb.WriteString(req.Method)
+	sb.WriteByte('\n')
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	sb.WriteString(path)
+	sb.WriteByte('\n')
+	// Encode sorts by key
+	sb.WriteString(req.URL.Query().Encode())
+	sb.WriteByte('\n')
+	sb.WriteString(req.Header.Get(s.opts.TimestampHeader))
+	sb.WriteByte('\n')
+	sb.WriteString(req.Header.Get(s.opts.KeyIDHeader))
+	sb.WriteByte('\n')
+	for _, h := range s.opts.SignedHeaders {
+		fmt.Fprintf(&sb, "%s:%s\n", strings.ToLower(h), strings.TrimSpace(req.Header.Get(h)))
+	}
+	sb.WriteString(req.Header.Get(s.opts.ContentHashHeader))
+
+	return sb.String()
+}
+
+func (s *HMACSigner) sign(req *http.Request) string {
+	mac := hmac.New(s.opts.Hash, s.opts.Secret)
+	mac.Write([]byte(s.StringToSign(req)))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+/*
+helpers
+*/
+// bodyHash returns the hex sha256 of the body without consuming it
+func bodyHash(req *http.Request) (string, error) {
+	h := sha256.New()
+
+	switch {
+	case req.Body == nil || req.Body == http.NoBody:
+	case req.GetBody != nil:
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer body.Close()
+		if _, err := io.Copy(h, body); err != nil {
+			return "", err
+		}
+	default:
+		return UnsignedPayload, nil
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readBodyHash hashes an incoming request body, replacing it with an in-memory copy
+func readBodyHash(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return bodyHash(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
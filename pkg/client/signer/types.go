@@ -0,0 +1,51 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package signer
+
+import (
+	"hash"
+	"net/http"
+	"time"
+)
+
+// Func adapts a function to the interfaces.Signer interface
+type Func func(*http.Request) error
+
+// SignRequest calls f(req)
+func (f Func) SignRequest(req *http.Request) error {
+	return f(req)
+}
+
+// HMACOptions configures an HMACSigner
+type HMACOptions struct {
+	// KeyID is sent in KeyIDHeader so the gateway can pick the secret. Optional.
+	KeyID string
+	// Secret is the shared HMAC key. Required.
+	Secret []byte
+	// Hash defaults to sha256.New
+	Hash func() hash.Hash
+
+	// header names, defaulting to the Default* constants
+	SignatureHeader   string
+	TimestampHeader   string
+	KeyIDHeader       string
+	ContentHashHeader string
+
+	// SignedHeaders are included in the signature in this order, e.g. "Authorization"
+	SignedHeaders []string
+
+	// Now defaults to time.Now
+	Now func() time.Time
+}
+
+// HMACSigner signs requests with an HMAC over the method, path, query, timestamp, signed headers and body hash
+type HMACSigner struct {
+	opts HMACOptions
+}
+
+// HeaderSigner sets a fixed set of headers on every request
+type HeaderSigner struct {
+	headers http.Header
+}
@@ -71,6 +71,12 @@ Output parameters:
 func (c *Client) DoText(ctx context.Context, text string, options *interfaces.SpeakOptions, keys []string, resBody interface{}) (map[string]string, error) {
 	klog.V(6).Infof("speak.DoText() ENTER\n")
 
+	if err := options.Check(); err != nil {
+		klog.V(1).Infof("SpeakOptions.Check() failed. Err: %v\n", err)
+		klog.V(6).Infof("speak.DoText() LEAVE\n")
+		return nil, err
+	}
+
 	// obtain URL for the REST API call
 	uri, err := version.GetSpeakAPI(ctx, c.Options.Host, c.Options.APIVersion, c.Options.Path, options)
 	if err != nil {
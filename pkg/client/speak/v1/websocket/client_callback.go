@@ -36,6 +36,21 @@ func (c *WSCallback) ConnectWithCancel(ctx context.Context, ctxCancel context.Ca
 	return c.WSClient.ConnectWithCancel(ctx, ctxCancel, retryCnt)
 }
 
+// ConnectContext performs a websocket connection with "DefaultConnectRetry" number of retries and
+// returns why the connection failed, see common.HandshakeError and common.ConnectError
+func (c *WSCallback) ConnectContext(ctx context.Context) error {
+	c.ctx, c.ctxCancel = context.WithCancel(ctx)
+	return c.ConnectContextWithCancel(c.ctx, c.ctxCancel, int(DefaultConnectRetry))
+}
+
+// ConnectContextWithCancel performs a websocket connection with specified number of retries and providing a
+// cancel function to stop the connection. It returns why the connection failed.
+func (c *WSCallback) ConnectContextWithCancel(ctx context.Context, ctxCancel context.CancelFunc, retryCnt int) error {
+	c.ctx = ctx
+	c.ctxCancel = ctxCancel
+	return c.WSClient.ConnectContextWithCancel(ctx, ctxCancel, retryCnt)
+}
+
 // AttemptReconnect performs a reconnect after failing retries
 func (c *WSCallback) AttemptReconnect(ctx context.Context, retries int64) bool {
 	c.ctx, c.ctxCancel = context.WithCancel(ctx)
@@ -186,6 +201,47 @@ func (c *WSCallback) Reset() error {
 	return nil
 }
 
+/*
+FinishContext gracefully finishes the stream. It sends Flush and waits for the Flushed reply, so all
+audio for the text sent so far has been received, or for ctx to end. The client is stopped either way.
+*/
+func (c *WSCallback) FinishContext(ctx context.Context) (*msginterfaces.FlushedResponse, error) {
+	klog.V(6).Infof("speak.FinishContext() ENTER\n")
+
+	byFlush, err := json.Marshal(controlMessage{Type: MessageTypeFlush})
+	if err != nil {
+		klog.V(1).Infof("json.Marshal failed. Err: %v\n", err)
+		klog.V(6).Infof("speak.FinishContext() LEAVE\n")
+		return nil, err
+	}
+
+	// the same bookkeeping as Flush
+	c.muFinal.Lock()
+	c.flushCount++
+	c.muFinal.Unlock()
+
+	byMsg, err := c.Drain(ctx, common.DrainOptions{
+		Message: byFlush,
+		Match:   common.MatchType(string(msginterfaces.TypeFlushedResponse)),
+	})
+	if byMsg == nil {
+		klog.V(1).Infof("FinishContext failed. Err: %v\n", err)
+		klog.V(6).Infof("speak.FinishContext() LEAVE\n")
+		return nil, err
+	}
+
+	var flushed msginterfaces.FlushedResponse
+	if jsonErr := json.Unmarshal(byMsg, &flushed); jsonErr != nil {
+		klog.V(1).Infof("json.Unmarshal(FlushedResponse) failed. Err: %v\n", jsonErr)
+		klog.V(6).Infof("speak.FinishContext() LEAVE\n")
+		return nil, jsonErr
+	}
+
+	klog.V(3).Infof("FinishContext Succeeded\n")
+	klog.V(6).Infof("speak.FinishContext() LEAVE\n")
+	return &flushed, err
+}
+
 // GetCloseMsg sends an application level message to Deepgram
 func (c *WSCallback) GetCloseMsg() []byte {
 	return []byte("{ \"type\": \"Close\" }")
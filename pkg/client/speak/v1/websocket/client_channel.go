@@ -17,9 +17,11 @@ import (
 	"github.com/dvonthenen/websocket"
 	klog "k8s.io/klog/v2"
 
+	websocketv1api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/speak/v1/websocket"
 	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/speak/v1/websocket/interfaces"
 	version "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/version"
 	common "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1"
+	delivery "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1/delivery"
 )
 
 // Connect performs a websocket connection with "DefaultConnectRetry" number of retries.
@@ -36,6 +38,21 @@ func (c *WSChannel) ConnectWithCancel(ctx context.Context, ctxCancel context.Can
 	return c.WSClient.ConnectWithCancel(ctx, ctxCancel, retryCnt)
 }
 
+// ConnectContext performs a websocket connection with "DefaultConnectRetry" number of retries and
+// returns why the connection failed, see common.HandshakeError and common.ConnectError
+func (c *WSChannel) ConnectContext(ctx context.Context) error {
+	c.ctx, c.ctxCancel = context.WithCancel(ctx)
+	return c.ConnectContextWithCancel(c.ctx, c.ctxCancel, int(DefaultConnectRetry))
+}
+
+// ConnectContextWithCancel performs a websocket connection with specified number of retries and providing a
+// cancel function to stop the connection. It returns why the connection failed.
+func (c *WSChannel) ConnectContextWithCancel(ctx context.Context, ctxCancel context.CancelFunc, retryCnt int) error {
+	c.ctx = ctx
+	c.ctxCancel = ctxCancel
+	return c.WSClient.ConnectContextWithCancel(ctx, ctxCancel, retryCnt)
+}
+
 // AttemptReconnect performs a reconnect after failing retries
 func (c *WSChannel) AttemptReconnect(ctx context.Context, retries int64) bool {
 	c.ctx, c.ctxCancel = context.WithCancel(ctx)
@@ -185,6 +202,76 @@ func (c *WSChannel) Reset() error {
 	return nil
 }
 
+/*
+Subscribe adds a subscriber which receives the events matching filter, for example only Flushed.
+Subscribers can be added and removed at any time, including while connected. Call cancel when done,
+which closes the channel. The channel is also closed when the client context is done.
+*/
+func (c *WSChannel) Subscribe(filter *msginterfaces.EventFilter) (<-chan msginterfaces.Event, func()) {
+	if c.router != nil {
+		if router, ok := (*c.router).(*websocketv1api.ChanRouter); ok {
+			return router.Subscribe(filter)
+		}
+	}
+
+	klog.V(1).Infof("Subscribe is not supported by this router\n")
+	ch := make(chan msginterfaces.Event)
+	close(ch)
+	return ch, func() {}
+}
+
+// DeliveryStats returns the delivery counters for each channel subscriber, see ClientOptions.ChanDelivery
+func (c *WSChannel) DeliveryStats() map[string]delivery.Stats {
+	if c.router == nil {
+		return nil
+	}
+	if router, ok := (*c.router).(*websocketv1api.ChanRouter); ok {
+		return router.DeliveryStats()
+	}
+	return nil
+}
+
+/*
+FinishContext gracefully finishes the stream. It sends Flush and waits for the Flushed reply, so all
+audio for the text sent so far has been received, or for ctx to end. The client is stopped either way.
+*/
+func (c *WSChannel) FinishContext(ctx context.Context) (*msginterfaces.FlushedResponse, error) {
+	klog.V(6).Infof("speak.FinishContext() ENTER\n")
+
+	byFlush, err := json.Marshal(controlMessage{Type: MessageTypeFlush})
+	if err != nil {
+		klog.V(1).Infof("json.Marshal failed. Err: %v\n", err)
+		klog.V(6).Infof("speak.FinishContext() LEAVE\n")
+		return nil, err
+	}
+
+	// the same bookkeeping as Flush
+	c.muFinal.Lock()
+	c.flushCount++
+	c.muFinal.Unlock()
+
+	byMsg, err := c.Drain(ctx, common.DrainOptions{
+		Message: byFlush,
+		Match:   common.MatchType(string(msginterfaces.TypeFlushedResponse)),
+	})
+	if byMsg == nil {
+		klog.V(1).Infof("FinishContext failed. Err: %v\n", err)
+		klog.V(6).Infof("speak.FinishContext() LEAVE\n")
+		return nil, err
+	}
+
+	var flushed msginterfaces.FlushedResponse
+	if jsonErr := json.Unmarshal(byMsg, &flushed); jsonErr != nil {
+		klog.V(1).Infof("json.Unmarshal(FlushedResponse) failed. Err: %v\n", jsonErr)
+		klog.V(6).Infof("speak.FinishContext() LEAVE\n")
+		return nil, jsonErr
+	}
+
+	klog.V(3).Infof("FinishContext Succeeded\n")
+	klog.V(6).Infof("speak.FinishContext() LEAVE\n")
+	return &flushed, err
+}
+
 // GetCloseMsg sends an application level message to Deepgram
 func (c *WSChannel) GetCloseMsg() []byte {
 	return []byte("{ \"type\": \"Close\" }")
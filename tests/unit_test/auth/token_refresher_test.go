@@ -0,0 +1,188 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+
+	auth "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/auth/v1"
+	authInterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/auth/v1/interfaces"
+	authclient "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/auth"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+)
+
+// countingGrant hands out token-1, token-2, ...
+func countingGrant(expiresIn float64, calls *int32) auth.GrantFunc {
+	return func(ctx context.Context) (*authInterfaces.GrantToken, error) {
+		n := atomic.AddInt32(calls, 1)
+		return &authInterfaces.GrantToken{AccessToken: fmt.Sprintf("token-%d", n), ExpiresIn: expiresIn}, nil
+	}
+}
+
+func TestTokenRefresher_Caches(t *testing.T) {
+	var calls int32
+	r := auth.NewTokenRefresherWithGrant(countingGrant(30, &calls), nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := r.Token(context.Background())
+			if err != nil || token != "token-1" {
+				t.Errorf("Token() = %q, %v", token, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("grant called %d times, want 1", calls)
+	}
+	if time.Until(r.Expiry()) <= 0 {
+		t.Errorf("Expiry() = %v, want in the future", r.Expiry())
+	}
+
+	r.Invalidate()
+	if token, _ := r.Token(context.Background()); token != "token-2" {
+		t.Errorf("Token() after Invalidate = %q, want token-2", token)
+	}
+}
+
+func TestTokenRefresher_RefreshBeforeExpiry(t *testing.T) {
+	var calls int32
+	r := auth.NewTokenRefresherWithGrant(countingGrant(0.1, &calls), &auth.TokenRefresherOptions{RefreshBefore: 50 * time.Millisecond})
+
+	if token, _ := r.Token(context.Background()); token != "token-1" {
+		t.Fatalf("Token() = %q, want token-1", token)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if token, _ := r.Token(context.Background()); token != "token-2" {
+		t.Errorf("Token() = %q, want token-2 once inside the refresh window", token)
+	}
+}
+
+func TestTokenRefresher_GrantFailure(t *testing.T) {
+	var calls int32
+	fail := errors.New("grant failed")
+	r := auth.NewTokenRefresherWithGrant(func(ctx context.Context) (*authInterfaces.GrantToken, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return &authInterfaces.GrantToken{AccessToken: "token-1", ExpiresIn: 10}, nil
+		}
+		return nil, fail
+	}, &auth.TokenRefresherOptions{RefreshBefore: 10 * time.Second})
+
+	// the whole lifetime is inside the refresh window, so every call tries to refresh
+	if token, _ := r.Token(context.Background()); token != "token-1" {
+		t.Fatalf("Token() = %q, want token-1", token)
+	}
+	if token, err := r.Token(context.Background()); err != nil || token != "token-1" {
+		t.Errorf("Token() = %q, %v, want the unexpired token-1", token, err)
+	}
+
+	r.Invalidate()
+	if _, err := r.Token(context.Background()); !errors.Is(err, fail) {
+		t.Errorf("Token() err = %v, want %v", err, fail)
+	}
+}
+
+func TestTokenRefresher_RetryOnUnauthorized(t *testing.T) {
+	var calls int32
+	r := auth.NewTokenRefresherWithGrant(countingGrant(30, &calls), nil)
+
+	c := client.New("", &interfaces.ClientOptions{TokenSource: r})
+	httpmock.ActivateNonDefault(&c.HTTPClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	var seen []string
+	httpmock.RegisterResponder("POST", "https://api.deepgram.com/v1/listen", func(req *http.Request) (*http.Response, error) {
+		authorization := req.Header.Get("Authorization")
+		seen = append(seen, authorization)
+		if authorization != "Bearer token-2" {
+			return httpmock.NewStringResponse(http.StatusUnauthorized, `{"err_code":"INVALID_AUTH"}`), nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{"metadata":{"request_id":"abc"}}`), nil
+	})
+
+	var resp map[string]interface{}
+	err := c.DoURL(context.Background(), "https://dpgr.am/spacewalk.wav", &interfaces.PreRecordedTranscriptionOptions{}, &resp)
+	if err != nil {
+		t.Fatalf("DoURL failed. Err: %v", err)
+	}
+	if len(seen) != 2 || seen[0] != "Bearer token-1" || seen[1] != "Bearer token-2" {
+		t.Errorf("Authorization headers = %v", seen)
+	}
+	if token, isBearer := c.Options.GetAuthToken(); !isBearer || token != "token-2" {
+		t.Errorf("GetAuthToken() = %q, %v", token, isBearer)
+	}
+
+	// only one retry
+	r.Invalidate()
+	httpmock.RegisterResponder("POST", "https://api.deepgram.com/v1/listen", httpmock.NewStringResponder(http.StatusUnauthorized, `{}`))
+	err = c.DoURL(context.Background(), "https://dpgr.am/spacewalk.wav", &interfaces.PreRecordedTranscriptionOptions{}, &resp)
+	if err == nil {
+		t.Errorf("DoURL succeeded, want a 401 error")
+	}
+	if n := httpmock.GetTotalCallCount(); n != 4 {
+		t.Errorf("total calls = %d, want 4", n)
+	}
+}
+
+func TestTokenRefresher_SharedOptions(t *testing.T) {
+	options := &interfaces.ClientOptions{APIKey: "mock-api-key"}
+	authClient := authclient.New("", options)
+	httpmock.ActivateNonDefault(&authClient.HTTPClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	var grants int32
+	var seen []string
+	httpmock.RegisterResponder("POST", "https://api.deepgram.com/v1/auth/grant", func(req *http.Request) (*http.Response, error) {
+		seen = append(seen, req.Header.Get("Authorization"))
+		n := atomic.AddInt32(&grants, 1)
+		return httpmock.NewStringResponse(http.StatusOK, fmt.Sprintf(`{"access_token":"token-%d","expires_in":30}`, n)), nil
+	})
+
+	// the auth client uses the options which hold its own refresher
+	options.TokenSource = auth.NewTokenRefresher(auth.New(authClient), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		err := options.RefreshAuthToken(ctx)
+		if err == nil {
+			// the stored access token must not be used for the next grant
+			options.InvalidateAuthToken()
+			err = options.RefreshAuthToken(ctx)
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("RefreshAuthToken failed. Err: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatalf("RefreshAuthToken deadlocked")
+	}
+
+	if len(seen) != 2 || seen[0] != "token mock-api-key" || seen[1] != "token mock-api-key" {
+		t.Errorf("grant Authorization headers = %v", seen)
+	}
+	if token, isBearer := options.GetAuthToken(); !isBearer || token != "token-2" {
+		t.Errorf("GetAuthToken() = %q, %v", token, isBearer)
+	}
+}
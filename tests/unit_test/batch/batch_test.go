@@ -0,0 +1,282 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+
+	batch "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/batch"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+)
+
+/* #nosec G101 */
+const mockAPIKey = "m0ckap1k3y0bbc125dac7f40ed3eb0ed232a2ff8"
+
+const listenEndPoint = "https://api.deepgram.com/v1/listen"
+
+// hashes maps each audio URL to the sha256 the mock server reports
+var hashes = map[string]string{
+	"https://example.test/a.wav":      "aaaa",
+	"https://example.test/b.wav":      "bbbb",
+	"https://example.test/a-copy.wav": "aaaa",
+	"https://example.test/flaky.wav":  "ffff",
+}
+
+type recordingSink struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (s *recordingSink) Write(_ context.Context, item *batch.Item, _ *api.PreRecordedResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids = append(s.ids, item.ID)
+	return nil
+}
+
+func newClient() *client.Client {
+	c := client.New(mockAPIKey, &interfaces.ClientOptions{})
+	httpmock.ActivateNonDefault(&c.Client.HTTPClient.Client)
+	return c
+}
+
+func mockListen(t *testing.T, calls map[string]int, failures map[string]int) {
+	var mu sync.Mutex
+	httpmock.RegisterResponder("POST", listenEndPoint, func(r *http.Request) (*http.Response, error) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("json.Decode failed. Err: %v", err)
+		}
+		url := body["url"]
+
+		mu.Lock()
+		calls[url]++
+		fail := failures[url] > 0
+		if fail {
+			failures[url]--
+		}
+		mu.Unlock()
+
+		if fail {
+			return httpmock.NewStringResponse(http.StatusServiceUnavailable, "unavailable"), nil
+		}
+		return httpmock.NewJsonResponse(200, map[string]any{
+			"metadata": map[string]any{"request_id": "req-" + hashes[url], "sha256": hashes[url]},
+			"results":  map[string]any{"channels": []any{}},
+		})
+	})
+}
+
+func TestBatch_Run(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	calls := make(map[string]int)
+	failures := map[string]int{"https://example.test/flaky.wav": 1}
+	mockListen(t, calls, failures)
+
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint.json")
+	sink := &recordingSink{}
+	opts := batch.Options{
+		Defaults:       &interfaces.PreRecordedTranscriptionOptions{Model: "nova-3"},
+		Concurrency:    1,
+		RetryBackoff:   time.Millisecond,
+		CheckpointFile: checkpoint,
+		Sink:           sink,
+	}
+
+	runner, err := batch.New(newClient(), opts)
+	if err != nil {
+		t.Fatalf("batch.New failed. Err: %v", err)
+	}
+
+	items := []batch.Item{
+		{URL: "https://example.test/a.wav"},
+		{URL: "https://example.test/b.wav"},
+		{URL: "https://example.test/a-copy.wav"},
+		{URL: "https://example.test/flaky.wav"},
+	}
+	summary, err := runner.Run(context.Background(), items)
+	if err != nil {
+		t.Fatalf("Run failed. Err: %v", err)
+	}
+
+	if summary.Succeeded != 3 || summary.Duplicates != 1 || summary.Failed != 0 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.Results[2].Status != batch.StatusDuplicate {
+		t.Errorf("expected a-copy to be a duplicate, got %s", summary.Results[2].Status)
+	}
+	if summary.Results[3].Attempts != 2 {
+		t.Errorf("expected flaky to take 2 attempts, got %d", summary.Results[3].Attempts)
+	}
+	if len(sink.ids) != 3 {
+		t.Errorf("expected 3 sink writes, got %v", sink.ids)
+	}
+
+	// resume: everything is already completed
+	runner, err = batch.New(newClient(), opts)
+	if err != nil {
+		t.Fatalf("batch.New failed. Err: %v", err)
+	}
+	before := httpmock.GetTotalCallCount()
+
+	summary, err = runner.Run(context.Background(), items)
+	if err != nil {
+		t.Fatalf("Run failed. Err: %v", err)
+	}
+	if summary.Skipped != len(items) {
+		t.Errorf("expected all items to be skipped, got %+v", summary)
+	}
+	if httpmock.GetTotalCallCount() != before {
+		t.Errorf("expected no requests on resume")
+	}
+}
+
+func TestBatch_NoRetryOnClientError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", listenEndPoint, httpmock.NewStringResponder(http.StatusForbidden, "forbidden"))
+
+	runner, err := batch.New(newClient(), batch.Options{
+		RetryBackoff: time.Millisecond,
+		Sink:         &recordingSink{},
+	})
+	if err != nil {
+		t.Fatalf("batch.New failed. Err: %v", err)
+	}
+
+	summary, err := runner.Run(context.Background(), []batch.Item{{URL: "https://example.test/a.wav"}})
+	if err != nil {
+		t.Fatalf("Run failed. Err: %v", err)
+	}
+	if summary.Failed != 1 || summary.Results[0].Attempts != 1 {
+		t.Errorf("expected a single failed attempt, got %+v", summary.Results[0])
+	}
+}
+
+func TestBatch_InvalidItems(t *testing.T) {
+	runner, err := batch.New(newClient(), batch.Options{Sink: &recordingSink{}})
+	if err != nil {
+		t.Fatalf("batch.New failed. Err: %v", err)
+	}
+
+	if _, err := runner.Run(context.Background(), []batch.Item{{File: "a.wav", URL: "https://example.test/a.wav"}}); err != batch.ErrInvalidItem {
+		t.Errorf("expected ErrInvalidItem, got %v", err)
+	}
+	if _, err := runner.Run(context.Background(), []batch.Item{{URL: "https://example.test/a.wav"}, {URL: "https://example.test/a.wav"}}); err != batch.ErrDuplicateID {
+		t.Errorf("expected ErrDuplicateID, got %v", err)
+	}
+}
+
+func TestBatch_ConcurrentSameFile(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	dir := t.TempDir()
+	audio := append([]byte("RIFF\x24\x00\x00\x00WAVEfmt "), make([]byte, 64)...)
+	files := []string{filepath.Join(dir, "a.wav"), filepath.Join(dir, "a-copy.wav")}
+	for _, file := range files {
+		if err := os.WriteFile(file, audio, 0o600); err != nil {
+			t.Fatalf("os.WriteFile failed. Err: %v", err)
+		}
+	}
+	items := []batch.Item{{File: files[0]}, {File: files[1]}}
+
+	run := func(failFirst bool) (*batch.Summary, int32) {
+		var calls int32
+		httpmock.RegisterResponder("POST", listenEndPoint, func(r *http.Request) (*http.Response, error) {
+			call := atomic.AddInt32(&calls, 1)
+			// slow enough for both items to be in flight together
+			time.Sleep(100 * time.Millisecond)
+			if failFirst && call == 1 {
+				res := httpmock.NewStringResponse(http.StatusForbidden, "forbidden")
+				res.Request = r
+				return res, nil
+			}
+			return httpmock.NewJsonResponse(200, map[string]any{
+				"metadata": map[string]any{"request_id": "req-a"},
+				"results":  map[string]any{"channels": []any{}},
+			})
+		})
+
+		runner, err := batch.New(newClient(), batch.Options{
+			Defaults:     &interfaces.PreRecordedTranscriptionOptions{Model: "nova-3"},
+			Concurrency:  2,
+			RetryBackoff: time.Millisecond,
+			Sink:         &recordingSink{},
+		})
+		if err != nil {
+			t.Fatalf("batch.New failed. Err: %v", err)
+		}
+		summary, err := runner.Run(context.Background(), items)
+		if err != nil {
+			t.Fatalf("Run failed. Err: %v", err)
+		}
+		return summary, atomic.LoadInt32(&calls)
+	}
+
+	// the same audio is uploaded once
+	summary, calls := run(false)
+	if calls != 1 || summary.Succeeded != 1 || summary.Duplicates != 1 {
+		t.Errorf("%d uploads, summary %+v", calls, summary)
+	}
+
+	// when the first upload fails, the other item transcribes the audio itself
+	summary, calls = run(true)
+	if calls != 2 || summary.Succeeded != 1 || summary.Failed != 1 || summary.Duplicates != 0 {
+		t.Errorf("%d uploads, summary %+v", calls, summary)
+	}
+}
+
+func TestBatch_DirSinkFilenames(t *testing.T) {
+	if got := batch.Filename("call-1.wav"); got != "call-1.wav" {
+		t.Errorf("Filename(call-1.wav) = %q", got)
+	}
+
+	// these sanitize to the same name
+	ids := []string{"a/b.wav", "a_b.wav", "a:b.wav"}
+	sink, err := batch.NewDirSink(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDirSink failed. Err: %v", err)
+	}
+	for _, id := range ids {
+		resp := &api.PreRecordedResponse{Metadata: &api.Metadata{RequestID: id}}
+		if err := sink.Write(context.Background(), &batch.Item{ID: id}, resp); err != nil {
+			t.Fatalf("Write(%s) failed. Err: %v", id, err)
+		}
+	}
+
+	entries, err := os.ReadDir(sink.Dir)
+	if err != nil {
+		t.Fatalf("os.ReadDir failed. Err: %v", err)
+	}
+	if len(entries) != len(ids) {
+		t.Errorf("wrote %d files for %d ids", len(entries), len(ids))
+	}
+	for _, id := range ids {
+		byData, err := os.ReadFile(filepath.Join(sink.Dir, batch.Filename(id)+".json"))
+		if err != nil {
+			t.Fatalf("os.ReadFile failed. Err: %v", err)
+		}
+		var resp api.PreRecordedResponse
+		if err := json.Unmarshal(byData, &resp); err != nil || resp.Metadata == nil || resp.Metadata.RequestID != id {
+			t.Errorf("%s holds the response for another id", batch.Filename(id))
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+	redact "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/redact"
+	bleep "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/bleep"
+)
+
+// 1kHz makes every frame 1ms
+var mono = bleep.Format{SampleRate: 1000, Channels: 1, BitsPerSample: 16}
+
+const level int16 = 1000
+
+// pcm returns frames of 16 bit audio where every sample is level
+func pcm(frames, channels int) []byte {
+	byData := make([]byte, frames*channels*2)
+	for i := 0; i < len(byData); i += 2 {
+		binary.LittleEndian.PutUint16(byData[i:], uint16(level))
+	}
+	return byData
+}
+
+func sample(byData []byte, frame, channels, channel int) int16 {
+	return int16(binary.LittleEndian.Uint16(byData[(frame*channels+channel)*2:]))
+}
+
+func scrub(t *testing.T, byData []byte, opts bleep.Options) []byte {
+	t.Helper()
+
+	var out bytes.Buffer
+	if err := bleep.ProcessPCM(bytes.NewReader(byData), &out, opts); err != nil {
+		t.Fatalf("ProcessPCM failed. Err: %v", err)
+	}
+	if out.Len() != len(byData) {
+		t.Fatalf("wrote %d bytes, want %d", out.Len(), len(byData))
+	}
+	return out.Bytes()
+}
+
+func TestBleep_MuteBoundaries(t *testing.T) {
+	out := scrub(t, pcm(1000, 1), bleep.Options{
+		Format: mono,
+		Spans:  []bleep.Span{{Start: 0.2, End: 0.3, Channel: bleep.AllChannels}},
+	})
+
+	// both ends of the span are scrubbed, nothing around it
+	for frame, want := range map[int]int16{199: level, 200: 0, 250: 0, 300: 0, 301: level} {
+		if got := sample(out, frame, 1, 0); got != want {
+			t.Errorf("frame %d = %d, want %d", frame, got, want)
+		}
+	}
+
+	// padding extends both sides
+	out = scrub(t, pcm(1000, 1), bleep.Options{
+		Format:  mono,
+		Spans:   []bleep.Span{{Start: 0.2, End: 0.3, Channel: bleep.AllChannels}},
+		Padding: 10 * time.Millisecond,
+	})
+	for frame, want := range map[int]int16{189: level, 191: 0, 309: 0, 311: level} {
+		if got := sample(out, frame, 1, 0); got != want {
+			t.Errorf("padded frame %d = %d, want %d", frame, got, want)
+		}
+	}
+}
+
+func TestBleep_Tone(t *testing.T) {
+	out := scrub(t, pcm(1000, 1), bleep.Options{
+		Format:        mono,
+		Spans:         []bleep.Span{{Start: 0.5, End: 0.6, Channel: bleep.AllChannels}},
+		Mode:          bleep.ModeTone,
+		ToneFrequency: 100,
+		ToneVolume:    0.5,
+	})
+
+	for frame := 490; frame <= 610; frame++ {
+		want := level
+		if frame >= 500 && frame <= 600 {
+			tm := float64(frame) / 1000
+			want = int16(0.5 * math.Sin(2*math.Pi*100*tm) * math.MaxInt16)
+		}
+		if got := sample(out, frame, 1, 0); got != want {
+			t.Fatalf("frame %d = %d, want %d", frame, got, want)
+		}
+	}
+}
+
+func TestBleep_StereoChannel(t *testing.T) {
+	stereo := bleep.Format{SampleRate: 1000, Channels: 2, BitsPerSample: 16}
+	out := scrub(t, pcm(100, 2), bleep.Options{
+		Format: stereo,
+		Spans: []bleep.Span{
+			{Start: 0.01, End: 0.02, Channel: 1},
+			{Start: 0.05, End: 0.06, Channel: bleep.AllChannels},
+		},
+	})
+
+	checks := []struct {
+		frame, channel int
+		want           int16
+	}{
+		{15, 0, level}, {15, 1, 0},
+		{25, 0, level}, {25, 1, level},
+		{55, 0, 0}, {55, 1, 0},
+	}
+	for _, c := range checks {
+		if got := sample(out, c.frame, 2, c.channel); got != c.want {
+			t.Errorf("frame %d channel %d = %d, want %d", c.frame, c.channel, got, c.want)
+		}
+	}
+}
+
+func TestBleep_WriterPartialFrames(t *testing.T) {
+	stereo := bleep.Format{SampleRate: 1000, Channels: 2, BitsPerSample: 16}
+	opts := bleep.Options{Format: stereo, Spans: []bleep.Span{{Start: 0.01, End: 0.03, Channel: bleep.AllChannels}}}
+
+	byData := append(pcm(50, 2), 0x01) // a trailing partial frame
+	want := scrub(t, byData[:len(byData)-1], opts)
+
+	var out bytes.Buffer
+	w, err := bleep.NewWriter(&out, opts)
+	if err != nil {
+		t.Fatalf("NewWriter failed. Err: %v", err)
+	}
+	// 3 byte writes end mid sample and mid frame
+	for i := 0; i < len(byData); i += 3 {
+		end := i + 3
+		if end > len(byData) {
+			end = len(byData)
+		}
+		if n, err := w.Write(byData[i:end]); err != nil || n != end-i {
+			t.Fatalf("Write = %d, %v", n, err)
+		}
+	}
+	if out.Len() != len(want) || !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("partial writes differ from a single write")
+	}
+	if w.Position() != 50*time.Millisecond {
+		t.Errorf("Position() = %v", w.Position())
+	}
+
+	// the partial frame is kept until Flush
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush failed. Err: %v", err)
+	}
+	if !bytes.Equal(out.Bytes()[len(want):], []byte{0x01}) {
+		t.Errorf("Flush wrote %x", out.Bytes()[len(want):])
+	}
+}
+
+// chunk encodes a RIFF chunk, padded to an even size
+func chunk(id string, body []byte) []byte {
+	byData := append([]byte(id), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(byData[4:], uint32(len(body)))
+	byData = append(byData, body...)
+	if len(body)%2 == 1 {
+		byData = append(byData, 0)
+	}
+	return byData
+}
+
+func TestBleep_ProcessWAV(t *testing.T) {
+	fmtBody := make([]byte, 16)
+	binary.LittleEndian.PutUint16(fmtBody[0:], 1) // PCM
+	binary.LittleEndian.PutUint16(fmtBody[2:], 1)
+	binary.LittleEndian.PutUint32(fmtBody[4:], 1000)
+	binary.LittleEndian.PutUint32(fmtBody[8:], 2000)
+	binary.LittleEndian.PutUint16(fmtBody[12:], 2)
+	binary.LittleEndian.PutUint16(fmtBody[14:], 16)
+
+	samples := pcm(100, 1)
+	var body []byte
+	body = append(body, []byte("WAVE")...)
+	body = append(body, chunk("fmt ", fmtBody)...)
+	body = append(body, chunk("LIST", []byte("INFOISFT\x05\x00\x00\x00test\x00"))...)
+	body = append(body, chunk("data", samples)...)
+	body = append(body, chunk("cue ", []byte("odd"))...)
+	wavFile := append(chunk("RIFF", body)[:8], body...)
+
+	// a plain reader, like a pipe
+	var out bytes.Buffer
+	err := bleep.ProcessWAV(io.MultiReader(bytes.NewReader(wavFile)), &out, bleep.Options{
+		Spans: []bleep.Span{{Start: 0.01, End: 0.02, Channel: bleep.AllChannels}},
+	})
+	if err != nil {
+		t.Fatalf("ProcessWAV failed. Err: %v", err)
+	}
+
+	// only the scrubbed samples differ
+	dataStart := bytes.Index(wavFile, []byte("data")) + 8
+	want := append([]byte{}, wavFile...)
+	for i := dataStart + 10*2; i <= dataStart+20*2+1; i++ {
+		want[i] = 0
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("ProcessWAV output differs from the input outside the span")
+	}
+
+	// samples before their format can't be scrubbed
+	body = append([]byte("WAVE"), chunk("data", samples)...)
+	body = append(body, chunk("fmt ", fmtBody)...)
+	wavFile = append(chunk("RIFF", body)[:8], body...)
+	out.Reset()
+	err = bleep.ProcessWAV(bytes.NewReader(wavFile), &out, bleep.Options{
+		Spans: []bleep.Span{{Start: 0.01, End: 0.02, Channel: bleep.AllChannels}},
+	})
+	if !errors.Is(err, bleep.ErrDataBeforeFmt) {
+		t.Errorf("ProcessWAV err = %v, want ErrDataBeforeFmt", err)
+	}
+	if bytes.Contains(out.Bytes(), samples) {
+		t.Errorf("ProcessWAV copied the unscrubbed samples")
+	}
+}
+
+func TestBleep_Spans(t *testing.T) {
+	words := func(ws ...api.Word) []api.Alternative {
+		return []api.Alternative{{Words: ws}}
+	}
+	resp := &api.PreRecordedResponse{
+		Results: &api.Result{
+			Channels: []api.Channel{
+				{Alternatives: words(
+					api.Word{Word: "card", Start: 0, End: 0.5},
+					api.Word{Word: "[pci]", PunctuatedWord: "[PCI]", Start: 0.5, End: 1},
+					api.Word{Word: "[pci]", PunctuatedWord: "[PCI].", Start: 0.9, End: 1.5},
+					api.Word{Word: "ok", Start: 1.5, End: 2},
+				)},
+				{Alternatives: words(
+					api.Word{Word: "[ssn]", Start: 0.7, End: 1.2},
+				)},
+			},
+		},
+	}
+
+	// overlapping words merge, multichannel spans keep their channel
+	want := []bleep.Span{
+		{Start: 0.5, End: 1.5, Channel: 0},
+		{Start: 0.7, End: 1.2, Channel: 1},
+	}
+	if got := bleep.SpansFromResponse(resp); !reflect.DeepEqual(got, want) {
+		t.Errorf("SpansFromResponse = %+v", got)
+	}
+
+	redacted := []redact.Span{
+		{Label: "pci", Channel: 1, Start: 2, End: 3},
+		{Label: "ssn", Channel: 0, Start: 1, End: 2.5},
+		{Label: "pci", Channel: 1, Start: 2.5, End: 4},
+	}
+	if got := bleep.SpansFromRedaction(redacted, true); !reflect.DeepEqual(got, []bleep.Span{
+		{Start: 1, End: 2.5, Channel: 0},
+		{Start: 2, End: 4, Channel: 1},
+	}) {
+		t.Errorf("SpansFromRedaction(multichannel) = %+v", got)
+	}
+	if got := bleep.SpansFromRedaction(redacted, false); !reflect.DeepEqual(got, []bleep.Span{
+		{Start: 1, End: 4, Channel: bleep.AllChannels},
+	}) {
+		t.Errorf("SpansFromRedaction = %+v", got)
+	}
+}
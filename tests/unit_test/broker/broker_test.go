@@ -0,0 +1,295 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	broker "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/auth/v1/broker"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/auth/v1/interfaces"
+)
+
+type fakeGrant struct {
+	mu    sync.Mutex
+	calls int
+	ttls  []int
+	err   error
+}
+
+func (f *fakeGrant) grant(ctx context.Context, req *api.GrantTokenRequest) (*api.GrantToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.calls++
+	f.ttls = append(f.ttls, *req.TTLSeconds)
+	return &api.GrantToken{AccessToken: fmt.Sprintf("token-%d", f.calls), ExpiresIn: float64(*req.TTLSeconds)}, nil
+}
+
+// authorizeHeader accepts "Bearer <user>" sessions, "Bearer banned" is forbidden
+func authorizeHeader(r *http.Request) (*broker.Caller, error) {
+	switch user := r.Header.Get("Authorization"); user {
+	case "":
+		return nil, errors.New("no session")
+	case "Bearer banned":
+		return nil, fmt.Errorf("user is banned: %w", broker.ErrForbidden)
+	case "Bearer admin":
+		return &broker.Caller{ID: user, TTLSeconds: 7200}, nil
+	default:
+		return &broker.Caller{ID: user}, nil
+	}
+}
+
+type recorder struct {
+	mu     sync.Mutex
+	events []broker.AuditEvent
+}
+
+func (r *recorder) audit(e broker.AuditEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) outcomes() []broker.Outcome {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	outcomes := make([]broker.Outcome, 0, len(r.events))
+	for _, e := range r.events {
+		outcomes = append(outcomes, e.Outcome)
+	}
+	return outcomes
+}
+
+func request(h http.Handler, method, user, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/token", nil)
+	if user != "" {
+		req.Header.Set("Authorization", "Bearer "+user)
+	}
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestBroker_Grant(t *testing.T) {
+	g := &fakeGrant{}
+	rec := &recorder{}
+	b, err := broker.NewWithGrant(g.grant, broker.Options{
+		Authorize:     authorizeHeader,
+		TTLSeconds:    60,
+		MaxTTLSeconds: 600,
+		AllowGet:      true,
+		Audit:         rec.audit,
+	})
+	if err != nil {
+		t.Fatalf("NewWithGrant failed. Err: %v", err)
+	}
+
+	w := request(b, http.MethodPost, "alice", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	var resp broker.Response
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Decode failed. Err: %v", err)
+	}
+	if resp.AccessToken != "token-1" || resp.ExpiresIn < 59 || resp.ExpiresIn > 60 {
+		t.Errorf("response = %+v", resp)
+	}
+	if w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("Cache-Control = %q", w.Header().Get("Cache-Control"))
+	}
+
+	// the caller's TTL is capped
+	request(b, http.MethodGet, "admin", "")
+
+	if codes := []int{request(b, http.MethodPost, "", "").Code, request(b, http.MethodPost, "banned", "").Code, request(b, http.MethodDelete, "alice", "").Code}; codes[0] != 401 || codes[1] != 403 || codes[2] != 405 {
+		t.Errorf("status codes = %v, want [401 403 405]", codes)
+	}
+
+	if len(g.ttls) != 2 || g.ttls[0] != 60 || g.ttls[1] != 600 {
+		t.Errorf("granted ttls = %v, want [60 600]", g.ttls)
+	}
+	want := []broker.Outcome{broker.OutcomeGranted, broker.OutcomeGranted, broker.OutcomeUnauthorized, broker.OutcomeForbidden}
+	got := rec.outcomes()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("audit outcomes = %v, want %v", got, want)
+	}
+	if rec.events[0].CallerID != "Bearer alice" || rec.events[0].ExpiresAt.IsZero() {
+		t.Errorf("audit event = %+v", rec.events[0])
+	}
+}
+
+func TestBroker_RateLimitAndCache(t *testing.T) {
+	g := &fakeGrant{}
+	rec := &recorder{}
+	b, err := broker.NewWithGrant(g.grant, broker.Options{
+		Authorize: authorizeHeader,
+		RateLimit: 0.001,
+		Burst:     2,
+		Cache:     true,
+		Audit:     rec.audit,
+	})
+	if err != nil {
+		t.Fatalf("NewWithGrant failed. Err: %v", err)
+	}
+
+	codes := []int{
+		request(b, http.MethodPost, "alice", "").Code,
+		request(b, http.MethodPost, "alice", "").Code,
+		request(b, http.MethodPost, "alice", "").Code,
+		request(b, http.MethodPost, "bob", "").Code,
+	}
+	if fmt.Sprint(codes) != "[200 200 429 200]" {
+		t.Errorf("status codes = %v, want [200 200 429 200]", codes)
+	}
+	if g.calls != 2 {
+		t.Errorf("grant called %d times, want 2 (alice's second token is cached)", g.calls)
+	}
+
+	want := []broker.Outcome{broker.OutcomeGranted, broker.OutcomeCached, broker.OutcomeRateLimited, broker.OutcomeGranted}
+	if got := rec.outcomes(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("audit outcomes = %v, want %v", got, want)
+	}
+
+	w := request(b, http.MethodPost, "alice", "")
+	if w.Header().Get("Retry-After") == "" {
+		t.Errorf("missing Retry-After header")
+	}
+}
+
+func TestBroker_CORS(t *testing.T) {
+	g := &fakeGrant{}
+	b, err := broker.NewWithGrant(g.grant, broker.Options{
+		Authorize:        authorizeHeader,
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowCredentials: true,
+	})
+	if err != nil {
+		t.Fatalf("NewWithGrant failed. Err: %v", err)
+	}
+
+	w := request(b, http.MethodOptions, "", "https://app.example.com")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("preflight status = %d, want 204", w.Code)
+	}
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+		w.Header().Get("Access-Control-Allow-Credentials") != "true" ||
+		w.Header().Get("Access-Control-Allow-Headers") != "Authorization, Content-Type" {
+		t.Errorf("preflight headers = %v", w.Header())
+	}
+
+	if w := request(b, http.MethodOptions, "", "https://evil.example.com"); w.Code != http.StatusForbidden {
+		t.Errorf("preflight from another origin = %d, want 403", w.Code)
+	}
+
+	w = request(b, http.MethodPost, "alice", "https://app.example.com")
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Errorf("status = %d, headers = %v", w.Code, w.Header())
+	}
+
+	// another origin is refused before a token is granted
+	w = request(b, http.MethodPost, "alice", "https://evil.example.com")
+	if w.Code != http.StatusForbidden || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("status = %d, headers = %v", w.Code, w.Header())
+	}
+	if len(g.ttls) != 1 {
+		t.Errorf("granted %d tokens, want 1", len(g.ttls))
+	}
+}
+
+func TestBroker_GetOptIn(t *testing.T) {
+	g := &fakeGrant{}
+	rec := &recorder{}
+	b, err := broker.NewWithGrant(g.grant, broker.Options{
+		Authorize:      authorizeHeader,
+		AllowedOrigins: []string{"https://app.example.com"},
+		Audit:          rec.audit,
+	})
+	if err != nil {
+		t.Fatalf("NewWithGrant failed. Err: %v", err)
+	}
+
+	w := request(b, http.MethodGet, "alice", "")
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "POST, OPTIONS" {
+		t.Errorf("GET status = %d, Allow = %q", w.Code, w.Header().Get("Allow"))
+	}
+	w = request(b, http.MethodOptions, "", "https://app.example.com")
+	if w.Header().Get("Access-Control-Allow-Methods") != "POST, OPTIONS" {
+		t.Errorf("preflight headers = %v", w.Header())
+	}
+
+	// a disallowed origin is audited, without calling Authorize
+	if w := request(b, http.MethodPost, "banned", "https://evil.example.com"); w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", w.Code)
+	}
+	if len(rec.events) != 1 || rec.events[0].Outcome != broker.OutcomeForbidden || rec.events[0].Error != broker.ErrOriginNotAllowed.Error() {
+		t.Errorf("audit events = %+v", rec.events)
+	}
+	if len(g.ttls) != 0 {
+		t.Errorf("granted %d tokens, want 0", len(g.ttls))
+	}
+}
+
+func TestBroker_CORSWildcard(t *testing.T) {
+	g := &fakeGrant{}
+
+	// any site could mint tokens with a logged in user's cookies
+	_, err := broker.NewWithGrant(g.grant, broker.Options{
+		Authorize:        authorizeHeader,
+		AllowedOrigins:   []string{"https://app.example.com", "*"},
+		AllowCredentials: true,
+	})
+	if !errors.Is(err, broker.ErrWildcardCredentials) {
+		t.Errorf("NewWithGrant err = %v, want ErrWildcardCredentials", err)
+	}
+
+	b, err := broker.NewWithGrant(g.grant, broker.Options{
+		Authorize:      authorizeHeader,
+		AllowedOrigins: []string{"*"},
+	})
+	if err != nil {
+		t.Fatalf("NewWithGrant failed. Err: %v", err)
+	}
+
+	// the origin is not echoed back
+	w := request(b, http.MethodPost, "alice", "https://evil.example.com")
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "*" ||
+		w.Header().Get("Access-Control-Allow-Credentials") != "" {
+		t.Errorf("status = %d, headers = %v", w.Code, w.Header())
+	}
+}
+
+func TestBroker_GrantFailure(t *testing.T) {
+	g := &fakeGrant{err: errors.New("platform unavailable")}
+	rec := &recorder{}
+	b, _ := broker.NewWithGrant(g.grant, broker.Options{Authorize: authorizeHeader, Audit: rec.audit})
+
+	if w := request(b, http.MethodPost, "alice", ""); w.Code != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502", w.Code)
+	}
+	if len(rec.events) != 1 || rec.events[0].Outcome != broker.OutcomeFailed || rec.events[0].Error == "" {
+		t.Errorf("audit events = %+v", rec.events)
+	}
+
+	if _, err := broker.NewWithGrant(g.grant, broker.Options{}); !errors.Is(err, broker.ErrInvalidInput) {
+		t.Errorf("NewWithGrant without Authorize err = %v", err)
+	}
+}
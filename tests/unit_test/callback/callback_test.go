@@ -0,0 +1,139 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	callback "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/callback/v1"
+)
+
+const transcriptionBody = `{
+	"request_id": "req-1",
+	"metadata": {"request_id": "req-1", "sha256": "abc"},
+	"results": {"channels": [{"alternatives": [{"transcript": "hello world"}]}]}
+}`
+
+const analyzeBody = `{
+	"request_id": "req-2",
+	"metadata": {"request_id": "req-2"},
+	"results": {"summary": {"text": "a short summary"}}
+}`
+
+func post(t *testing.T, h http.Handler, contentType, body string, header map[string]string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestCallback_DecodeAndDedupe(t *testing.T) {
+	var got []*callback.Result
+	r := callback.New(callback.Options{
+		Handler: func(result *callback.Result) { got = append(got, result) },
+	})
+
+	if code := post(t, r, "application/json", transcriptionBody, nil); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	// retried delivery
+	if code := post(t, r, "application/json", transcriptionBody, nil); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if code := post(t, r, "application/json", analyzeBody, nil); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if code := post(t, r, "audio/mpeg", "ID3fakeaudio", map[string]string{"dg-request-id": "req-3"}); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(got))
+	}
+	if got[0].Kind != callback.KindTranscription || got[0].RequestID != "req-1" ||
+		got[0].Transcription.Results.Channels[0].Alternatives[0].Transcript != "hello world" {
+		t.Errorf("unexpected transcription result: %+v", got[0])
+	}
+	if got[1].Kind != callback.KindAnalyze || got[1].Analyze.Results.Summary.Text != "a short summary" {
+		t.Errorf("unexpected analyze result: %+v", got[1])
+	}
+	if got[2].Kind != callback.KindSpeak || got[2].RequestID != "req-3" || string(got[2].Audio) != "ID3fakeaudio" {
+		t.Errorf("unexpected speak result: %+v", got[2])
+	}
+
+	if code := post(t, r, "application/json", `{"foo": "bar"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown payload, got %d", code)
+	}
+}
+
+func TestCallback_Verify(t *testing.T) {
+	r := callback.New(callback.Options{
+		Username: "deepgram",
+		Password: "s3cret",
+		Headers:  map[string]string{"dg-token": "key-id"},
+	})
+
+	if code := post(t, r, "application/json", transcriptionBody, nil); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without credentials, got %d", code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(transcriptionBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("dg-token", "key-id")
+	req.SetBasicAuth("deepgram", "s3cret")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 with credentials, got %d", rec.Code)
+	}
+}
+
+func TestCallback_SubmitAndAwait(t *testing.T) {
+	r := callback.New(callback.Options{})
+	defer r.Close()
+
+	// the callback can arrive before the submit call returns
+	submit := func(ctx context.Context) (string, error) {
+		if code := post(t, r, "application/json", transcriptionBody, nil); code != http.StatusOK {
+			t.Errorf("expected 200, got %d", code)
+		}
+		return "req-1", nil
+	}
+
+	res, err := r.SubmitAndAwait(context.Background(), submit)
+	if err != nil {
+		t.Fatalf("SubmitAndAwait failed. Err: %v", err)
+	}
+	if res.RequestID != "req-1" {
+		t.Errorf("unexpected request id: %s", res.RequestID)
+	}
+
+	// the callback arrives later
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		post(t, r, "application/json", `{"request_id": "req-4", "err_code": "INTERNAL", "err_msg": "failed"}`, nil)
+	}()
+	res, err = r.Await(context.Background(), "req-4")
+	if err == nil || res == nil || res.Kind != callback.KindError {
+		t.Errorf("expected a delivery error, got %v %v", res, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := r.Await(ctx, "never"); err != callback.ErrTimeout {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+}
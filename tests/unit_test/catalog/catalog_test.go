@@ -0,0 +1,123 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	catalog "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/catalog"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/manage"
+)
+
+/* #nosec G101 */
+const mockAPIKey = "m0ckap1k3y0bbc125dac7f40ed3eb0ed232a2ff8"
+
+var mockModels = &api.ModelsResult{
+	Stt: []api.Stt{
+		{Name: "general", CanonicalName: "nova-3-general", Architecture: "nova-3", Languages: []string{"en", "es"}, Batch: true, Streaming: true, FormattedOutput: true},
+		{Name: "general", CanonicalName: "nova-2-general", Architecture: "nova-2", Languages: []string{"en-US", "es-419", "fr"}, Batch: true, Streaming: true},
+		{Name: "whisper-large", CanonicalName: "whisper-large", Architecture: "whisper", Languages: []string{"en", "ja"}, Batch: true},
+	},
+	Tts: []api.Tts{
+		{Name: "asteria", CanonicalName: "aura-asteria-en", Languages: []string{"en-US"}, Metadata: api.Metadata{Accent: "American", Tags: []string{"feminine"}}},
+		{Name: "helios", CanonicalName: "aura-helios-en", Languages: []string{"en-GB"}, Metadata: api.Metadata{Accent: "British", Tags: []string{"masculine"}}},
+	},
+}
+
+func TestCatalog_Query(t *testing.T) {
+	c, err := catalog.NewFromModels(mockModels)
+	if err != nil {
+		t.Fatalf("NewFromModels failed. Err: %v", err)
+	}
+	ctx := context.Background()
+
+	stt, err := c.STT(ctx, catalog.STTFilter{Language: "es-419", Streaming: true})
+	if err != nil {
+		t.Fatalf("STT failed. Err: %v", err)
+	}
+	if len(stt) != 2 {
+		t.Errorf("expected 2 streaming models for es-419, got %d", len(stt))
+	}
+
+	stt, _ = c.STT(ctx, catalog.STTFilter{Language: "ja", Streaming: true})
+	if len(stt) != 0 {
+		t.Errorf("expected no streaming models for ja, got %d", len(stt))
+	}
+
+	tts, err := c.TTS(ctx, catalog.TTSFilter{Accent: "british"})
+	if err != nil {
+		t.Fatalf("TTS failed. Err: %v", err)
+	}
+	if len(tts) != 1 || tts[0].CanonicalName != "aura-helios-en" {
+		t.Errorf("unexpected voices: %+v", tts)
+	}
+}
+
+func TestCatalog_Validate(t *testing.T) {
+	c, err := catalog.NewFromModels(mockModels)
+	if err != nil {
+		t.Fatalf("NewFromModels failed. Err: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := c.ValidatePreRecorded(ctx, &interfaces.PreRecordedTranscriptionOptions{Model: "nova-3", Language: "en-US"}); err != nil {
+		t.Errorf("expected valid options, got %v", err)
+	}
+	if err := c.ValidateLive(ctx, &interfaces.LiveTranscriptionOptions{Model: "nova-2", Language: "es-419"}); err != nil {
+		t.Errorf("expected valid options, got %v", err)
+	}
+
+	checks := []struct {
+		name string
+		err  error
+		kind catalog.ProblemKind
+	}{
+		{"unsupported_language", c.ValidatePreRecorded(ctx, &interfaces.PreRecordedTranscriptionOptions{Model: "nova-3", Language: "de"}), catalog.ProblemUnsupportedLanguage},
+		{"batch_only", c.ValidateLive(ctx, &interfaces.LiveTranscriptionOptions{Model: "whisper-large"}), catalog.ProblemBatchOnly},
+		{"unknown_model", c.ValidateLive(ctx, &interfaces.LiveTranscriptionOptions{Model: "nova-9"}), catalog.ProblemUnknownModel},
+		{"unknown_voice", c.ValidateSpeak(ctx, &interfaces.SpeakOptions{Model: "aura-zeus-en"}), catalog.ProblemUnknownVoice},
+	}
+	for _, check := range checks {
+		var verr *catalog.ValidationError
+		if !errors.As(check.err, &verr) || verr.Problems[0].Kind != check.kind {
+			t.Errorf("%s: expected %s, got %v", check.name, check.kind, check.err)
+		}
+	}
+
+	if err := c.ValidateSpeak(ctx, &interfaces.SpeakOptions{Model: "aura-asteria-en"}); err != nil {
+		t.Errorf("expected a known voice, got %v", err)
+	}
+}
+
+func TestCatalog_Cached(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.deepgram.com/v1/models", httpmock.NewJsonResponderOrPanic(200, mockModels))
+
+	mc := client.New(mockAPIKey, &interfaces.ClientOptions{})
+	httpmock.ActivateNonDefault(&mc.HTTPClient.Client)
+
+	c, err := catalog.New(manage.New(mc), catalog.Options{})
+	if err != nil {
+		t.Fatalf("catalog.New failed. Err: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.STT(context.Background(), catalog.STTFilter{}); err != nil {
+			t.Fatalf("STT failed. Err: %v", err)
+		}
+	}
+	if httpmock.GetTotalCallCount() != 1 {
+		t.Errorf("expected 1 request, got %d", httpmock.GetTotalCallCount())
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dvonthenen/websocket"
+
+	common "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	listenws "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/websocket"
+)
+
+const mockAPIKey string = "mock-api-key"
+
+type transitions struct {
+	mu     sync.Mutex
+	states []string
+}
+
+func (tr *transitions) hook(from, to common.ConnectionState) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if len(tr.states) == 0 {
+		tr.states = append(tr.states, from.String())
+	}
+	tr.states = append(tr.states, to.String())
+}
+
+func (tr *transitions) String() string {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return strings.Join(tr.states, ">")
+}
+
+func newClient(t *testing.T, ctx context.Context, cancel context.CancelFunc, url string) (*listenws.WSChannel, *transitions) {
+	t.Helper()
+
+	cOptions := &interfaces.ClientOptions{
+		APIKey: mockAPIKey,
+		Host:   "ws://" + strings.TrimPrefix(url, "http://"),
+	}
+	dg, err := listenws.NewUsingChanWithCancel(ctx, cancel, "", cOptions, &interfaces.LiveTranscriptionOptions{Model: "nova-3"}, nil)
+	if err != nil {
+		t.Fatalf("NewUsingChan failed. Err: %v", err)
+	}
+
+	tr := &transitions{}
+	dg.OnStateChange(tr.hook)
+	return dg, tr
+}
+
+func TestConnect_HandshakeRejected(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("dg-request-id", "req-123")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"err_code":"INSUFFICIENT_PERMISSIONS","err_msg":"Project does not have access to the requested model."}`)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dg, tr := newClient(t, ctx, cancel, server.URL)
+
+	err := dg.ConnectContext(ctx)
+	if !errors.Is(err, common.ErrHandshakeFailed) {
+		t.Fatalf("ConnectContext err = %v, want ErrHandshakeFailed", err)
+	}
+
+	var hsErr *common.HandshakeError
+	if !errors.As(err, &hsErr) {
+		t.Fatalf("err %T is not a HandshakeError", err)
+	}
+	if hsErr.StatusCode != http.StatusForbidden || hsErr.RequestID != "req-123" || !strings.Contains(string(hsErr.Body), "INSUFFICIENT_PERMISSIONS") {
+		t.Errorf("HandshakeError = %+v", hsErr)
+	}
+	if hsErr.DeepgramError == nil || hsErr.DeepgramError.ErrCode != "INSUFFICIENT_PERMISSIONS" {
+		t.Errorf("DeepgramError = %+v", hsErr.DeepgramError)
+	}
+
+	// a 403 fails the same way every time, so it is not retried
+	var connErr *common.ConnectError
+	if !errors.As(err, &connErr) || connErr.Attempts != 1 || calls != 1 {
+		t.Errorf("attempts = %d, server calls = %d, want 1", connErr.Attempts, calls)
+	}
+
+	if got := tr.String(); got != "Idle>Connecting>Closed" {
+		t.Errorf("states = %s", got)
+	}
+	if dg.State() != common.StateClosed {
+		t.Errorf("State() = %s", dg.State())
+	}
+}
+
+func TestConnect_ContextCanceledWhileRetrying(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	dg, _ := newClient(t, ctx, cancel, server.URL)
+
+	start := time.Now()
+	err := dg.ConnectContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ConnectContext err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ConnectContext took %v, the retry delay should stop with the context", elapsed)
+	}
+}
+
+func TestConnect_StateMachine(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dg, tr := newClient(t, ctx, cancel, server.URL)
+
+	if dg.State() != common.StateIdle {
+		t.Errorf("initial State() = %s", dg.State())
+	}
+	if err := dg.ConnectContext(ctx); err != nil {
+		t.Fatalf("ConnectContext failed. Err: %v", err)
+	}
+	if dg.State() != common.StateOpen {
+		t.Errorf("State() = %s, want Open", dg.State())
+	}
+
+	dg.Stop()
+	if got := tr.String(); got != "Idle>Connecting>Open>Closing>Closed" {
+		t.Errorf("states = %s", got)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"testing"
+
+	conversation "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/conversation"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+)
+
+func channelOf(words ...api.Word) api.Channel {
+	return api.Channel{
+		Alternatives: []api.Alternative{{Words: words}},
+	}
+}
+
+func TestConversation_Merge(t *testing.T) {
+	resp := &api.PreRecordedResponse{
+		Results: &api.Result{
+			Channels: []api.Channel{
+				channelOf(
+					api.Word{Word: "hello", PunctuatedWord: "Hello.", Start: 0.0, End: 0.4},
+					api.Word{Word: "how", PunctuatedWord: "How", Start: 3.0, End: 3.2},
+					api.Word{Word: "can", PunctuatedWord: "can", Start: 3.2, End: 3.4},
+					api.Word{Word: "i", PunctuatedWord: "I", Start: 3.4, End: 3.5},
+					api.Word{Word: "help", PunctuatedWord: "help?", Start: 3.5, End: 3.9},
+				),
+				channelOf(
+					api.Word{Word: "hi", PunctuatedWord: "Hi.", Start: 1.0, End: 1.3},
+					api.Word{Word: "yes", PunctuatedWord: "Yes.", Start: 3.8, End: 4.1},
+				),
+			},
+		},
+	}
+
+	t.Run("Test_interleave_channels_with_roles", func(t *testing.T) {
+		conv, err := conversation.Merge(resp, &conversation.Options{
+			ChannelRoles: map[int]string{0: "Agent", 1: "Customer"},
+			MaxGap:       1.0,
+		})
+		if err != nil {
+			t.Fatalf("Merge failed. Err: %v", err)
+		}
+
+		expected := "Agent: Hello.\nCustomer: Hi.\nAgent: How can I help?\nCustomer: Yes. [overlap]\n"
+		if conv.Text() != expected {
+			t.Errorf("unexpected text:\n%s", conv.Text())
+		}
+	})
+
+	t.Run("Test_default_roles_and_formats", func(t *testing.T) {
+		conv, err := conversation.Merge(resp, nil)
+		if err != nil {
+			t.Fatalf("Merge failed. Err: %v", err)
+		}
+		if conv.Turns[0].Role != "Channel 0" {
+			t.Errorf("unexpected role: %s", conv.Turns[0].Role)
+		}
+
+		for _, format := range []conversation.Format{conversation.FormatText, conversation.FormatMarkdown, conversation.FormatJSON} {
+			out, err := conv.Render(format)
+			if err != nil || out == "" {
+				t.Errorf("Render(%s) failed. Err: %v", format, err)
+			}
+		}
+		if _, err := conv.Render("xml"); err == nil {
+			t.Errorf("expected error for unknown format")
+		}
+	})
+}
+
+func TestConversation_TimeOrder(t *testing.T) {
+	// the customer answers inside a pause shorter than MaxGap
+	resp := &api.PreRecordedResponse{
+		Results: &api.Result{
+			Channels: []api.Channel{
+				channelOf(
+					api.Word{Word: "are", PunctuatedWord: "Are", Start: 0.0, End: 0.5},
+					api.Word{Word: "you", PunctuatedWord: "you", Start: 0.5, End: 1.0},
+					api.Word{Word: "there", PunctuatedWord: "there?", Start: 2.0, End: 3.0},
+				),
+				channelOf(
+					api.Word{Word: "yes", PunctuatedWord: "Yes.", Start: 1.2, End: 1.8},
+				),
+			},
+		},
+	}
+
+	conv, err := conversation.Merge(resp, &conversation.Options{
+		ChannelRoles: map[int]string{0: "Agent", 1: "Customer"},
+	})
+	if err != nil {
+		t.Fatalf("Merge failed. Err: %v", err)
+	}
+
+	expected := "Agent: Are you\nCustomer: Yes.\nAgent: there?\n"
+	if conv.Text() != expected {
+		t.Errorf("unexpected text:\n%s", conv.Text())
+	}
+}
+
+func TestConversation_ChannelSpeakerRoles(t *testing.T) {
+	zero, one := 0, 1
+	resp := &api.PreRecordedResponse{
+		Results: &api.Result{
+			Channels: []api.Channel{
+				channelOf(
+					api.Word{Word: "hello", Start: 0.0, End: 0.5, Speaker: &zero},
+					api.Word{Word: "hey", Start: 1.0, End: 1.5, Speaker: &one},
+				),
+				channelOf(
+					api.Word{Word: "hi", Start: 2.0, End: 2.5, Speaker: &zero},
+				),
+			},
+		},
+	}
+
+	// speaker 0 on channel 1 is not speaker 0 on channel 0
+	conv, err := conversation.Merge(resp, &conversation.Options{
+		SpeakerRoles: map[int]string{0: "Agent"},
+		ChannelSpeakerRoles: map[int]map[int]string{
+			0: {0: "Agent", 1: "Supervisor"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Merge failed. Err: %v", err)
+	}
+
+	expected := "Agent: hello\nSupervisor: hey\nChannel 1 Speaker 0: hi\n"
+	if conv.Text() != expected {
+		t.Errorf("unexpected text:\n%s", conv.Text())
+	}
+}
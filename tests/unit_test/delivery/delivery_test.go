@@ -0,0 +1,181 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	websocketv1api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket"
+	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+	delivery "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1/delivery"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+)
+
+type events struct {
+	mu     sync.Mutex
+	events []interfaces.SlowConsumerEvent
+}
+
+func (e *events) record(event *interfaces.SlowConsumerEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, *event)
+}
+
+func (e *events) count() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.events)
+}
+
+func TestDelivery_DropPolicies(t *testing.T) {
+	rec := &events{}
+	d := delivery.New(context.Background(), &interfaces.ChanDeliveryOptions{
+		Policy:         interfaces.DeliveryDropNewest,
+		Policies:       map[string]interfaces.DeliveryPolicy{"Results": interfaces.DeliveryDropOldest},
+		OnSlowConsumer: rec.record,
+	})
+
+	newest := make(chan int, 2)
+	oldest := make(chan int, 2)
+	for i := 1; i <= 4; i++ {
+		if err := delivery.Send(d, "Metadata", &newest, i); err != nil {
+			t.Fatalf("Send failed. Err: %v", err)
+		}
+		if err := delivery.Send(d, "Results", &oldest, i); err != nil {
+			t.Fatalf("Send failed. Err: %v", err)
+		}
+	}
+
+	if a, b := <-newest, <-newest; a != 1 || b != 2 {
+		t.Errorf("drop_newest kept %d, %d, want 1, 2", a, b)
+	}
+	if a, b := <-oldest, <-oldest; a != 3 || b != 4 {
+		t.Errorf("drop_oldest kept %d, %d, want 3, 4", a, b)
+	}
+
+	stats := d.Stats()
+	if s := stats["Metadata#0"]; s.Delivered != 2 || s.Dropped != 2 {
+		t.Errorf("Metadata#0 stats = %+v", s)
+	}
+	// drop_oldest delivers every message and evicts the ones nobody read
+	if s := stats["Results#0"]; s.Delivered != 4 || s.Dropped != 2 {
+		t.Errorf("Results#0 stats = %+v", s)
+	}
+	if stats["Results#0"].Policy != interfaces.DeliveryDropOldest {
+		t.Errorf("Results#0 policy = %s", stats["Results#0"].Policy)
+	}
+	// drop warnings are rate limited to one per threshold
+	if n := rec.count(); n != 2 {
+		t.Errorf("slow consumer events = %d, want 2", n)
+	}
+}
+
+func TestDelivery_Unbounded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &events{}
+	d := delivery.New(ctx, &interfaces.ChanDeliveryOptions{
+		Policy:         interfaces.DeliveryUnbounded,
+		QueueWarning:   5,
+		OnSlowConsumer: rec.record,
+	})
+
+	ch := make(chan int)
+	for i := 0; i < 10; i++ {
+		if err := delivery.Send(d, "Results", &ch, i); err != nil {
+			t.Fatalf("Send failed. Err: %v", err)
+		}
+	}
+
+	for i := 0; i < 10; i++ {
+		select {
+		case v := <-ch:
+			if v != i {
+				t.Fatalf("received %d, want %d", v, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for message %d", i)
+		}
+	}
+
+	s := d.Stats()["Results#0"]
+	if s.MaxQueued < 6 || s.Dropped != 0 {
+		t.Errorf("stats = %+v", s)
+	}
+	if rec.count() != 1 || rec.events[0].Queued != 6 {
+		t.Errorf("slow consumer events = %+v, want one at 6 queued", rec.events)
+	}
+}
+
+func TestDelivery_BlockHonorsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	rec := &events{}
+	d := delivery.New(ctx, &interfaces.ChanDeliveryOptions{
+		SlowConsumerThreshold: 10 * time.Millisecond,
+		OnSlowConsumer:        rec.record,
+	})
+
+	ch := make(chan int)
+	done := make(chan error, 1)
+	go func() {
+		done <- delivery.Send(d, "Results", &ch, 1)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Send err = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Send did not return after the context was canceled")
+	}
+
+	if rec.count() == 0 || rec.events[0].Blocked < 10*time.Millisecond {
+		t.Errorf("slow consumer events = %+v", rec.events)
+	}
+	if s := d.Stats()["Results#0"]; s.Dropped != 1 || s.Delivered != 0 {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+type resultsOnly struct {
+	websocketv1api.DefaultChanHandler
+	results chan *msginterfaces.MessageResponse
+}
+
+func (r *resultsOnly) GetMessage() []*chan *msginterfaces.MessageResponse {
+	return []*chan *msginterfaces.MessageResponse{&r.results}
+}
+
+func TestDelivery_ChanRouter(t *testing.T) {
+	chans := &resultsOnly{results: make(chan *msginterfaces.MessageResponse, 1)}
+	router := websocketv1api.NewChanRouterWithOptions(context.Background(), chans, &interfaces.ChanDeliveryOptions{
+		Policy: interfaces.DeliveryDropOldest,
+	})
+
+	// nobody reads, the router must not stall
+	for i := 0; i < 3; i++ {
+		if err := router.Message([]byte(`{"type":"Results","start":` + string(rune('0'+i)) + `}`)); err != nil {
+			t.Fatalf("Message failed. Err: %v", err)
+		}
+	}
+
+	if msg := <-chans.results; msg.Start != 2 {
+		t.Errorf("latest message start = %v, want 2", msg.Start)
+	}
+	if s := router.DeliveryStats()["Results#0"]; s.Delivered != 3 || s.Dropped != 2 {
+		t.Errorf("stats = %+v", s)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+
+	eval "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/eval"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+)
+
+func TestEval_Normalize(t *testing.T) {
+	n := eval.DefaultNormalization()
+
+	cases := map[string][]string{
+		"Hello, World!":                            {"hello", "world"},
+		"I don't know, um, twenty-one people.":     {"i", "don't", "know", "21", "people"},
+		"one hundred and five thousand dollars":    {"105000", "dollars"},
+		"call one two three":                       {"call", "1", "2", "3"},
+		"It costs $1,000.50 (approximately).":      {"it", "costs", "$1000.50", "approximately"},
+		"two thousand twenty four was a leap year": {"2024", "was", "a", "leap", "year"},
+	}
+
+	for input, expected := range cases {
+		if got := eval.Normalize(input, n); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Normalize(%q) = %v, expected %v", input, got, expected)
+		}
+	}
+}
+
+func TestEval_CompareText(t *testing.T) {
+	t.Run("Test_substitution_insertion_deletion", func(t *testing.T) {
+		n := eval.DefaultNormalization()
+		reference := "the cat sat on the mat"
+
+		result := eval.CompareText("the bat sat on the mat", reference, n)
+		if result.Substitutions != 1 || result.Insertions != 0 || result.Deletions != 0 {
+			t.Errorf("unexpected breakdown: S:%d I:%d D:%d", result.Substitutions, result.Insertions, result.Deletions)
+		}
+
+		result = eval.CompareText("the cat sat down on the mat", reference, n)
+		if result.Substitutions != 0 || result.Insertions != 1 || result.Deletions != 0 {
+			t.Errorf("unexpected breakdown: S:%d I:%d D:%d", result.Substitutions, result.Insertions, result.Deletions)
+		}
+
+		result = eval.CompareText("the bat sat on mat", reference, n)
+		if result.Substitutions != 1 || result.Insertions != 0 || result.Deletions != 1 {
+			t.Errorf("unexpected breakdown: S:%d I:%d D:%d", result.Substitutions, result.Insertions, result.Deletions)
+		}
+		if result.WER != 2.0/6.0 {
+			t.Errorf("expected WER 0.333, got %f", result.WER)
+		}
+		if result.Diff() != "the [-cat-]{+bat+} sat on [-the-] mat" {
+			t.Errorf("unexpected diff: %s", result.Diff())
+		}
+	})
+
+	t.Run("Test_identical_text", func(t *testing.T) {
+		result := eval.CompareText("Hello there.", "hello there", eval.DefaultNormalization())
+		if result.WER != 0 || result.CER != 0 {
+			t.Errorf("expected a perfect score, got WER %f CER %f", result.WER, result.CER)
+		}
+	})
+
+	t.Run("Test_compare_responses", func(t *testing.T) {
+		newResp := func(transcript string) *api.PreRecordedResponse {
+			return &api.PreRecordedResponse{
+				Results: &api.Result{
+					Channels: []api.Channel{{Alternatives: []api.Alternative{{Transcript: transcript}}}},
+				},
+			}
+		}
+
+		result, err := eval.CompareResponses(newResp("a b c d"), newResp("a b x d"), eval.DefaultNormalization())
+		if err != nil {
+			t.Fatalf("CompareResponses failed. Err: %v", err)
+		}
+		if result.WER != 0.25 {
+			t.Errorf("expected WER 0.25, got %f", result.WER)
+		}
+	})
+}
+
+func TestEval_EvaluateDirKeepsOptions(t *testing.T) {
+	e := eval.NewEvaluator(client.New("mock-api-key", &interfaces.ClientOptions{}))
+
+	normalization := eval.DefaultNormalization()
+	opts := &eval.BatchOptions{Normalization: &normalization}
+	if _, err := e.EvaluateDir(context.Background(), t.TempDir(), opts); err != nil {
+		t.Fatalf("EvaluateDir failed. Err: %v", err)
+	}
+	if opts.Transcription != nil {
+		t.Errorf("EvaluateDir set the caller's Transcription options to %+v", opts.Transcription)
+	}
+}
+
+func TestEval_EvaluateDirDefaultNormalization(t *testing.T) {
+	c := client.New("mock-api-key", &interfaces.ClientOptions{})
+	httpmock.ActivateNonDefault(&c.HTTPClient.Client)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://api.deepgram.com/v1/listen", httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{
+		"results": map[string]any{"channels": []any{map[string]any{"alternatives": []any{map[string]any{"transcript": "Hello, World."}}}}},
+	}))
+
+	dir := t.TempDir()
+	wavHeader := append([]byte("RIFF\x24\x00\x00\x00WAVEfmt "), make([]byte, 100)...)
+	if err := os.WriteFile(filepath.Join(dir, "call1.wav"), wavHeader, 0o600); err != nil {
+		t.Fatalf("os.WriteFile failed. Err: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "call1.txt"), []byte("hello world"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile failed. Err: %v", err)
+	}
+
+	// options without Normalization still ignore case and punctuation
+	e := eval.NewEvaluator(c)
+	report, err := e.EvaluateDir(context.Background(), dir, &eval.BatchOptions{
+		Transcription: &interfaces.PreRecordedTranscriptionOptions{Model: "nova-3"},
+	})
+	if err != nil {
+		t.Fatalf("EvaluateDir failed. Err: %v", err)
+	}
+	if len(report.Items) != 1 || report.Items[0].Error != "" || report.Total.WER != 0 {
+		t.Errorf("report = %+v", report)
+	}
+}
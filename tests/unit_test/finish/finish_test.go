@@ -0,0 +1,193 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dvonthenen/websocket"
+
+	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+	agentws "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/agent/v1/websocket"
+	common "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	listenws "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/websocket"
+	speakws "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/speak/v1/websocket"
+)
+
+const mockAPIKey string = "mock-api-key"
+
+// newServer upgrades every request and passes each message received to reply
+func newServer(t *testing.T, reply func(conn *websocket.Conn, byMsg []byte)) (*httptest.Server, *interfaces.ClientOptions) {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, byMsg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			reply(conn, byMsg)
+		}
+	}))
+
+	return server, &interfaces.ClientOptions{
+		APIKey: mockAPIKey,
+		Host:   "ws://" + strings.TrimPrefix(server.URL, "http://"),
+	}
+}
+
+func TestFinish_Listen(t *testing.T) {
+	server, cOptions := newServer(t, func(conn *websocket.Conn, byMsg []byte) {
+		if !strings.Contains(string(byMsg), "CloseStream") {
+			return
+		}
+		// the server flushes its last transcript, then the summary, then closes
+		time.Sleep(300 * time.Millisecond)
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"Results","is_final":true,"channel":{"alternatives":[{"transcript":"goodbye"}]}}`))
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"Metadata","request_id":"req-1","duration":12.5}`))
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dg, err := listenws.NewUsingChanWithCancel(ctx, cancel, "", cOptions, &interfaces.LiveTranscriptionOptions{Model: "nova-3"}, nil)
+	if err != nil {
+		t.Fatalf("NewUsingChan failed. Err: %v", err)
+	}
+	if err := dg.ConnectContext(ctx); err != nil {
+		t.Fatalf("ConnectContext failed. Err: %v", err)
+	}
+	results, unsubscribe := dg.Subscribe(&msginterfaces.EventFilter{Types: []string{string(msginterfaces.TypeMessageResponse)}})
+	defer unsubscribe()
+
+	finishCtx, finishCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer finishCancel()
+	metadata, err := dg.FinishContext(finishCtx)
+	if err != nil {
+		t.Fatalf("FinishContext failed. Err: %v", err)
+	}
+	if metadata.RequestID != "req-1" || metadata.Duration != 12.5 {
+		t.Errorf("metadata = %+v", metadata)
+	}
+
+	// the final transcript was delivered before FinishContext returned
+	select {
+	case e, ok := <-results:
+		if !ok || e.Message.Channel.Alternatives[0].Transcript != "goodbye" {
+			t.Errorf("final result = %+v", e)
+		}
+	default:
+		t.Errorf("final result was not delivered")
+	}
+	if dg.State() != common.StateClosed {
+		t.Errorf("State() = %s, want Closed", dg.State())
+	}
+}
+
+func TestFinish_ListenContextEnds(t *testing.T) {
+	// a server which never answers CloseStream
+	server, cOptions := newServer(t, func(*websocket.Conn, []byte) {})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dg, err := listenws.NewUsingChanWithCancel(ctx, cancel, "", cOptions, &interfaces.LiveTranscriptionOptions{Model: "nova-3"}, nil)
+	if err != nil {
+		t.Fatalf("NewUsingChan failed. Err: %v", err)
+	}
+	if err := dg.ConnectContext(ctx); err != nil {
+		t.Fatalf("ConnectContext failed. Err: %v", err)
+	}
+
+	finishCtx, finishCancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer finishCancel()
+	if _, err := dg.FinishContext(finishCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FinishContext err = %v, want context.DeadlineExceeded", err)
+	}
+	if dg.State() != common.StateClosed {
+		t.Errorf("State() = %s, want Closed", dg.State())
+	}
+}
+
+func TestFinish_Speak(t *testing.T) {
+	server, cOptions := newServer(t, func(conn *websocket.Conn, byMsg []byte) {
+		if strings.Contains(string(byMsg), `"Flush"`) {
+			_ = conn.WriteMessage(websocket.BinaryMessage, make([]byte, 32))
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"Flushed","sequence_id":3}`))
+		}
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dg, err := speakws.NewUsingChanWithCancel(ctx, cancel, "", cOptions, &interfaces.WSSpeakOptions{Model: "aura-2-thalia-en"}, nil)
+	if err != nil {
+		t.Fatalf("NewUsingChan failed. Err: %v", err)
+	}
+	if err := dg.ConnectContext(ctx); err != nil {
+		t.Fatalf("ConnectContext failed. Err: %v", err)
+	}
+
+	finishCtx, finishCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer finishCancel()
+	flushed, err := dg.FinishContext(finishCtx)
+	if err != nil {
+		t.Fatalf("FinishContext failed. Err: %v", err)
+	}
+	if flushed.SequenceID != 3 {
+		t.Errorf("flushed = %+v", flushed)
+	}
+}
+
+func TestFinish_Agent(t *testing.T) {
+	server, cOptions := newServer(t, func(conn *websocket.Conn, byMsg []byte) {
+		if !strings.Contains(string(byMsg), `"Settings"`) {
+			return
+		}
+		// the agent is mid-reply when the client finishes
+		_ = conn.WriteMessage(websocket.BinaryMessage, make([]byte, 32))
+		go func() {
+			time.Sleep(300 * time.Millisecond)
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"AgentAudioDone"}`))
+		}()
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dg, err := agentws.NewUsingChanWithCancel(ctx, cancel, "", cOptions, interfaces.NewSettingsConfigurationOptions(), nil)
+	if err != nil {
+		t.Fatalf("NewUsingChan failed. Err: %v", err)
+	}
+	if err := dg.ConnectContext(ctx); err != nil {
+		t.Fatalf("ConnectContext failed. Err: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	finishCtx, finishCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer finishCancel()
+	start := time.Now()
+	done, err := dg.FinishContext(finishCtx)
+	if err != nil {
+		t.Fatalf("FinishContext failed. Err: %v", err)
+	}
+	if done == nil || time.Since(start) < 100*time.Millisecond {
+		t.Errorf("FinishContext returned %+v after %v, want to wait for AgentAudioDone", done, time.Since(start))
+	}
+}
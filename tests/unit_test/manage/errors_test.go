@@ -0,0 +1,72 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+)
+
+func TestManage_ErrorResponses(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mg := newManageClient()
+	httpmock.RegisterNoResponder(func(r *http.Request) (*http.Response, error) {
+		res := httpmock.NewStringResponse(http.StatusForbidden, `{"err_code":"FORBIDDEN","err_msg":"forbidden"}`)
+		res.Request = r
+		return res, nil
+	})
+
+	ctx := context.Background()
+	calls := map[string]func() error{
+		"GetBalance": func() error {
+			_, err := mg.GetBalance(ctx, mockProjectID, "balance-1")
+			return err
+		},
+		"GetModel": func() error {
+			_, err := mg.GetModel(ctx, "model-1")
+			return err
+		},
+		"GetProjectModel": func() error {
+			_, err := mg.GetProjectModel(ctx, mockProjectID, "model-1")
+			return err
+		},
+		"GetProject": func() error {
+			_, err := mg.GetProject(ctx, mockProjectID)
+			return err
+		},
+		"UpdateProject": func() error {
+			_, err := mg.UpdateProject(ctx, mockProjectID, &api.ProjectUpdateRequest{Name: "renamed"})
+			return err
+		},
+		"DeleteProject": func() error {
+			_, err := mg.DeleteProject(ctx, mockProjectID)
+			return err
+		},
+		"GetRequest": func() error {
+			_, err := mg.GetRequest(ctx, mockProjectID, "request-1")
+			return err
+		},
+		"GetFields": func() error {
+			_, err := mg.GetFields(ctx, mockProjectID, &api.UsageListRequest{})
+			return err
+		},
+		"GetUsage": func() error {
+			_, err := mg.GetUsage(ctx, mockProjectID, &api.UsageRequest{})
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); err == nil {
+			t.Errorf("%s succeeded, want the 403 error", name)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/manage"
+)
+
+/* #nosec G101 */
+const mockAPIKey = "m0ckap1k3y0bbc125dac7f40ed3eb0ed232a2ff8"
+
+const (
+	mockProjectID = "project-1"
+	requestsURL   = "https://api.deepgram.com/v1/projects/" + mockProjectID + "/requests"
+	totalRequests = 25
+)
+
+func newManageClient() *manage.Client {
+	c := client.New(mockAPIKey, &interfaces.ClientOptions{})
+	httpmock.ActivateNonDefault(&c.HTTPClient.Client)
+	return manage.New(c)
+}
+
+// mockRequests serves totalRequests requests in pages of ?limit=
+func mockRequests(calls *int32) {
+	httpmock.RegisterResponder("GET", requestsURL, func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(calls, 1)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		requests := make([]api.Request, 0)
+		for i := page * limit; i < (page+1)*limit && i < totalRequests; i++ {
+			requests = append(requests, api.Request{RequestID: fmt.Sprintf("req-%d", i)})
+		}
+
+		return httpmock.NewJsonResponse(200, api.RequestList{Page: page, Limit: limit, Requests: requests})
+	})
+}
+
+func TestManage_IterateRequests(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for _, prefetch := range []int{0, 3} {
+		t.Run(fmt.Sprintf("prefetch_%d", prefetch), func(t *testing.T) {
+			var calls int32
+			mockRequests(&calls)
+
+			mg := newManageClient()
+			it := mg.IterateRequests(context.Background(), mockProjectID, &api.UsageListRequest{Limit: 10}, &manage.IteratorOptions{Prefetch: prefetch})
+
+			requests, err := it.All()
+			if err != nil {
+				t.Fatalf("All failed. Err: %v", err)
+			}
+			if len(requests) != totalRequests {
+				t.Fatalf("expected %d requests, got %d", totalRequests, len(requests))
+			}
+			for i, req := range requests {
+				if req.RequestID != fmt.Sprintf("req-%d", i) {
+					t.Errorf("request %d out of order: %s", i, req.RequestID)
+				}
+			}
+			if prefetch == 0 && atomic.LoadInt32(&calls) != 3 {
+				t.Errorf("expected 3 page fetches, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestManage_IterateRequestsLazy(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var calls int32
+	mockRequests(&calls)
+
+	mg := newManageClient()
+	it := mg.IterateRequests(context.Background(), mockProjectID, &api.UsageListRequest{Limit: 10}, nil)
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Errorf("expected no fetch before Next")
+	}
+
+	for i := 0; i < 10 && it.Next(); i++ {
+	}
+	it.Close()
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected 1 page fetch, got %d", calls)
+	}
+	if it.Next() {
+		t.Errorf("expected Next to return false after Close")
+	}
+}
+
+func TestManage_IterateRequestsCanceled(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var calls int32
+	mockRequests(&calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mg := newManageClient()
+	it := mg.IterateRequests(ctx, mockProjectID, &api.UsageListRequest{Limit: 10}, nil)
+
+	if !it.Next() {
+		t.Fatalf("expected a first item. Err: %v", it.Err())
+	}
+	cancel()
+
+	for it.Next() {
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", it.Err())
+	}
+}
+
+func TestManage_IterateRequestsError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", requestsURL, httpmock.NewStringResponder(http.StatusForbidden, "forbidden"))
+
+	mg := newManageClient()
+	it := mg.IterateRequests(context.Background(), mockProjectID, nil, nil)
+	if it.Next() {
+		t.Errorf("expected no items")
+	}
+	if it.Err() == nil {
+		t.Errorf("expected an error")
+	}
+}
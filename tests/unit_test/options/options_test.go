@@ -0,0 +1,180 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+)
+
+// problemFields returns the fields reported by a Check() error
+func problemFields(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, interfaces.ErrInvalidOptions) {
+		t.Fatalf("errors.Is(ErrInvalidOptions) = false for %v", err)
+	}
+	var oe *interfaces.OptionsError
+	if !errors.As(err, &oe) {
+		t.Fatalf("error is not an *OptionsError: %v", err)
+	}
+
+	fields := make([]string, 0, len(oe.Problems))
+	for _, p := range oe.Problems {
+		fields = append(fields, p.Field)
+	}
+	return fields
+}
+
+func expectFields(t *testing.T, err error, want ...string) {
+	t.Helper()
+
+	got := problemFields(t, err)
+	if len(got) != len(want) {
+		t.Fatalf("problems = %v, want %v (err: %v)", got, want, err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("problems = %v, want %v (err: %v)", got, want, err)
+		}
+	}
+}
+
+func TestOptions_PreRecorded(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		o := &interfaces.PreRecordedTranscriptionOptions{
+			Model:          "nova-3",
+			Keyterm:        []string{"deepgram"},
+			Summarize:      "v2",
+			Callback:       "https://example.com/callback",
+			CallbackMethod: "PUT",
+			Encoding:       "linear16",
+			SampleRate:     16000,
+		}
+		expectFields(t, o.Check())
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var o *interfaces.PreRecordedTranscriptionOptions
+		expectFields(t, o.Check())
+	})
+
+	t.Run("EveryProblem", func(t *testing.T) {
+		o := &interfaces.PreRecordedTranscriptionOptions{
+			Model:          "nova-2",
+			Keyterm:        []string{"deepgram"},
+			Encoding:       "mulaw",
+			Alternatives:   -1,
+			Summarize:      "v1",
+			CallbackMethod: "GET",
+		}
+		expectFields(t, o.Check(), "alternatives", "sample_rate", "keyterm", "callback_method", "callback_method", "summarize")
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		o := &interfaces.PreRecordedTranscriptionOptions{
+			Model:            "nova-3",
+			Keywords:         []string{"deepgram:2"},
+			Dictation:        true,
+			SampleRate:       8000,
+			CustomIntentMode: "strict",
+			DetectLanguage:   true,
+			Language:         "en",
+		}
+		expectFields(t, o.Check(), "encoding", "keywords", "dictation", "custom_intent_mode", "detect_language")
+	})
+}
+
+func TestOptions_Live(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		o := &interfaces.LiveTranscriptionOptions{
+			Model:          "nova-3",
+			Encoding:       "linear16",
+			SampleRate:     16000,
+			Endpointing:    "300",
+			InterimResults: true,
+			UtteranceEndMs: "1000",
+		}
+		expectFields(t, o.Check())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		o := &interfaces.LiveTranscriptionOptions{
+			Encoding:       "wav",
+			Endpointing:    "soon",
+			UtteranceEndMs: "500",
+		}
+		expectFields(t, o.Check(), "encoding", "endpointing", "utterance_end_ms", "utterance_end_ms")
+	})
+}
+
+func TestOptions_Analyze(t *testing.T) {
+	expectFields(t, (&interfaces.AnalyzeOptions{Language: "en", Summarize: true}).Check())
+
+	o := &interfaces.AnalyzeOptions{
+		Language:        "fr",
+		Callback:        "not a url",
+		CustomTopicMode: "loose",
+	}
+	expectFields(t, o.Check(), "callback", "custom_topic_mode", "custom_topic_mode", "language")
+}
+
+func TestOptions_Speak(t *testing.T) {
+	expectFields(t, (&interfaces.SpeakOptions{Model: "aura-2-thalia-en", Encoding: "linear16", Container: "wav", SampleRate: 24000}).Check())
+	expectFields(t, (&interfaces.SpeakOptions{Encoding: "mp3", BitRate: 48000}).Check())
+
+	o := &interfaces.SpeakOptions{
+		Encoding:   "mp3",
+		Container:  "ogg",
+		SampleRate: 16000,
+		BitRate:    64000,
+	}
+	expectFields(t, o.Check(), "sample_rate", "bit_rate", "container")
+
+	expectFields(t, (&interfaces.WSSpeakOptions{Encoding: "linear16", SampleRate: 48000}).Check())
+	expectFields(t, (&interfaces.WSSpeakOptions{Encoding: "mp3"}).Check(), "encoding")
+	expectFields(t, (&interfaces.WSSpeakOptions{Encoding: "mulaw", SampleRate: 44100}).Check(), "sample_rate")
+}
+
+func TestOptions_Settings(t *testing.T) {
+	expectFields(t, interfaces.NewSettingsConfigurationOptions().Check())
+
+	o := interfaces.NewSettingsConfigurationOptions()
+	o.Type = "Config"
+	o.Audio.Input.SampleRate = 0
+	o.Audio.Output.Container = "ogg"
+	o.Agent.Think.Provider = nil
+	o.Agent.Think.ContextLength = "all"
+	expectFields(t, o.Check(), "type", "audio.input.sample_rate", "audio.output.container", "agent.think.provider.type", "agent.think.context_length")
+
+	o = interfaces.NewSettingsConfigurationOptions()
+	o.Agent.Think.ContextLength = "max"
+	expectFields(t, o.Check())
+}
+
+func TestOptions_CheckedBeforeRequest(t *testing.T) {
+	c := client.New("mock-api-key", &interfaces.ClientOptions{})
+
+	// nothing is listening on this host, so only a validation error can come back quickly
+	options := &interfaces.PreRecordedTranscriptionOptions{Model: "nova-2", Keyterm: []string{"deepgram"}}
+
+	err := c.DoStream(context.Background(), bytes.NewReader([]byte("audio")), options, nil)
+	if !errors.Is(err, interfaces.ErrInvalidOptions) {
+		t.Errorf("DoStream err = %v, want ErrInvalidOptions", err)
+	}
+
+	err = c.DoURL(context.Background(), "https://example.com/audio.wav", options, nil)
+	if !errors.Is(err, interfaces.ErrInvalidOptions) {
+		t.Errorf("DoURL err = %v, want ErrInvalidOptions", err)
+	}
+}
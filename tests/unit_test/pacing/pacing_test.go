@@ -0,0 +1,139 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	wav "github.com/youpy/go-wav"
+
+	pacing "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/pacing"
+	replay "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/replay"
+)
+
+// countingWriter records the size of every write
+type countingWriter struct {
+	writes []int
+	total  int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, len(p))
+	w.total += len(p)
+	return len(p), nil
+}
+
+func TestPacing_FrameBytes(t *testing.T) {
+	tests := []struct {
+		opts pacing.Options
+		want int
+	}{
+		{pacing.Options{Encoding: "linear16", SampleRate: 16000}, 640},
+		{pacing.Options{Encoding: "linear16", SampleRate: 48000, Channels: 2, FrameMs: 100}, 19200},
+		{pacing.Options{Encoding: "mulaw", SampleRate: 8000, FrameMs: 50}, 400},
+	}
+	for _, tt := range tests {
+		pacer, err := pacing.New(tt.opts)
+		if err != nil {
+			t.Fatalf("New(%+v) failed. Err: %v", tt.opts, err)
+		}
+		if got := pacer.FrameBytes(); got != tt.want {
+			t.Errorf("New(%+v).FrameBytes() = %d, want %d", tt.opts, got, tt.want)
+		}
+	}
+
+	if _, err := pacing.New(pacing.Options{Encoding: "flac", SampleRate: 16000}); !errors.Is(err, pacing.ErrUnsupportedEncoding) {
+		t.Errorf("New(flac) err = %v, want ErrUnsupportedEncoding", err)
+	}
+	if _, err := pacing.New(pacing.Options{Encoding: "linear16"}); !errors.Is(err, pacing.ErrInvalidInput) {
+		t.Errorf("New without a sample rate err = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestPacing_Copy(t *testing.T) {
+	// 1s of audio at 10x takes 100ms, less the first frame which is sent immediately
+	pacer, err := pacing.New(pacing.Options{Encoding: "linear16", SampleRate: 16000, FrameMs: 100, Speed: 10})
+	if err != nil {
+		t.Fatalf("New failed. Err: %v", err)
+	}
+
+	w := &countingWriter{}
+	start := time.Now()
+	written, err := pacer.Copy(context.Background(), w, bytes.NewReader(make([]byte, 32000)))
+	elapsed := time.Since(start)
+	if err != nil || written != 32000 {
+		t.Fatalf("Copy = %d, %v", written, err)
+	}
+	if elapsed < 80*time.Millisecond || elapsed > 500*time.Millisecond {
+		t.Errorf("Copy took %v, want about 90ms", elapsed)
+	}
+	if len(w.writes) != 10 || w.writes[0] != 3200 {
+		t.Errorf("writes = %v, want 10 frames of 3200 bytes", w.writes)
+	}
+	if pacer.Position() != time.Second {
+		t.Errorf("Position() = %v, want 1s", pacer.Position())
+	}
+}
+
+func TestPacing_CopyCanceled(t *testing.T) {
+	pacer, err := pacing.New(pacing.Options{Encoding: "linear16", SampleRate: 16000})
+	if err != nil {
+		t.Fatalf("New failed. Err: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = pacer.Copy(ctx, &countingWriter{}, bytes.NewReader(make([]byte, 320000)))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Copy err = %v, want context.DeadlineExceeded", err)
+	}
+	if pos := pacer.Position(); pos < 80*time.Millisecond || pos > 200*time.Millisecond {
+		t.Errorf("Position() = %v, want about 100ms", pos)
+	}
+}
+
+func TestPacing_Replay(t *testing.T) {
+	// 500ms of 8kHz mono linear16
+	filename := filepath.Join(t.TempDir(), "tone.wav")
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatalf("os.Create failed. Err: %v", err)
+	}
+	writer := wav.NewWriter(f, 4000, 1, 8000, 16)
+	if err := writer.WriteSamples(make([]wav.Sample, 4000)); err != nil {
+		t.Fatalf("WriteSamples failed. Err: %v", err)
+	}
+	f.Close()
+
+	play, err := replay.New(replay.Options{FullFilename: filename, RealTime: true, FrameMs: 100, Speed: 5})
+	if err != nil {
+		t.Fatalf("replay.New failed. Err: %v", err)
+	}
+	if err := play.Start(); err != nil {
+		t.Fatalf("Start failed. Err: %v", err)
+	}
+	defer play.Stop()
+
+	w := &countingWriter{}
+	start := time.Now()
+	if err := play.Stream(w); err != nil {
+		t.Fatalf("Stream failed. Err: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("Stream took %v, want about 80ms at 5x", elapsed)
+	}
+	if w.total != 8000 || w.writes[0] != 1600 {
+		t.Errorf("writes = %v", w.writes)
+	}
+	if play.Position() != 500*time.Millisecond {
+		t.Errorf("Position() = %v, want 500ms", play.Position())
+	}
+}
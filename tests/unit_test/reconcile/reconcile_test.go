@@ -0,0 +1,189 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+	reconcile "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/reconcile"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/manage"
+)
+
+/* #nosec G101 */
+const mockAPIKey = "m0ckap1k3y0bbc125dac7f40ed3eb0ed232a2ff8"
+
+const projectURL = "https://api.deepgram.com/v1/projects/project-1"
+
+const desiredYAML = `
+projects:
+  - id: project-1
+    prune: true
+    members:
+      - email: Alice@example.com
+        scope: admin
+      - email: owner@example.com
+        scope: member
+      - email: dave@example.com
+        scope: member
+    invites:
+      - email: carol@example.com
+        scope: admin
+`
+
+func newManageClient() *manage.Client {
+	c := client.New(mockAPIKey, &interfaces.ClientOptions{})
+	httpmock.ActivateNonDefault(&c.HTTPClient.Client)
+	return manage.New(c)
+}
+
+func mockProject() {
+	httpmock.RegisterResponder("GET", projectURL+"/members", httpmock.NewJsonResponderOrPanic(200, api.MemberList{Members: []api.Member{
+		{MemberID: "m-owner", Email: "owner@example.com", Scopes: []string{"owner"}},
+		{MemberID: "m-alice", Email: "alice@example.com", Scopes: []string{"member"}},
+		{MemberID: "m-bob", Email: "bob@example.com", Scopes: []string{"member"}},
+	}}))
+	httpmock.RegisterResponder("GET", projectURL+"/invites", httpmock.NewJsonResponderOrPanic(200, api.InvitesList{Invites: []api.Invite{
+		{Email: "carol@example.com", Scope: "member"},
+		{Email: "eve@example.com", Scope: "member"},
+	}}))
+}
+
+func TestReconcile_Plan(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	mockProject()
+
+	desired, err := reconcile.ParseYAML([]byte(desiredYAML))
+	if err != nil {
+		t.Fatalf("ParseYAML failed. Err: %v", err)
+	}
+
+	r, err := reconcile.New(newManageClient(), reconcile.Options{})
+	if err != nil {
+		t.Fatalf("reconcile.New failed. Err: %v", err)
+	}
+
+	plan, err := r.Plan(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("Plan failed. Err: %v", err)
+	}
+
+	expected := "project project-1:\n" +
+		"  ~ member alice@example.com: member -> admin\n" +
+		"  - invite carol@example.com (member)\n" +
+		"  - invite eve@example.com (member)\n" +
+		"  + invite dave@example.com (member)\n" +
+		"  + invite carol@example.com (admin)\n" +
+		"  - member bob@example.com (member)\n" +
+		"  ! owner@example.com is an owner of project project-1 and will not be changed to member\n" +
+		"Plan: 2 to add, 1 to change, 3 to remove.\n"
+	if plan.String() != expected {
+		t.Errorf("unexpected plan:\n%s\nexpected:\n%s", plan.String(), expected)
+	}
+}
+
+func TestReconcile_Apply(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	mockProject()
+
+	var calls []string
+	record := func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/v1/projects/project-1"))
+		return httpmock.NewJsonResponse(200, api.MessageResult{Message: "ok"})
+	}
+	httpmock.RegisterResponder("PUT", `=~^`+projectURL+`/members/.+/scopes\z`, record)
+	httpmock.RegisterResponder("POST", projectURL+"/invites", record)
+	httpmock.RegisterResponder("DELETE", `=~^`+projectURL+`/invites/.+\z`, record)
+	httpmock.RegisterResponder("DELETE", `=~^`+projectURL+`/members/.+\z`, record)
+
+	desired, err := reconcile.ParseYAML([]byte(desiredYAML))
+	if err != nil {
+		t.Fatalf("ParseYAML failed. Err: %v", err)
+	}
+	r, err := reconcile.New(newManageClient(), reconcile.Options{})
+	if err != nil {
+		t.Fatalf("reconcile.New failed. Err: %v", err)
+	}
+	plan, err := r.Plan(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("Plan failed. Err: %v", err)
+	}
+
+	applied, err := r.Apply(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("Apply failed. Err: %v", err)
+	}
+	if len(applied) != len(plan.Actions) {
+		t.Errorf("expected %d applied actions, got %d", len(plan.Actions), len(applied))
+	}
+
+	expected := []string{
+		"PUT /members/m-alice/scopes",
+		"DELETE /invites/carol@example.com",
+		"DELETE /invites/eve@example.com",
+		"POST /invites",
+		"POST /invites",
+		"DELETE /members/m-bob",
+	}
+	if strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected calls:\n%s", strings.Join(calls, "\n"))
+	}
+}
+
+func TestReconcile_Demote(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", projectURL+"/members", httpmock.NewJsonResponderOrPanic(200, api.MemberList{Members: []api.Member{
+		{MemberID: "m-alice", Email: "alice@example.com", Scopes: []string{"admin", "member"}},
+		{MemberID: "m-bob", Email: "bob@example.com", Scopes: []string{"member"}},
+	}}))
+	httpmock.RegisterResponder("GET", projectURL+"/invites", httpmock.NewJsonResponderOrPanic(200, api.InvitesList{}))
+
+	desired, err := reconcile.ParseYAML([]byte(`
+projects:
+  - id: project-1
+    members:
+      - email: alice@example.com
+        scope: member
+      - email: bob@example.com
+        scope: member
+`))
+	if err != nil {
+		t.Fatalf("ParseYAML failed. Err: %v", err)
+	}
+	r, err := reconcile.New(newManageClient(), reconcile.Options{})
+	if err != nil {
+		t.Fatalf("reconcile.New failed. Err: %v", err)
+	}
+	plan, err := r.Plan(context.Background(), desired)
+	if err != nil {
+		t.Fatalf("Plan failed. Err: %v", err)
+	}
+
+	expected := "project project-1:\n" +
+		"  ~ member alice@example.com: admin,member -> member\n" +
+		"Plan: 0 to add, 1 to change, 0 to remove.\n"
+	if plan.String() != expected {
+		t.Errorf("unexpected plan:\n%s\nexpected:\n%s", plan.String(), expected)
+	}
+}
+
+func TestReconcile_InvalidState(t *testing.T) {
+	_, err := reconcile.ParseJSON([]byte(`{"projects": [{"id": "p", "members": [{"email": "a@x.com", "scope": "member"}], "invites": [{"email": "A@x.com", "scope": "admin"}]}]}`))
+	if !errors.Is(err, reconcile.ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/interfaces"
+	redact "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/rest/redact"
+)
+
+func newResponse(words []string) *api.PreRecordedResponse {
+	apiWords := make([]api.Word, 0, len(words))
+	for i, w := range words {
+		apiWords = append(apiWords, api.Word{
+			Word:           strings.ToLower(w),
+			PunctuatedWord: w,
+			Start:          float64(i),
+			End:            float64(i) + 0.5,
+		})
+	}
+	transcript := strings.Join(words, " ")
+
+	return &api.PreRecordedResponse{
+		Metadata: &api.Metadata{RequestID: "test"},
+		Results: &api.Result{
+			Channels: []api.Channel{
+				{
+					Alternatives: []api.Alternative{
+						{
+							Transcript: transcript,
+							Words:      apiWords,
+							Paragraphs: &api.Paragraphs{
+								Transcript: transcript,
+								Paragraphs: []api.Paragraph{
+									{
+										Sentences: []api.Sentence{
+											{Text: transcript, Start: 0, End: float64(len(words))},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Utterances: []api.Utterance{
+				{Transcript: transcript, Words: apiWords, Start: 0, End: float64(len(words))},
+			},
+		},
+	}
+}
+
+func TestRedact_BuiltInRules(t *testing.T) {
+	t.Run("Test_credit_card_with_luhn", func(t *testing.T) {
+		r := redact.NewWithDefaults()
+
+		text, matches := r.RedactText("my card is 4111 1111 1111 1111 thanks")
+		if len(matches) != 1 || matches[0].Label != redact.LabelCreditCard {
+			t.Fatalf("expected one pci match, got %v", matches)
+		}
+		if text != "my card is [PCI] thanks" {
+			t.Errorf("unexpected text: %s", text)
+		}
+
+		_, matches = r.RedactText("my card is 4111 1111 1111 1112 thanks")
+		for _, m := range matches {
+			if m.Label == redact.LabelCreditCard {
+				t.Errorf("number failing luhn should not be labeled pci")
+			}
+		}
+	})
+
+	t.Run("Test_ssn_email_phone", func(t *testing.T) {
+		r := redact.NewWithDefaults()
+
+		text, _ := r.RedactText("ssn 123-45-6789 email john.doe@example.com phone (555) 123-4567")
+		if text != "ssn [SSN] email [EMAIL] phone [PHONE]" {
+			t.Errorf("unexpected text: %s", text)
+		}
+
+		text, _ = r.RedactText("invalid ssn 000-12-3456")
+		if text != "invalid ssn 000-12-3456" {
+			t.Errorf("invalid ssn should not be redacted: %s", text)
+		}
+	})
+
+	t.Run("Test_dictionary_rule", func(t *testing.T) {
+		rule, err := redact.NewDictionaryRule("name", []string{"John Smith"}, false)
+		if err != nil {
+			t.Fatalf("NewDictionaryRule failed. Err: %v", err)
+		}
+		r, err := redact.New(redact.Options{Rules: []redact.Rule{rule}})
+		if err != nil {
+			t.Fatalf("New failed. Err: %v", err)
+		}
+
+		text, _ := r.RedactText("hello john smith, not johnsmithy")
+		if text != "hello [NAME], not johnsmithy" {
+			t.Errorf("unexpected text: %s", text)
+		}
+	})
+
+	t.Run("Test_dictionary_rule_non_ascii", func(t *testing.T) {
+		rule, err := redact.NewDictionaryRule("name", []string{"bob", "zoë"}, false)
+		if err != nil {
+			t.Fatalf("NewDictionaryRule failed. Err: %v", err)
+		}
+		r, err := redact.New(redact.Options{Rules: []redact.Rule{rule}})
+		if err != nil {
+			t.Fatalf("New failed. Err: %v", err)
+		}
+
+		// İ grows when lowercased, which shifted the offsets
+		text, _ := r.RedactText("İİİİ bob")
+		if text != "İİİİ [NAME]" {
+			t.Errorf("unexpected text: %s", text)
+		}
+
+		// a letter outside ASCII is part of the word
+		text, _ = r.RedactText("Zoë met bobé and ébob")
+		if text != "[NAME] met bobé and ébob" {
+			t.Errorf("unexpected text: %s", text)
+		}
+	})
+}
+
+func TestRedact_Response(t *testing.T) {
+	t.Run("Test_consistent_redaction_and_spans", func(t *testing.T) {
+		resp := newResponse([]string{"Call", "me", "at", "555-123-4567.", "Bye."})
+
+		r, err := redact.New(redact.Options{Rules: redact.DefaultRules(), KeepUnredacted: true})
+		if err != nil {
+			t.Fatalf("New failed. Err: %v", err)
+		}
+
+		result, err := r.Redact(resp)
+		if err != nil {
+			t.Fatalf("Redact failed. Err: %v", err)
+		}
+
+		alt := result.Redacted.Results.Channels[0].Alternatives[0]
+		if alt.Words[3].PunctuatedWord != "[PHONE]" {
+			t.Errorf("word not redacted: %s", alt.Words[3].PunctuatedWord)
+		}
+		expected := "Call me at [PHONE]. Bye."
+		if alt.Transcript != expected {
+			t.Errorf("transcript: %s", alt.Transcript)
+		}
+		if alt.Paragraphs.Paragraphs[0].Sentences[0].Text != expected {
+			t.Errorf("sentence: %s", alt.Paragraphs.Paragraphs[0].Sentences[0].Text)
+		}
+		if result.Redacted.Results.Utterances[0].Transcript != expected {
+			t.Errorf("utterance: %s", result.Redacted.Results.Utterances[0].Transcript)
+		}
+
+		if len(result.Spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(result.Spans))
+		}
+		if result.Spans[0].Start != 3 || result.Spans[0].End != 3.5 {
+			t.Errorf("unexpected span: %+v", result.Spans[0])
+		}
+
+		// the input and the unredacted copy are untouched
+		if resp.Results.Channels[0].Alternatives[0].Words[3].PunctuatedWord != "555-123-4567." {
+			t.Errorf("input response was modified")
+		}
+		if result.Unredacted.Results.Channels[0].Alternatives[0].Transcript != resp.Results.Channels[0].Alternatives[0].Transcript {
+			t.Errorf("unredacted copy does not match input")
+		}
+	})
+	t.Run("Test_repeated_value_redacted_once", func(t *testing.T) {
+		// the pin is the same text as part of the card, but only the card is redacted
+		resp := newResponse([]string{"My", "pin", "is", "1111.", "Card", "4111", "1111", "1111", "1111", "thanks."})
+
+		result, err := redact.NewWithDefaults().Redact(resp)
+		if err != nil {
+			t.Fatalf("Redact failed. Err: %v", err)
+		}
+
+		expected := "My pin is 1111. Card [PCI] thanks."
+		alt := result.Redacted.Results.Channels[0].Alternatives[0]
+		if alt.Transcript != expected {
+			t.Errorf("transcript: %s", alt.Transcript)
+		}
+		if alt.Paragraphs.Transcript != expected {
+			t.Errorf("paragraphs: %s", alt.Paragraphs.Transcript)
+		}
+		if result.Redacted.Results.Utterances[0].Transcript != expected {
+			t.Errorf("utterance: %s", result.Redacted.Results.Utterances[0].Transcript)
+		}
+		if alt.Words[3].PunctuatedWord != "1111." {
+			t.Errorf("pin word was redacted: %s", alt.Words[3].PunctuatedWord)
+		}
+	})
+}
@@ -0,0 +1,282 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	flac "github.com/mewkiz/flac"
+	"github.com/mewkiz/flac/frame"
+	"github.com/mewkiz/flac/meta"
+	wav "github.com/youpy/go-wav"
+
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
+	replay "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/replay"
+)
+
+const mp3File string = "../../../examples/speech-to-text/rest/file/Bueller-Life-moves-pretty-fast.mp3"
+
+func start(t *testing.T, opts replay.Options) *replay.Client {
+	t.Helper()
+
+	client, err := replay.New(opts)
+	if err != nil {
+		t.Fatalf("replay.New failed. Err: %v", err)
+	}
+	if err := client.Start(); err != nil {
+		t.Fatalf("Start failed. Err: %v", err)
+	}
+	return client
+}
+
+// writeFLAC writes a 24 bit stereo FLAC file where sample i is i<<8 on the left and -i<<8 on the right
+func writeFLAC(t *testing.T, samples int) string {
+	t.Helper()
+
+	name := filepath.Join(t.TempDir(), "test.flac")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatalf("os.Create failed. Err: %v", err)
+	}
+	defer f.Close()
+
+	info := &meta.StreamInfo{
+		BlockSizeMin:  uint16(samples),
+		BlockSizeMax:  uint16(samples),
+		SampleRate:    16000,
+		NChannels:     2,
+		BitsPerSample: 24,
+	}
+	enc, err := flac.NewEncoder(f, info)
+	if err != nil {
+		t.Fatalf("flac.NewEncoder failed. Err: %v", err)
+	}
+
+	left := make([]int32, samples)
+	right := make([]int32, samples)
+	for i := range left {
+		left[i] = int32(i) << 8
+		right[i] = -int32(i) << 8
+	}
+	err = enc.WriteFrame(&frame.Frame{
+		Header: frame.Header{
+			HasFixedBlockSize: true,
+			BlockSize:         uint16(samples),
+			SampleRate:        16000,
+			Channels:          frame.ChannelsLR,
+			BitsPerSample:     24,
+		},
+		Subframes: []*frame.Subframe{
+			{SubHeader: frame.SubHeader{Pred: frame.PredVerbatim}, Samples: left, NSamples: samples},
+			{SubHeader: frame.SubHeader{Pred: frame.PredVerbatim}, Samples: right, NSamples: samples},
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriteFrame failed. Err: %v", err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close failed. Err: %v", err)
+	}
+	return name
+}
+
+func TestReplay_ReadShortWAV(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "short.wav")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatalf("os.Create failed. Err: %v", err)
+	}
+	w := wav.NewWriter(f, 100, 1, 16000, 16)
+	if _, err := w.Write(make([]byte, 200)); err != nil {
+		t.Fatalf("Write failed. Err: %v", err)
+	}
+	f.Close()
+
+	client := start(t, replay.Options{FullFilename: name})
+	defer client.Stop()
+
+	// only the bytes read are returned, not the whole buffer
+	byData, err := client.Read()
+	if err != nil || len(byData) != 200 {
+		t.Errorf("Read() = %d bytes, err %v", len(byData), err)
+	}
+}
+
+func TestReplay_FLAC(t *testing.T) {
+	client := start(t, replay.Options{FullFilename: writeFLAC(t, 32)})
+	defer client.Stop()
+
+	if client.Container() != replay.ContainerFLAC {
+		t.Errorf("Container() = %q", client.Container())
+	}
+	src, err := client.Source()
+	if err != nil {
+		t.Fatalf("Source failed. Err: %v", err)
+	}
+	if want := (audio.Format{Encoding: audio.EncodingLinear16, SampleRate: 16000, Channels: 2}); !src.Format().Equal(want) {
+		t.Errorf("Format() = %+v", src.Format())
+	}
+
+	byData, err := io.ReadAll(src)
+	if err != nil || len(byData) != 32*4 {
+		t.Fatalf("read %d bytes, err %v", len(byData), err)
+	}
+	// scaled from 24 to 16 bits
+	for i := 0; i < 32; i++ {
+		left := int16(binary.LittleEndian.Uint16(byData[i*4:]))
+		right := int16(binary.LittleEndian.Uint16(byData[i*4+2:]))
+		if int(left) != i || int(right) != -i {
+			t.Fatalf("sample %d = %d,%d", i, left, right)
+		}
+	}
+}
+
+func TestReplay_MP3(t *testing.T) {
+	client := start(t, replay.Options{FullFilename: mp3File})
+	defer client.Stop()
+
+	if client.Container() != replay.ContainerMP3 {
+		t.Errorf("Container() = %q", client.Container())
+	}
+	format, err := client.Format()
+	if err != nil {
+		t.Fatalf("Format failed. Err: %v", err)
+	}
+	if format.NumChannels != 2 || format.BitsPerSample != 16 || format.SampleRate == 0 {
+		t.Errorf("Format() = %+v", format)
+	}
+
+	var buf bytes.Buffer
+	if err := client.Stream(&buf); err != nil {
+		t.Fatalf("Stream failed. Err: %v", err)
+	}
+	// a few seconds of audio, not the compressed bytes
+	if seconds := buf.Len() / int(format.ByteRate); seconds < 10 || seconds > 30 {
+		t.Errorf("decoded %d bytes, %d seconds", buf.Len(), seconds)
+	}
+}
+
+func TestReplay_Passthrough(t *testing.T) {
+	client := start(t, replay.Options{FullFilename: mp3File, Passthrough: true})
+	defer client.Stop()
+
+	if client.Container() != replay.ContainerMP3 {
+		t.Errorf("Container() = %q", client.Container())
+	}
+	if _, err := client.Format(); !errors.Is(err, replay.ErrUnsupportedFormat) {
+		t.Errorf("Format() err = %v, want ErrUnsupportedFormat", err)
+	}
+
+	// the file is sent as is, even muted
+	client.Mute()
+	var buf bytes.Buffer
+	if err := client.Stream(&buf); err != nil {
+		t.Fatalf("Stream failed. Err: %v", err)
+	}
+	byFile, err := os.ReadFile(mp3File)
+	if err != nil {
+		t.Fatalf("os.ReadFile failed. Err: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), byFile) {
+		t.Errorf("streamed %d bytes, want the %d bytes of the file", buf.Len(), len(byFile))
+	}
+
+	// compressed audio has no byte rate to pace by
+	paced := start(t, replay.Options{FullFilename: mp3File, Passthrough: true, RealTime: true})
+	defer paced.Stop()
+	if err := paced.Stream(io.Discard); !errors.Is(err, replay.ErrUnsupportedFormat) {
+		t.Errorf("RealTime Stream err = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestReplay_Unsupported(t *testing.T) {
+	dir := t.TempDir()
+	for name, byData := range map[string][]byte{
+		"unknown.bin": []byte("not audio at all"),
+		"vorbis.ogg":  append([]byte("OggS"), make([]byte, 64)...),
+	} {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, byData, 0o600); err != nil {
+			t.Fatalf("os.WriteFile failed. Err: %v", err)
+		}
+
+		client, err := replay.New(replay.Options{FullFilename: file})
+		if err != nil {
+			t.Fatalf("replay.New failed. Err: %v", err)
+		}
+		if err := client.Start(); !errors.Is(err, replay.ErrUnsupportedFormat) {
+			t.Errorf("%s: Start err = %v, want ErrUnsupportedFormat", name, err)
+		}
+		client.Stop()
+	}
+}
+
+// oggPage builds an Ogg page holding a single packet shorter than 255 bytes
+func oggPage(headerType byte, seq uint32, packet []byte) []byte {
+	page := []byte("OggS")
+	page = append(page, 0, headerType)
+	page = append(page, make([]byte, 8)...) // granule position
+	page = binary.LittleEndian.AppendUint32(page, 1)
+	page = binary.LittleEndian.AppendUint32(page, seq)
+	page = append(page, 0, 0, 0, 0) // checksum
+	page = append(page, 1, byte(len(packet)))
+	page = append(page, packet...)
+
+	var crc uint32
+	for _, b := range page {
+		crc ^= uint32(b) << 24
+		for i := 0; i < 8; i++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04c11db7
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	binary.LittleEndian.PutUint32(page[22:], crc)
+	return page
+}
+
+func TestReplay_UnsupportedOpus(t *testing.T) {
+	head := append([]byte("OpusHead"), 1, 1)
+	head = binary.LittleEndian.AppendUint16(head, 312)
+	head = binary.LittleEndian.AppendUint32(head, 48000)
+	head = append(head, 0, 0, 0)
+
+	// a CELT fullband 20ms frame, what opusenc and browsers produce
+	celt := append([]byte{0xF8}, bytes.Repeat([]byte{0x55}, 60)...)
+
+	var byData []byte
+	byData = append(byData, oggPage(0x02, 0, head)...)
+	byData = append(byData, oggPage(0x00, 1, append([]byte("OpusTags"), make([]byte, 8)...))...)
+	byData = append(byData, oggPage(0x04, 2, celt)...)
+
+	file := filepath.Join(t.TempDir(), "celt.opus")
+	if err := os.WriteFile(file, byData, 0o600); err != nil {
+		t.Fatalf("os.WriteFile failed. Err: %v", err)
+	}
+
+	// rejected up front, not partway through Stream
+	client, err := replay.New(replay.Options{FullFilename: file})
+	if err != nil {
+		t.Fatalf("replay.New failed. Err: %v", err)
+	}
+	if err := client.Start(); !errors.Is(err, replay.ErrUnsupportedOpus) {
+		t.Errorf("Start err = %v, want ErrUnsupportedOpus", err)
+	}
+	client.Stop()
+
+	passthrough := start(t, replay.Options{FullFilename: file, Passthrough: true})
+	defer passthrough.Stop()
+	if passthrough.Container() != replay.ContainerOgg {
+		t.Errorf("Container() = %q, want ogg", passthrough.Container())
+	}
+}
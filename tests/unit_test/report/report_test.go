@@ -0,0 +1,132 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+	report "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/report"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/manage"
+)
+
+/* #nosec G101 */
+const mockAPIKey = "m0ckap1k3y0bbc125dac7f40ed3eb0ed232a2ff8"
+
+func request(id, created, key, method string, seconds, usd float64, models, tags []string) api.Request {
+	return api.Request{
+		RequestID: id,
+		Created:   created,
+		APIKeyID:  key,
+		Response: api.Response{
+			Details: api.Details{Usd: usd, Duration: seconds, Method: method, Models: models, Tags: tags},
+		},
+	}
+}
+
+var mockRequests = []api.Request{
+	request("1", "2024-05-01T10:00:00Z", "key-a", "sync", 3600, 0.25, []string{"nova-3"}, []string{"billing", "team-a"}),
+	request("2", "2024-05-01T12:00:00Z", "key-b", "streaming", 1800, 0.15, []string{"nova-3"}, nil),
+	request("3", "2024-05-02T09:00:00Z", "key-a", "async", 7200, 0.50, []string{"whisper"}, []string{"billing"}),
+	request("4", "2024-06-01T00:00:00Z", "key-a", "sync", 3600, 9.99, []string{"nova-3"}, nil), // out of range
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestReport_Build(t *testing.T) {
+	opts := report.Options{
+		Start:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		End:        time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Budget:     1.0,
+		LowBalance: 10,
+	}
+	balances := []api.Balance{{BalanceID: "b1", Amount: 5, Units: "usd"}}
+
+	r, err := report.Build(mockRequests, balances, opts)
+	if err != nil {
+		t.Fatalf("Build failed. Err: %v", err)
+	}
+
+	if r.Total.Requests != 3 || !almostEqual(r.Total.USD, 0.90) || !almostEqual(r.Total.Hours, 3.5) {
+		t.Errorf("unexpected total: %+v", r.Total)
+	}
+
+	tags := r.Rows(report.DimensionTag)
+	if len(tags) != 3 || tags[0].Key != "billing" || !almostEqual(tags[0].USD, 0.75) {
+		t.Errorf("unexpected tag breakdown: %+v", tags)
+	}
+
+	days := r.Rows(report.DimensionDay)
+	if len(days) != 2 || days[0].Key != "2024-05-01" || days[0].Requests != 2 {
+		t.Errorf("unexpected day breakdown: %+v", days)
+	}
+
+	keys := r.Rows(report.DimensionAPIKey)
+	if len(keys) != 2 || keys[0].Key != "key-a" || !almostEqual(keys[0].USD, 0.75) {
+		t.Errorf("unexpected api key breakdown: %+v", keys)
+	}
+
+	if len(r.Alerts) != 2 || r.Alerts[0].Kind != report.AlertBudget || r.Alerts[0].Threshold != 0.8 ||
+		r.Alerts[1].Kind != report.AlertLowBalance {
+		t.Errorf("unexpected alerts: %+v", r.Alerts)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed. Err: %v", err)
+	}
+	if !strings.Contains(buf.String(), "method,streaming,1,0.5000,0.1500") ||
+		!strings.Contains(buf.String(), "TOTAL,,3,3.5000,0.9000") {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+
+	if _, err := report.Build(nil, nil, report.Options{Dimensions: []report.Dimension{"color"}}); err != report.ErrInvalidDimension {
+		t.Errorf("expected ErrInvalidDimension, got %v", err)
+	}
+}
+
+func TestReport_Generate(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.deepgram.com/v1/projects/project-1/requests",
+		httpmock.NewJsonResponderOrPanic(200, api.RequestList{Limit: 100, Requests: mockRequests[:3]}))
+	httpmock.RegisterResponder("GET", "https://api.deepgram.com/v1/projects/project-1/balances",
+		httpmock.NewJsonResponderOrPanic(200, api.BalanceList{Balances: []api.Balance{{Amount: 100, Units: "usd"}}}))
+
+	c := client.New(mockAPIKey, &interfaces.ClientOptions{})
+	httpmock.ActivateNonDefault(&c.HTTPClient.Client)
+
+	var alerts []report.Alert
+	reporter, err := report.New(manage.New(c), "project-1", report.Options{
+		Budget:  0.5,
+		OnAlert: func(alert report.Alert) { alerts = append(alerts, alert) },
+	})
+	if err != nil {
+		t.Fatalf("report.New failed. Err: %v", err)
+	}
+
+	r, err := reporter.Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate failed. Err: %v", err)
+	}
+	if r.Total.Requests != 3 || r.Remaining != 100 {
+		t.Errorf("unexpected report: %+v", r)
+	}
+	if len(alerts) != 1 || alerts[0].Threshold != 1.0 {
+		t.Errorf("unexpected alerts: %+v", alerts)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+
+	manage "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1"
+	api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/interfaces"
+	rotation "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/manage/v1/rotation"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/manage"
+)
+
+/* #nosec G101 */
+const mockAPIKey = "m0ckap1k3y0bbc125dac7f40ed3eb0ed232a2ff8"
+
+const keysURL = "https://api.deepgram.com/v1/projects/project-1/keys"
+
+var oldKey = api.APIKey{
+	APIKeyID:       "old-key",
+	Comment:        "transcription service",
+	Scopes:         []string{"usage:write"},
+	Tags:           []string{"prod"},
+	ExpirationDate: time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339),
+}
+
+// mockKeys registers the key endpoints and records created and deleted key IDs
+func mockKeys(t *testing.T, created *api.KeyCreateRequest, deleted *[]string) {
+	httpmock.RegisterResponder("GET", keysURL+"/old-key",
+		httpmock.NewJsonResponderOrPanic(200, api.APIKeyPermission{APIKey: oldKey}))
+	httpmock.RegisterResponder("GET", keysURL,
+		httpmock.NewJsonResponderOrPanic(200, api.APIKeysList{APIKeys: []api.APIKeyPermission{
+			{APIKey: oldKey},
+			{APIKey: api.APIKey{APIKeyID: "forever"}},
+			{APIKey: api.APIKey{APIKeyID: "later", ExpirationDate: time.Now().Add(90 * 24 * time.Hour).UTC().Format(time.RFC3339)}},
+		}}))
+	httpmock.RegisterResponder("POST", keysURL, func(r *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(r.Body).Decode(created); err != nil {
+			t.Errorf("json.Decode failed. Err: %v", err)
+		}
+		return httpmock.NewJsonResponse(200, api.APIKey{APIKeyID: "new-key", Key: "s3cr3t"})
+	})
+	httpmock.RegisterResponder("DELETE", `=~^`+keysURL+`/(.+)\z`, func(r *http.Request) (*http.Response, error) {
+		id, _ := httpmock.GetSubmatch(r, 1)
+		*deleted = append(*deleted, id)
+		return httpmock.NewJsonResponse(200, api.MessageResult{Message: "deleted"})
+	})
+}
+
+func newManageClient() *manage.Client {
+	c := client.New(mockAPIKey, &interfaces.ClientOptions{})
+	httpmock.ActivateNonDefault(&c.HTTPClient.Client)
+	return manage.New(c)
+}
+
+func TestRotation_Rotate(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var created api.KeyCreateRequest
+	var deleted []string
+	mockKeys(t, &created, &deleted)
+
+	path := filepath.Join(t.TempDir(), "deepgram.key")
+	m, err := rotation.New(newManageClient(), rotation.Options{
+		Sink:        &rotation.FileSink{Path: path},
+		GracePeriod: time.Millisecond,
+		ExpiresIn:   30 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("rotation.New failed. Err: %v", err)
+	}
+
+	r, err := m.Rotate(context.Background(), "project-1", "old-key")
+	if err != nil {
+		t.Fatalf("Rotate failed. Err: %v", err)
+	}
+	if r.NewKeyID != "new-key" || r.DeletedAt.IsZero() {
+		t.Errorf("unexpected rotation: %+v", r)
+	}
+	if created.Comment != oldKey.Comment || !reflect.DeepEqual(created.Scopes, oldKey.Scopes) || !reflect.DeepEqual(created.Tags, oldKey.Tags) {
+		t.Errorf("new key does not match the old one: %+v", created)
+	}
+	if created.ExpirationDate.IsZero() {
+		t.Errorf("expected an expiration date")
+	}
+	if !reflect.DeepEqual(deleted, []string{"old-key"}) {
+		t.Errorf("expected the old key to be deleted, got %v", deleted)
+	}
+
+	byData, err := os.ReadFile(path)
+	if err != nil || string(byData) != "s3cr3t" {
+		t.Errorf("expected the new key to be published, got %q %v", byData, err)
+	}
+}
+
+func TestRotation_PublishFailureRollsBack(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var created api.KeyCreateRequest
+	var deleted []string
+	mockKeys(t, &created, &deleted)
+
+	errPublish := errors.New("vault is sealed")
+	m, err := rotation.New(newManageClient(), rotation.Options{
+		Sink: rotation.SinkFunc(func(context.Context, *rotation.Secret) error { return errPublish }),
+	})
+	if err != nil {
+		t.Fatalf("rotation.New failed. Err: %v", err)
+	}
+
+	if _, err := m.Rotate(context.Background(), "project-1", "old-key"); err != errPublish {
+		t.Errorf("expected the publish error, got %v", err)
+	}
+	if !reflect.DeepEqual(deleted, []string{"new-key"}) {
+		t.Errorf("expected only the new key to be deleted, got %v", deleted)
+	}
+}
+
+func TestRotation_DryRunExpiring(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var created api.KeyCreateRequest
+	var deleted []string
+	mockKeys(t, &created, &deleted)
+
+	m, err := rotation.New(newManageClient(), rotation.Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("rotation.New failed. Err: %v", err)
+	}
+
+	keys, err := m.ListExpiringDays(context.Background(), "project-1", 7)
+	if err != nil {
+		t.Fatalf("ListExpiringDays failed. Err: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Key.APIKeyID != "old-key" {
+		t.Errorf("unexpected expiring keys: %+v", keys)
+	}
+
+	plan, err := m.RotateExpiring(context.Background(), []string{"project-1"}, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("RotateExpiring failed. Err: %v", err)
+	}
+	if len(plan) != 1 || !plan[0].DryRun || plan[0].OldKeyID != "old-key" {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	if created.Comment != "" || len(deleted) != 0 {
+		t.Errorf("dry run changed keys")
+	}
+}
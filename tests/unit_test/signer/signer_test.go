@@ -0,0 +1,166 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dvonthenen/websocket"
+	"github.com/jarcoal/httpmock"
+
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	listenrest "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+	listenws "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/websocket"
+	signer "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/signer"
+)
+
+const mockAPIKey string = "mock-api-key"
+
+func newHMAC(t *testing.T) *signer.HMACSigner {
+	t.Helper()
+
+	s, err := signer.NewHMAC(signer.HMACOptions{
+		KeyID:         "app-1",
+		Secret:        []byte("gateway-secret"),
+		SignedHeaders: []string{"Authorization"},
+	})
+	if err != nil {
+		t.Fatalf("NewHMAC failed. Err: %v", err)
+	}
+	return s
+}
+
+func TestSigner_HMAC(t *testing.T) {
+	s := newHMAC(t)
+
+	req, _ := http.NewRequest(http.MethodPost, "https://gateway.example.com/v1/listen?model=nova-3&punctuate=true", strings.NewReader(`{"url":"https://dpgr.am/spacewalk.wav"}`))
+	req.Header.Set("Authorization", "token abc")
+	if err := s.SignRequest(req); err != nil {
+		t.Fatalf("SignRequest failed. Err: %v", err)
+	}
+	if req.Header.Get(signer.DefaultKeyIDHeader) != "app-1" || req.Header.Get(signer.DefaultSignatureHeader) == "" {
+		t.Fatalf("headers = %v", req.Header)
+	}
+	if err := s.Verify(req, time.Minute); err != nil {
+		t.Errorf("Verify failed. Err: %v", err)
+	}
+
+	// a signed header was changed
+	req.Header.Set("Authorization", "token xyz")
+	if err := s.Verify(req, time.Minute); !errors.Is(err, signer.ErrInvalidSignature) {
+		t.Errorf("Verify err = %v, want ErrInvalidSignature", err)
+	}
+
+	// streams are signed without consuming them
+	stream, _ := http.NewRequest(http.MethodPost, "https://gateway.example.com/v1/listen", struct{ *strings.Reader }{strings.NewReader("audio")})
+	if err := s.SignRequest(stream); err != nil {
+		t.Fatalf("SignRequest failed. Err: %v", err)
+	}
+	if stream.Header.Get(signer.DefaultContentHashHeader) != signer.UnsignedPayload {
+		t.Errorf("content hash = %q, want %q", stream.Header.Get(signer.DefaultContentHashHeader), signer.UnsignedPayload)
+	}
+
+	if _, err := signer.NewHMAC(signer.HMACOptions{}); !errors.Is(err, signer.ErrInvalidInput) {
+		t.Errorf("NewHMAC without a secret err = %v", err)
+	}
+}
+
+func TestSigner_REST(t *testing.T) {
+	s := newHMAC(t)
+	header := signer.NewHeader(http.Header{"X-Gateway-Tenant": []string{"acme"}})
+	ctx := interfaces.WithSigner(context.Background(), signer.Chain(header, s))
+
+	c := listenrest.New(mockAPIKey, &interfaces.ClientOptions{})
+	httpmock.ActivateNonDefault(&c.HTTPClient.Client)
+	defer httpmock.DeactivateAndReset()
+
+	var verifyErr error
+	var tenant string
+	httpmock.RegisterResponder("POST", "https://api.deepgram.com/v1/listen", func(req *http.Request) (*http.Response, error) {
+		verifyErr = s.Verify(req, time.Minute)
+		tenant = req.Header.Get("X-Gateway-Tenant")
+		return httpmock.NewStringResponse(http.StatusOK, `{"metadata":{"request_id":"abc"}}`), nil
+	})
+
+	var resp map[string]interface{}
+	if err := c.DoURL(ctx, "https://dpgr.am/spacewalk.wav", &interfaces.PreRecordedTranscriptionOptions{Model: "nova-3"}, &resp); err != nil {
+		t.Fatalf("DoURL failed. Err: %v", err)
+	}
+	if verifyErr != nil {
+		t.Errorf("gateway rejected the signature. Err: %v", verifyErr)
+	}
+	if tenant != "acme" {
+		t.Errorf("X-Gateway-Tenant = %q, want acme", tenant)
+	}
+
+	// a failing signer stops the request
+	fail := errors.New("no signing key")
+	ctx = interfaces.WithSigner(context.Background(), signer.Func(func(*http.Request) error { return fail }))
+	if err := c.DoURL(ctx, "https://dpgr.am/spacewalk.wav", &interfaces.PreRecordedTranscriptionOptions{}, &resp); !errors.Is(err, fail) {
+		t.Errorf("DoURL err = %v, want %v", err, fail)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 1 {
+		t.Errorf("total calls = %d, want 1", n)
+	}
+}
+
+func TestSigner_WebSocketHandshake(t *testing.T) {
+	s := newHMAC(t)
+
+	var mu sync.Mutex
+	var verifyErr error
+	handshakes := 0
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		handshakes++
+		verifyErr = s.Verify(r, time.Minute)
+		mu.Unlock()
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(interfaces.WithSigner(context.Background(), s))
+	defer cancel()
+
+	cOptions := &interfaces.ClientOptions{
+		APIKey: mockAPIKey,
+		Host:   "ws://" + strings.TrimPrefix(server.URL, "http://"),
+	}
+	dg, err := listenws.NewUsingChanWithCancel(ctx, cancel, "", cOptions, &interfaces.LiveTranscriptionOptions{Model: "nova-3"}, nil)
+	if err != nil {
+		t.Fatalf("NewUsingChan failed. Err: %v", err)
+	}
+	if !dg.ConnectWithCancel(ctx, cancel, 1) {
+		t.Fatalf("ConnectWithCancel failed")
+	}
+	dg.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if handshakes != 1 {
+		t.Fatalf("handshakes = %d, want 1", handshakes)
+	}
+	if verifyErr != nil {
+		t.Errorf("server rejected the handshake signature. Err: %v", verifyErr)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	client "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/rest"
+)
+
+/* #nosec G101 */
+const mockAPIKey = "m0ckap1k3y0bbc125dac7f40ed3eb0ed232a2ff8"
+
+const listenEndPoint = "https://api.deepgram.com/v1/listen"
+
+var wavHeader = append([]byte("RIFF\x24\x00\x00\x00WAVEfmt "), make([]byte, 100)...)
+
+type upload struct {
+	contentType   string
+	contentLength int64
+	query         string
+	body          []byte
+}
+
+// newClient returns a prerecorded client whose requests are recorded in got
+func newClient(t *testing.T, got *upload) *client.Client {
+	t.Helper()
+
+	c := client.New(mockAPIKey, &interfaces.ClientOptions{})
+	httpmock.ActivateNonDefault(&c.Client.HTTPClient.Client)
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("POST", listenEndPoint, func(r *http.Request) (*http.Response, error) {
+		byBody, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("io.ReadAll failed. Err: %v", err)
+		}
+		*got = upload{
+			contentType:   r.Header.Get("Content-Type"),
+			contentLength: r.ContentLength,
+			query:         r.URL.RawQuery,
+			body:          byBody,
+		}
+		return httpmock.NewJsonResponse(200, map[string]any{"metadata": map[string]any{"request_id": "req-1"}})
+	})
+	return c
+}
+
+func TestSniff_DetectContentType(t *testing.T) {
+	for want, header := range map[string][]byte{
+		audio.ContentTypeWAV:  wavHeader,
+		audio.ContentTypeFLAC: []byte("fLaC\x00\x00\x00\x22"),
+		audio.ContentTypeMP3:  []byte("ID3\x04\x00"),
+		audio.ContentTypeOgg:  []byte("OggS\x00\x02"),
+		audio.ContentTypeWebM: {0x1A, 0x45, 0xDF, 0xA3, 0x9F},
+		audio.ContentTypeMP4:  []byte("\x00\x00\x00\x20ftypM4A "),
+		audio.ContentTypeAAC:  {0xFF, 0xF1, 0x50, 0x80},
+		"":                    []byte("not audio"),
+	} {
+		if got := audio.DetectContentType(header); got != want {
+			t.Errorf("DetectContentType(%q) = %q, want %q", header, got, want)
+		}
+	}
+
+	// an mp3 frame without tags
+	if got := audio.DetectContentType([]byte{0xFF, 0xFB, 0x90, 0x64}); got != audio.ContentTypeMP3 {
+		t.Errorf("DetectContentType(mp3 frame) = %q", got)
+	}
+}
+
+func TestSniff_Containers(t *testing.T) {
+	var got upload
+	c := newClient(t, &got)
+
+	// a seeker keeps its length
+	var res map[string]any
+	if err := c.DoStream(context.Background(), bytes.NewReader(wavHeader), &interfaces.PreRecordedTranscriptionOptions{}, &res); err != nil {
+		t.Fatalf("DoStream failed. Err: %v", err)
+	}
+	if got.contentType != audio.ContentTypeWAV || got.contentLength != int64(len(wavHeader)) || !bytes.Equal(got.body, wavHeader) {
+		t.Errorf("upload = %q, %d bytes of %d", got.contentType, len(got.body), got.contentLength)
+	}
+
+	// a plain reader is not consumed by the sniffing
+	flac := append([]byte("fLaC"), bytes.Repeat([]byte{1}, 2000)...)
+	if err := c.DoStream(context.Background(), io.MultiReader(bytes.NewReader(flac)), &interfaces.PreRecordedTranscriptionOptions{}, &res); err != nil {
+		t.Fatalf("DoStream failed. Err: %v", err)
+	}
+	if got.contentType != audio.ContentTypeFLAC || !bytes.Equal(got.body, flac) {
+		t.Errorf("upload = %q, %d bytes", got.contentType, len(got.body))
+	}
+
+	// a pipe is an *os.File which cannot seek, like os.Stdin
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe failed. Err: %v", err)
+	}
+	defer pr.Close()
+	go func() {
+		_, _ = pw.Write(flac)
+		pw.Close()
+	}()
+	if err := c.DoStream(context.Background(), pr, &interfaces.PreRecordedTranscriptionOptions{}, &res); err != nil {
+		t.Fatalf("DoStream(pipe) failed. Err: %v", err)
+	}
+	if got.contentType != audio.ContentTypeFLAC || !bytes.Equal(got.body, flac) {
+		t.Errorf("pipe upload = %q, %d bytes", got.contentType, len(got.body))
+	}
+}
+
+func TestSniff_Headerless(t *testing.T) {
+	var got upload
+	c := newClient(t, &got)
+
+	pcm := bytes.Repeat([]byte{0x00, 0x01, 0xFF, 0x7F}, 800)
+	src, err := audio.NewReader(bytes.NewReader(pcm), audio.Format{Encoding: audio.EncodingLinear16, SampleRate: 16000, Channels: 2})
+	if err != nil {
+		t.Fatalf("NewReader failed. Err: %v", err)
+	}
+
+	options := &interfaces.PreRecordedTranscriptionOptions{Model: "nova-3"}
+	var res map[string]any
+	if err := c.DoStream(context.Background(), src, options, &res); err != nil {
+		t.Fatalf("DoStream failed. Err: %v", err)
+	}
+	if got.contentType != "application/octet-stream" || !bytes.Equal(got.body, pcm) {
+		t.Errorf("upload = %q, %d bytes", got.contentType, len(got.body))
+	}
+	if got.query != "channels=2&encoding=linear16&model=nova-3&sample_rate=16000" {
+		t.Errorf("query = %s", got.query)
+	}
+	// the caller's options are left alone
+	if options.Encoding != "" {
+		t.Errorf("options.Encoding = %q", options.Encoding)
+	}
+}
+
+func TestSniff_NotAudio(t *testing.T) {
+	var got upload
+	c := newClient(t, &got)
+
+	for name, byData := range map[string][]byte{
+		"json":  []byte(`{"transcript": "hello"}`),
+		"html":  []byte("<!DOCTYPE html><html></html>"),
+		"png":   []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR"),
+		"empty": {},
+	} {
+		var res map[string]any
+		if err := c.DoStream(context.Background(), bytes.NewReader(byData), &interfaces.PreRecordedTranscriptionOptions{}, &res); !errors.Is(err, client.ErrNotAudio) {
+			t.Errorf("%s: DoStream err = %v, want ErrNotAudio", name, err)
+		}
+	}
+	if count := httpmock.GetTotalCallCount(); count != 0 {
+		t.Errorf("%d uploads, want none", count)
+	}
+}
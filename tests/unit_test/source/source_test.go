@@ -0,0 +1,174 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dvonthenen/websocket"
+	wav "github.com/youpy/go-wav"
+
+	audio "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	listenws "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/websocket"
+)
+
+const mockAPIKey string = "mock-api-key"
+
+var linear16 = audio.Format{Encoding: audio.EncodingLinear16, SampleRate: 16000, Channels: 1}
+
+func TestSource_Generators(t *testing.T) {
+	tone, err := audio.NewTone(linear16, 440, 0.5, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewTone failed. Err: %v", err)
+	}
+	byTone, err := io.ReadAll(tone)
+	if err != nil || len(byTone) != 3200 {
+		t.Fatalf("tone read %d bytes, err %v", len(byTone), err)
+	}
+	if bytes.Equal(byTone[2:200], make([]byte, 198)) {
+		t.Errorf("tone is silent")
+	}
+
+	mulaw := audio.Format{Encoding: audio.EncodingMulaw, SampleRate: 8000}
+	silence, err := audio.NewSilence(mulaw, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewSilence failed. Err: %v", err)
+	}
+	bySilence, _ := io.ReadAll(silence)
+	if !bytes.Equal(bySilence, bytes.Repeat([]byte{0xFF}, 400)) {
+		t.Errorf("mulaw silence = %d bytes of %x", len(bySilence), bySilence[:1])
+	}
+
+	if _, err := audio.NewTone(mulaw, 440, 0.5, time.Second); !errors.Is(err, audio.ErrUnsupportedFormat) {
+		t.Errorf("NewTone(mulaw) err = %v", err)
+	}
+	if _, err := audio.Concat(tone, silence); !errors.Is(err, audio.ErrFormatMismatch) {
+		t.Errorf("Concat of mixed formats err = %v", err)
+	}
+
+	// Channels 0 is mono
+	mono, _ := audio.NewSilence(audio.Format{Encoding: audio.EncodingLinear16, SampleRate: 16000}, 10*time.Millisecond)
+	if _, err := audio.Concat(mono, tone); err != nil {
+		t.Errorf("Concat of equal formats failed. Err: %v", err)
+	}
+}
+
+func TestSource_File(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "stereo.wav")
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatalf("os.Create failed. Err: %v", err)
+	}
+	writer := wav.NewWriter(f, 800, 2, 8000, 16)
+	if err := writer.WriteSamples(make([]wav.Sample, 800)); err != nil {
+		t.Fatalf("WriteSamples failed. Err: %v", err)
+	}
+	f.Close()
+
+	src, err := audio.NewFile(filename)
+	if err != nil {
+		t.Fatalf("NewFile failed. Err: %v", err)
+	}
+	defer src.Close()
+
+	want := audio.Format{Encoding: audio.EncodingLinear16, SampleRate: 8000, Channels: 2}
+	if src.Format() != want {
+		t.Errorf("Format() = %+v", src.Format())
+	}
+	byData, err := io.ReadAll(src)
+	if err != nil || len(byData) != 3200 || src.Format().Duration(int64(len(byData))) != 100*time.Millisecond {
+		t.Errorf("read %d bytes, err %v", len(byData), err)
+	}
+}
+
+func TestSource_StreamSource(t *testing.T) {
+	var mu sync.Mutex
+	var query string
+	var received int
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		query = r.URL.RawQuery
+		mu.Unlock()
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			msgType, byMsg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if msgType == websocket.BinaryMessage {
+				mu.Lock()
+				received += len(byMsg)
+				mu.Unlock()
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cOptions := &interfaces.ClientOptions{
+		APIKey: mockAPIKey,
+		Host:   "ws://" + strings.TrimPrefix(server.URL, "http://"),
+	}
+	dg, err := listenws.NewUsingChanWithCancel(ctx, cancel, "", cOptions, &interfaces.LiveTranscriptionOptions{Model: "nova-3"}, nil)
+	if err != nil {
+		t.Fatalf("NewUsingChan failed. Err: %v", err)
+	}
+	defer dg.Stop()
+
+	tone, _ := audio.NewTone(linear16, 440, 0.5, 200*time.Millisecond)
+	silence, _ := audio.NewSilence(linear16, 300*time.Millisecond)
+	src, err := audio.Concat(tone, silence)
+	if err != nil {
+		t.Fatalf("Concat failed. Err: %v", err)
+	}
+
+	// connects with the source's format
+	if err := dg.StreamSource(ctx, src); err != nil {
+		t.Fatalf("StreamSource failed. Err: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	if !strings.Contains(query, "encoding=linear16") || !strings.Contains(query, "sample_rate=16000") || !strings.Contains(query, "channels=1") {
+		t.Errorf("query = %s", query)
+	}
+	if received != 16000 {
+		t.Errorf("server received %d bytes, want 16000", received)
+	}
+	mu.Unlock()
+
+	// the connection can't change format
+	other, _ := audio.NewSilence(audio.Format{Encoding: audio.EncodingMulaw, SampleRate: 8000}, time.Second)
+	if err := dg.StreamSource(ctx, other); !errors.Is(err, audio.ErrFormatMismatch) {
+		t.Errorf("StreamSource err = %v, want ErrFormatMismatch", err)
+	}
+
+	// nor does an endless source outlive its context
+	endless, _ := audio.NewSilence(linear16, 0)
+	streamCtx, streamCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer streamCancel()
+	if err := dg.StreamSource(streamCtx, endless); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("StreamSource err = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	agentapi "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/agent/v1/websocket"
+	agentinterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/agent/v1/websocket/interfaces"
+	websocketv1api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket"
+	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+)
+
+const (
+	interimResult = `{"type":"Results","is_final":false,"channel_index":[0,2],"channel":{"alternatives":[{"transcript":"hel","words":[{"word":"hel","speaker":0}]}]}}`
+	finalSpeaker0 = `{"type":"Results","is_final":true,"channel_index":[0,2],"channel":{"alternatives":[{"transcript":"hello","words":[{"word":"hello","speaker":0}]}]}}`
+	finalSpeaker1 = `{"type":"Results","is_final":true,"channel_index":[1,2],"channel":{"alternatives":[{"transcript":"hi","words":[{"word":"hi","speaker":1}]}]}}`
+	metadata      = `{"type":"Metadata","request_id":"abc"}`
+)
+
+func drain(t *testing.T, ch <-chan msginterfaces.Event) []msginterfaces.Event {
+	t.Helper()
+
+	var events []msginterfaces.Event
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return events
+			}
+			events = append(events, e)
+		case <-time.After(100 * time.Millisecond):
+			return events
+		}
+	}
+}
+
+func TestSubscribe_Filters(t *testing.T) {
+	router := websocketv1api.NewChanRouterWithOptions(context.Background(), nil, nil)
+
+	all, cancelAll := router.Subscribe(nil)
+	defer cancelAll()
+	finals, cancelFinals := router.Subscribe(&msginterfaces.EventFilter{
+		Types:   []string{string(msginterfaces.TypeMessageResponse)},
+		IsFinal: true,
+	})
+	defer cancelFinals()
+	speaker := 1
+	channel := 1
+	speaker1, cancelSpeaker1 := router.Subscribe(&msginterfaces.EventFilter{Speaker: &speaker, ChannelIndex: &channel})
+	defer cancelSpeaker1()
+
+	for _, msg := range []string{interimResult, finalSpeaker0, finalSpeaker1, metadata} {
+		if err := router.Message([]byte(msg)); err != nil {
+			t.Fatalf("Message failed. Err: %v", err)
+		}
+	}
+
+	if events := drain(t, all); len(events) != 4 || events[3].Type != "Metadata" || events[3].Metadata.RequestID != "abc" {
+		t.Errorf("all received %+v", events)
+	}
+	if events := drain(t, finals); len(events) != 2 || events[0].Message.Channel.Alternatives[0].Transcript != "hello" {
+		t.Errorf("finals received %+v", events)
+	}
+	// Metadata carries no speaker so it passes the Results conditions
+	events := drain(t, speaker1)
+	if len(events) != 2 || events[0].Message.Channel.Alternatives[0].Transcript != "hi" || events[1].Metadata == nil {
+		t.Errorf("speaker 1 received %+v", events)
+	}
+}
+
+func TestSubscribe_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	router := websocketv1api.NewChanRouterWithOptions(ctx, nil, &interfaces.ChanDeliveryOptions{Policy: interfaces.DeliveryBlock})
+
+	first, cancelFirst := router.Subscribe(nil)
+	if err := router.Message([]byte(metadata)); err != nil {
+		t.Fatalf("Message failed. Err: %v", err)
+	}
+	if _, ok := <-first; !ok {
+		t.Fatalf("first subscriber closed early")
+	}
+
+	cancelFirst()
+	cancelFirst()
+	if _, ok := <-first; ok {
+		t.Errorf("channel still open after cancel")
+	}
+
+	// a late subscriber only sees messages from now on
+	second, _ := router.Subscribe(nil)
+	if err := router.Message([]byte(finalSpeaker0)); err != nil {
+		t.Fatalf("Message failed. Err: %v", err)
+	}
+	if e := <-second; e.Message == nil {
+		t.Errorf("second subscriber received %+v", e)
+	}
+	if _, ok := router.DeliveryStats()["Subscriber#1"]; !ok {
+		t.Errorf("DeliveryStats = %v, want Subscriber#1", router.DeliveryStats())
+	}
+
+	// the connection context ends every subscription
+	cancel()
+	select {
+	case _, ok := <-second:
+		if ok {
+			t.Errorf("unexpected event after the context was canceled")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("subscriber not closed after the context was canceled")
+	}
+}
+
+func TestSubscribe_Agent(t *testing.T) {
+	router := agentapi.NewChanRouterWithOptions(context.Background(), nil, nil)
+
+	user, cancelUser := router.Subscribe(&agentinterfaces.EventFilter{
+		Types: []string{agentinterfaces.TypeConversationTextResponse},
+		Role:  "user",
+	})
+	defer cancelUser()
+
+	for _, msg := range []string{
+		`{"type":"ConversationText","role":"assistant","content":"How can I help?"}`,
+		`{"type":"ConversationText","role":"user","content":"What time is it?"}`,
+		`{"type":"AgentThinking","content":"..."}`,
+	} {
+		if err := router.Message([]byte(msg)); err != nil {
+			t.Fatalf("Message failed. Err: %v", err)
+		}
+	}
+
+	select {
+	case e := <-user:
+		if e.ConversationText == nil || e.ConversationText.Content != "What time is it?" {
+			t.Errorf("received %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the user's ConversationText")
+	}
+	select {
+	case e := <-user:
+		t.Errorf("unexpected event %+v", e)
+	default:
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	vad "github.com/deepgram/deepgram-go-sdk/v3/pkg/audio/vad"
+	listenws "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/websocket"
+)
+
+const sampleRate = 16000
+
+// the listen clients gate straight into the websocket
+var _ vad.Target = (*listenws.WSChannel)(nil)
+var _ vad.Target = (*listenws.WSCallback)(nil)
+
+// target records what the Gate sends, by the original audio position it was sent at
+type target struct {
+	gate   func() *vad.Gate
+	sent   int
+	events []string
+}
+
+func (t *target) Write(p []byte) (int, error) {
+	t.sent += len(p)
+	return len(p), nil
+}
+
+func (t *target) KeepAlive() error {
+	t.events = append(t.events, "KeepAlive")
+	return nil
+}
+
+func (t *target) Finalize() error {
+	t.events = append(t.events, fmt.Sprintf("Finalize@%v", t.gate().Stats().Sent))
+	return nil
+}
+
+func silence(d time.Duration) []byte {
+	return make([]byte, int(d.Seconds()*sampleRate)*2)
+}
+
+func tone(d time.Duration) []byte {
+	samples := int(d.Seconds() * sampleRate)
+	buf := make([]byte, samples*2)
+	for i := 0; i < samples; i++ {
+		v := 0.3 * math.Sin(2*math.Pi*440*float64(i)/sampleRate)
+		binary.LittleEndian.PutUint16(buf[i*2:], uint16(int16(v*math.MaxInt16)))
+	}
+	return buf
+}
+
+func TestVAD_Gate(t *testing.T) {
+	tgt := &target{}
+	gate, err := vad.NewGate(tgt, vad.Options{SampleRate: sampleRate})
+	if err != nil {
+		t.Fatalf("NewGate failed. Err: %v", err)
+	}
+	tgt.gate = func() *vad.Gate { return gate }
+
+	audio := [][]byte{
+		silence(time.Second),
+		tone(time.Second),
+		silence(3 * time.Second),
+		tone(500 * time.Millisecond),
+		silence(10 * time.Second),
+	}
+	for _, chunk := range audio {
+		// odd sized writes, as a source would make
+		for len(chunk) > 0 {
+			n := 999
+			if n > len(chunk) {
+				n = len(chunk)
+			}
+			if _, err := gate.Write(chunk[:n]); err != nil {
+				t.Fatalf("Write failed. Err: %v", err)
+			}
+			chunk = chunk[n:]
+		}
+	}
+
+	// 300ms pre-roll, the speech and 300ms hangover, twice. The last 300ms is held as pre-roll.
+	stats := gate.Stats()
+	if stats.Sent != 2700*time.Millisecond || stats.Skipped != 12500*time.Millisecond {
+		t.Errorf("Stats = %+v", stats)
+	}
+	if tgt.sent != 2700*sampleRate*2/1000 {
+		t.Errorf("sent %d bytes", tgt.sent)
+	}
+
+	// Finalize 2s after each utterance, then a KeepAlive every 5s of silence
+	if got := strings.Join(tgt.events, ","); got != "Finalize@1.6s,Finalize@2.7s,KeepAlive" {
+		t.Errorf("events = %s", got)
+	}
+
+	offsets := gate.Offsets()
+	want := []vad.Offset{
+		{Sent: 0, Original: 700 * time.Millisecond},
+		{Sent: 1600 * time.Millisecond, Original: 4700 * time.Millisecond},
+	}
+	if len(offsets) != len(want) || offsets[0] != want[0] || offsets[1] != want[1] {
+		t.Errorf("Offsets() = %+v, want %+v", offsets, want)
+	}
+
+	// a word 100ms into the second utterance's pre-roll
+	if got := gate.OriginalTime(1700 * time.Millisecond); got != 4800*time.Millisecond {
+		t.Errorf("OriginalTime(1.7s) = %v", got)
+	}
+	if got := gate.OriginalSeconds(0.5); math.Abs(got-1.2) > 1e-9 {
+		t.Errorf("OriginalSeconds(0.5) = %v", got)
+	}
+}
+
+func TestVAD_KeepAliveOnly(t *testing.T) {
+	tgt := &target{}
+	gate, err := vad.NewGate(tgt, vad.Options{SampleRate: sampleRate, KeepAliveInterval: 2 * time.Second, FinalizeAfter: -1})
+	if err != nil {
+		t.Fatalf("NewGate failed. Err: %v", err)
+	}
+	tgt.gate = func() *vad.Gate { return gate }
+
+	if _, err := gate.Write(silence(7 * time.Second)); err != nil {
+		t.Fatalf("Write failed. Err: %v", err)
+	}
+	if tgt.sent != 0 || len(tgt.events) != 3 {
+		t.Errorf("sent %d bytes, events %v", tgt.sent, tgt.events)
+	}
+	if len(gate.Offsets()) != 0 || gate.OriginalTime(time.Second) != time.Second {
+		t.Errorf("Offsets() = %v", gate.Offsets())
+	}
+}
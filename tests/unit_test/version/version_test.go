@@ -0,0 +1,31 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"testing"
+
+	version "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/version"
+)
+
+func TestVersion_AgentHost(t *testing.T) {
+	cases := map[string]string{
+		"":                         "wss://agent.deepgram.com/v1/agent/converse",
+		"ws://127.0.0.1:8080":      "ws://127.0.0.1:8080/v1/agent/converse",
+		"wss://agent.example.test": "wss://agent.example.test/v1/agent/converse",
+		"agent.example.test":       "wss://agent.example.test/v1/agent/converse",
+	}
+
+	for host, want := range cases {
+		got, err := version.GetAgentAPI(context.Background(), host, "", "")
+		if err != nil {
+			t.Fatalf("GetAgentAPI(%q) failed. Err: %v", host, err)
+		}
+		if got != want {
+			t.Errorf("GetAgentAPI(%q) = %s, want %s", host, got, want)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 Deepgram SDK contributors. All Rights Reserved.
+// Use of this source code is governed by a MIT license that can be found in the LICENSE file.
+// SPDX-License-Identifier: MIT
+
+package deepgram_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dvonthenen/websocket"
+
+	common "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/common/v1"
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	listenws "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen/v1/websocket"
+)
+
+const mockAPIKey string = "mock-api-key"
+
+type received struct {
+	mu       sync.Mutex
+	messages []string // "binary:<size>" or the text message
+}
+
+func (r *received) add(msgType int, byMsg []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if msgType == websocket.BinaryMessage {
+		r.messages = append(r.messages, fmt.Sprintf("binary:%d", len(byMsg)))
+		return
+	}
+	r.messages = append(r.messages, string(byMsg))
+}
+
+func (r *received) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.messages, ",")
+}
+
+func newClient(t *testing.T, ctx context.Context, cancel context.CancelFunc, url string, opts *interfaces.AsyncWriteOptions) *listenws.WSChannel {
+	t.Helper()
+
+	cOptions := &interfaces.ClientOptions{
+		APIKey:     mockAPIKey,
+		Host:       "ws://" + strings.TrimPrefix(url, "http://"),
+		AsyncWrite: opts,
+	}
+	dg, err := listenws.NewUsingChanWithCancel(ctx, cancel, "", cOptions, &interfaces.LiveTranscriptionOptions{Model: "nova-3"}, nil)
+	if err != nil {
+		t.Fatalf("NewUsingChan failed. Err: %v", err)
+	}
+	if err := dg.ConnectContext(ctx); err != nil {
+		t.Fatalf("ConnectContext failed. Err: %v", err)
+	}
+	return dg
+}
+
+func TestWriter_CoalesceAndPriority(t *testing.T) {
+	rcv := &received{}
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			msgType, byMsg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			rcv.add(msgType, byMsg)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// 100ms of 16kHz linear16 is 3200 bytes
+	dg := newClient(t, ctx, cancel, server.URL, &interfaces.AsyncWriteOptions{
+		FrameDuration:  100 * time.Millisecond,
+		BytesPerSecond: 32000,
+	})
+	defer dg.Stop()
+
+	// 9 chunks of 10ms is not a full frame, so the KeepAlive goes out ahead of it
+	for i := 0; i < 9; i++ {
+		if _, err := dg.Write(make([]byte, 320)); err != nil {
+			t.Fatalf("Write failed. Err: %v", err)
+		}
+	}
+	if err := dg.KeepAlive(); err != nil {
+		t.Fatalf("KeepAlive failed. Err: %v", err)
+	}
+	// completes the frame
+	if _, err := dg.Write(make([]byte, 320)); err != nil {
+		t.Fatalf("Write failed. Err: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	if got := rcv.String(); got != `{"type":"KeepAlive"},binary:3200` {
+		t.Errorf("server received %s", got)
+	}
+	stats := dg.WriteQueueStats()
+	if stats.Enqueued != 10 || stats.Frames != 1 || stats.Coalesced != 9 || stats.Controls != 1 || stats.Capacity != 100 {
+		t.Errorf("WriteQueueStats = %+v", stats)
+	}
+
+	// a partial frame is sent once it has waited FrameDuration
+	if _, err := dg.Write(make([]byte, 320)); err != nil {
+		t.Fatalf("Write failed. Err: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	if got := rcv.String(); got != `{"type":"KeepAlive"},binary:3200,binary:320` {
+		t.Errorf("server received %s", got)
+	}
+}
+
+func TestWriter_TryWriteQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		// never read, so the writer stalls once the socket buffers are full
+		<-release
+		conn.Close()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dg := newClient(t, ctx, cancel, server.URL, &interfaces.AsyncWriteOptions{QueueSize: 2})
+
+	chunk := make([]byte, 1024*1024)
+	var err error
+	deadline := time.Now().Add(10 * time.Second)
+	for err == nil && time.Now().Before(deadline) {
+		err = dg.TryWrite(chunk)
+	}
+	if !errors.Is(err, common.ErrWriteQueueFull) {
+		t.Errorf("TryWrite err = %v, want ErrWriteQueueFull", err)
+	}
+
+	stats := dg.WriteQueueStats()
+	if stats.Rejected != 1 || stats.Depth != 2 || stats.HighWater != 2 {
+		t.Errorf("WriteQueueStats = %+v", stats)
+	}
+
+	close(release)
+	dg.Stop()
+}
+
+func TestWriter_WriteWhileConnecting(t *testing.T) {
+	rcv := &received{}
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hold the handshake so the client stays Connecting
+		time.Sleep(300 * time.Millisecond)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			msgType, byMsg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			rcv.add(msgType, byMsg)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cOptions := &interfaces.ClientOptions{
+		APIKey:     mockAPIKey,
+		Host:       "ws://" + strings.TrimPrefix(server.URL, "http://"),
+		AsyncWrite: &interfaces.AsyncWriteOptions{},
+	}
+	dg, err := listenws.NewUsingChanWithCancel(ctx, cancel, "", cOptions, &interfaces.LiveTranscriptionOptions{Model: "nova-3"}, nil)
+	if err != nil {
+		t.Fatalf("NewUsingChan failed. Err: %v", err)
+	}
+
+	connected := make(chan error, 1)
+	go func() {
+		connected <- dg.ConnectContext(ctx)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for dg.State() != common.StateConnecting && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if state := dg.State(); state != common.StateConnecting {
+		t.Fatalf("State = %v, want StateConnecting", state)
+	}
+
+	// queued until the writer starts
+	if _, err := dg.Write(make([]byte, 320)); err != nil {
+		t.Fatalf("Write failed. Err: %v", err)
+	}
+	if err := dg.KeepAlive(); err != nil {
+		t.Fatalf("KeepAlive failed. Err: %v", err)
+	}
+
+	if err := <-connected; err != nil {
+		t.Fatalf("ConnectContext failed. Err: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	dg.Stop()
+
+	if got := rcv.String(); !strings.HasPrefix(got, `{"type":"KeepAlive"},binary:320`) {
+		t.Errorf("server received %s", got)
+	}
+}
+
+func TestWriter_StopSendsQueuedAudio(t *testing.T) {
+	rcv := &received{}
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			msgType, byMsg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			rcv.add(msgType, byMsg)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// a long frame, so the audio is still coalescing when Stop is called
+	dg := newClient(t, ctx, cancel, server.URL, &interfaces.AsyncWriteOptions{
+		FrameDuration:  10 * time.Second,
+		BytesPerSecond: 32000,
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := dg.Write(make([]byte, 320)); err != nil {
+			t.Fatalf("Write failed. Err: %v", err)
+		}
+	}
+	dg.Stop()
+
+	if got := rcv.String(); !strings.HasPrefix(got, "binary:960,") || !strings.Contains(got, "CloseStream") {
+		t.Errorf("server received %s", got)
+	}
+}